@@ -2,8 +2,9 @@ package apikey
 
 import (
 	"encoding/json"
-	"log"
 	"net/http"
+
+	zlog "github.com/rs/zerolog/log"
 )
 
 // ErrorCode representa códigos de error estandarizados
@@ -25,7 +26,9 @@ type ErrorResponse struct {
 	Detail string    `json:"detail,omitempty"`
 }
 
-// ErrorResponder permite desacoplar las respuestas de error
+// ErrorResponder permite desacoplar las respuestas de error. Es un subconjunto
+// de responder.ErrorResponder: responder.Default, errors.AuthResponder o
+// cualquier implementación compartida con middleware/chi sirve sin adaptadores.
 type ErrorResponder interface {
 	Unauthorized(w http.ResponseWriter, detail string)
 	InsufficientPermissions(w http.ResponseWriter, action string)
@@ -70,7 +73,7 @@ func respondError(w http.ResponseWriter, status int, code ErrorCode, message, de
 	w.Header().Set(responderHeaderContentType, responderMimeJSON)
 	w.WriteHeader(status)
 	if err := json.NewEncoder(w).Encode(resp); err != nil {
-		log.Printf("failed to encode error response: %v", err)
+		zlog.Error().Err(err).Int("status", status).Msg("failed to encode error response")
 	}
 }
 
@@ -0,0 +1,321 @@
+// Package catalogue builds the permission/role catalogue from Connect-Auth seed
+// JSON at runtime, exposing the same lookups as the generated constants in
+// authz/permissions and authz/roles without requiring a library release.
+package catalogue
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/AoC-Gamers/connect-libraries/authz/v2/roles"
+)
+
+// MaxPermissionBits is the number of permission bits available per scope (uint64 mask)
+const MaxPermissionBits = 64
+
+const (
+	unknownPermissionName = "UNKNOWN_PERMISSION"
+	unknownRoleName       = "Unknown"
+)
+
+// Catalogue holds permissions, groups and roles for every scope loaded from seeds
+type Catalogue struct {
+	scopes map[string]*scopeCatalogue
+}
+
+type scopeCatalogue struct {
+	seed            ScopeSeed
+	keyToBit        map[string]uint8
+	names           map[uint64]string
+	groups          map[string]uint64
+	roles           map[string]RoleSeed
+	rolePermissions map[string]uint64
+}
+
+// New validates the given seeds and builds a catalogue.
+// Seeds sharing the same scope are merged in order.
+func New(seeds ...ScopeSeed) (*Catalogue, error) {
+	merged := make(map[string]*ScopeSeed)
+	var order []string
+	for _, seed := range seeds {
+		if seed.Scope == "" {
+			return nil, errors.New("seed scope is required")
+		}
+		current, ok := merged[seed.Scope]
+		if !ok {
+			current = &ScopeSeed{Scope: seed.Scope}
+			merged[seed.Scope] = current
+			order = append(order, seed.Scope)
+		}
+		current.Permissions = append(current.Permissions, seed.Permissions...)
+		current.Groups = append(current.Groups, seed.Groups...)
+		current.Roles = append(current.Roles, seed.Roles...)
+	}
+
+	c := &Catalogue{scopes: make(map[string]*scopeCatalogue, len(merged))}
+	var errs []error
+	for _, scope := range order {
+		built, err := buildScope(*merged[scope])
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		c.scopes[scope] = built
+	}
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func buildScope(seed ScopeSeed) (*scopeCatalogue, error) {
+	sc := &scopeCatalogue{
+		keyToBit:        make(map[string]uint8, len(seed.Permissions)),
+		names:           make(map[uint64]string, len(seed.Permissions)),
+		groups:          make(map[string]uint64, len(seed.Groups)),
+		roles:           make(map[string]RoleSeed, len(seed.Roles)),
+		rolePermissions: make(map[string]uint64, len(seed.Roles)),
+	}
+
+	var errs []error
+	fail := func(format string, args ...any) {
+		errs = append(errs, fmt.Errorf("%s: "+format, append([]any{seed.Scope}, args...)...))
+	}
+
+	for _, perm := range seed.Permissions {
+		switch {
+		case perm.Key == "":
+			fail("permission with bit %d has no key", perm.Bit)
+			continue
+		case perm.Bit >= MaxPermissionBits:
+			fail("permission %s uses bit %d (max %d)", perm.Key, perm.Bit, MaxPermissionBits-1)
+			continue
+		}
+		if _, exists := sc.keyToBit[perm.Key]; exists {
+			fail("duplicated permission key %s", perm.Key)
+			continue
+		}
+		mask := uint64(1) << perm.Bit
+		if other, exists := sc.names[mask]; exists {
+			fail("permission %s reuses bit %d already assigned to %s", perm.Key, perm.Bit, other)
+			continue
+		}
+		sc.keyToBit[perm.Key] = perm.Bit
+		sc.names[mask] = perm.Key
+	}
+
+	for _, group := range seed.Groups {
+		if group.Key == "" {
+			fail("permission group has no key")
+			continue
+		}
+		if _, exists := sc.groups[group.Key]; exists {
+			fail("duplicated permission group %s", group.Key)
+			continue
+		}
+		var mask uint64
+		for _, key := range group.Permissions {
+			bit, ok := sc.keyToBit[key]
+			if !ok {
+				fail("group %s references unknown permission %s", group.Key, key)
+				continue
+			}
+			mask |= uint64(1) << bit
+		}
+		sc.groups[group.Key] = mask
+	}
+
+	for _, role := range seed.Roles {
+		if role.Name == "" {
+			fail("role has no name")
+			continue
+		}
+		if _, exists := sc.roles[role.Name]; exists {
+			fail("duplicated role %s", role.Name)
+			continue
+		}
+		var mask uint64
+		for _, group := range role.Groups {
+			groupMask, ok := sc.groups[group]
+			if !ok {
+				fail("role %s references unknown group %s", role.Name, group)
+				continue
+			}
+			mask |= groupMask
+		}
+		sc.roles[role.Name] = role
+		sc.rolePermissions[role.Name] = mask
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(seed.Permissions, func(i, j int) bool {
+		return seed.Permissions[i].Bit < seed.Permissions[j].Bit
+	})
+	sc.seed = seed
+	return sc, nil
+}
+
+// Scopes returns the loaded scope names in alphabetical order
+func (c *Catalogue) Scopes() []string {
+	scopes := make([]string, 0, len(c.scopes))
+	for scope := range c.scopes {
+		scopes = append(scopes, scope)
+	}
+	sort.Strings(scopes)
+	return scopes
+}
+
+// HasScope checks if the catalogue contains a scope
+func (c *Catalogue) HasScope(scope string) bool {
+	_, ok := c.scopes[scope]
+	return ok
+}
+
+// Seed returns the merged seed of a scope, with permissions ordered by bit
+func (c *Catalogue) Seed(scope string) (ScopeSeed, bool) {
+	sc, ok := c.scopes[scope]
+	if !ok {
+		return ScopeSeed{}, false
+	}
+	return sc.seed, true
+}
+
+// PermissionNames maps each permission bit of a scope to its key name
+func (c *Catalogue) PermissionNames(scope string) map[uint64]string {
+	result := make(map[uint64]string)
+	if sc, ok := c.scopes[scope]; ok {
+		for mask, name := range sc.names {
+			result[mask] = name
+		}
+	}
+	return result
+}
+
+// PermissionKeyToBit maps permission key names of a scope to their bit index (reverse lookup)
+func (c *Catalogue) PermissionKeyToBit(scope string) map[string]uint8 {
+	result := make(map[string]uint8)
+	if sc, ok := c.scopes[scope]; ok {
+		for key, bit := range sc.keyToBit {
+			result[key] = bit
+		}
+	}
+	return result
+}
+
+// Permission returns the bitmask of a single permission key
+func (c *Catalogue) Permission(scope, key string) (uint64, bool) {
+	sc, ok := c.scopes[scope]
+	if !ok {
+		return 0, false
+	}
+	bit, ok := sc.keyToBit[key]
+	if !ok {
+		return 0, false
+	}
+	return uint64(1) << bit, true
+}
+
+// PermissionName returns the key name of a single permission bit
+func (c *Catalogue) PermissionName(scope string, permission uint64) string {
+	if sc, ok := c.scopes[scope]; ok {
+		if name, ok := sc.names[permission]; ok {
+			return name
+		}
+	}
+	return unknownPermissionName
+}
+
+// AllPermissionNames returns all permission key names in a bitmask, ordered by bit
+func (c *Catalogue) AllPermissionNames(scope string, mask uint64) []string {
+	sc, ok := c.scopes[scope]
+	if !ok {
+		return []string{}
+	}
+
+	result := make([]string, 0, len(sc.seed.Permissions))
+	for _, perm := range sc.seed.Permissions {
+		if mask&(uint64(1)<<perm.Bit) != 0 {
+			result = append(result, perm.Key)
+		}
+	}
+	return result
+}
+
+// GroupPermissions returns the bitmask of a permission group
+func (c *Catalogue) GroupPermissions(scope, group string) (uint64, bool) {
+	sc, ok := c.scopes[scope]
+	if !ok {
+		return 0, false
+	}
+	mask, ok := sc.groups[group]
+	return mask, ok
+}
+
+// RolePermissions returns the permission bitmask for a role (0 if unknown)
+func (c *Catalogue) RolePermissions(scope, role string) uint64 {
+	if sc, ok := c.scopes[scope]; ok {
+		return sc.rolePermissions[role]
+	}
+	return 0
+}
+
+// RoleName returns the human-readable label of a role ("Unknown" if not found)
+func (c *Catalogue) RoleName(scope, role string) string {
+	if sc, ok := c.scopes[scope]; ok {
+		if seed, ok := sc.roles[role]; ok {
+			return seed.Label
+		}
+	}
+	return unknownRoleName
+}
+
+// IsRoleValid checks if a role identifier exists in a scope
+func (c *Catalogue) IsRoleValid(scope, role string) bool {
+	sc, ok := c.scopes[scope]
+	if !ok {
+		return false
+	}
+	_, ok = sc.roles[role]
+	return ok
+}
+
+// Role returns a role definition by scope and name
+func (c *Catalogue) Role(scope, name string) (roles.Role, bool) {
+	sc, ok := c.scopes[scope]
+	if !ok {
+		return roles.Role{}, false
+	}
+	seed, ok := sc.roles[name]
+	if !ok {
+		return roles.Role{}, false
+	}
+	return toRole(scope, seed), true
+}
+
+// Roles returns all roles of a scope in seed order
+func (c *Catalogue) Roles(scope string) []roles.Role {
+	sc, ok := c.scopes[scope]
+	if !ok {
+		return []roles.Role{}
+	}
+
+	result := make([]roles.Role, 0, len(sc.seed.Roles))
+	for _, seed := range sc.seed.Roles {
+		result = append(result, toRole(scope, seed))
+	}
+	return result
+}
+
+func toRole(scope string, seed RoleSeed) roles.Role {
+	return roles.Role{
+		Scope:       scope,
+		Name:        seed.Name,
+		Label:       seed.Label,
+		Description: seed.Description,
+		Groups:      append([]string(nil), seed.Groups...),
+	}
+}
@@ -0,0 +1,113 @@
+package catalogue
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/AoC-Gamers/connect-libraries/authz/v2/permissions"
+	"github.com/AoC-Gamers/connect-libraries/authz/v2/roles"
+)
+
+const (
+	testScopeTeam = "TEAM"
+	testScopeWeb  = "WEB"
+)
+
+func loadTestCatalogue(t *testing.T) *Catalogue {
+	t.Helper()
+	c, err := LoadDir("../seeds")
+	if err != nil {
+		t.Fatalf("unexpected load error: %v", err)
+	}
+	return c
+}
+
+func TestLoadDirMatchesCompiledConstants(t *testing.T) {
+	c := loadTestCatalogue(t)
+
+	if got := c.Scopes(); strings.Join(got, ",") != "COMMUNITY,LOBBY,TEAM,WEB" {
+		t.Fatalf("unexpected scopes: %v", got)
+	}
+	if err := VerifyCompiled(c); err != nil {
+		t.Fatalf("expected catalogue to match compiled constants: %v", err)
+	}
+}
+
+func TestCatalogueLookups(t *testing.T) {
+	c := loadTestCatalogue(t)
+
+	if c.RolePermissions(testScopeTeam, roles.TEAM_STAFF) != permissions.GetTeamRolePermissions(roles.TEAM_STAFF) {
+		t.Fatalf("unexpected team staff mask")
+	}
+	if c.RolePermissions(testScopeTeam, "unknown") != 0 {
+		t.Fatalf("expected unknown role permissions to be zero")
+	}
+	if c.RoleName(testScopeTeam, "unknown") != "Unknown" {
+		t.Fatalf("expected unknown role name fallback")
+	}
+	if !c.IsRoleValid(testScopeWeb, roles.WEB_OWNER) || c.IsRoleValid(testScopeWeb, roles.TEAM_OWNER) {
+		t.Fatalf("unexpected role validity")
+	}
+
+	mask := permissions.WebTeamsEdit | permissions.WebTeamView
+	got := c.AllPermissionNames(testScopeWeb, mask)
+	want := permissions.GetAllPermissionNames(mask)
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	if c.PermissionName(testScopeWeb, 1<<60) != "UNKNOWN_PERMISSION" {
+		t.Fatalf("expected unknown permission fallback")
+	}
+	if c.PermissionKeyToBit(testScopeWeb)[permissions.PermWebRolesEdit] != 39 {
+		t.Fatalf("unexpected bit for %s", permissions.PermWebRolesEdit)
+	}
+
+	role, ok := c.Role(testScopeTeam, roles.TEAM_OWNER)
+	if !ok || role.Scope != testScopeTeam || len(role.Groups) != 3 {
+		t.Fatalf("unexpected role: %+v", role)
+	}
+}
+
+func TestNewValidation(t *testing.T) {
+	_, err := New(ScopeSeed{
+		Scope: testScopeTeam,
+		Permissions: []PermissionSeed{
+			{Key: "TEAM__A", Bit: 0},
+			{Key: "TEAM__B", Bit: 0},
+			{Key: "TEAM__C", Bit: 64},
+		},
+		Groups: []GroupSeed{{Key: "TEAM__BASIC", Permissions: []string{"TEAM__MISSING"}}},
+		Roles:  []RoleSeed{{Name: "team_user", Groups: []string{"TEAM__NOPE"}}},
+	})
+	if err == nil {
+		t.Fatalf("expected validation error")
+	}
+
+	for _, fragment := range []string{"reuses bit 0", "bit 64", "unknown permission TEAM__MISSING", "unknown group TEAM__NOPE"} {
+		if !strings.Contains(err.Error(), fragment) {
+			t.Fatalf("expected error to mention %q, got: %v", fragment, err)
+		}
+	}
+}
+
+func TestVerifyCompiledReportsDrift(t *testing.T) {
+	c, err := Parse([]byte(`{
+		"scope": "LOBBY",
+		"permissions": [{"key": "LOBBY__KICK", "bit": 9}],
+		"groups": [{"key": "LOBBY__STAFF", "permissions": ["LOBBY__KICK"]}],
+		"roles": [{"name": "lobby_staff", "label": "Lobby Staff", "groups": ["LOBBY__STAFF"]}]
+	}`))
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+
+	err = VerifyCompiled(c)
+	if err == nil {
+		t.Fatalf("expected drift to be reported")
+	}
+	for _, fragment := range []string{"LOBBY__KICK is bit 9", "LOBBY__DISBAND is missing", "role lobby_owner is missing"} {
+		if !strings.Contains(err.Error(), fragment) {
+			t.Fatalf("expected error to mention %q, got: %v", fragment, err)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package catalogue
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/AoC-Gamers/connect-libraries/authz/v2/permissions"
+	"github.com/AoC-Gamers/connect-libraries/authz/v2/roles"
+)
+
+// VerifyCompiled checks that the compiled constants in authz/permissions and
+// authz/roles match the catalogue: same permission keys and bits, same roles
+// with the same groups and resulting masks. Every mismatch is reported.
+func VerifyCompiled(c *Catalogue) error {
+	var errs []error
+	for _, scope := range c.Scopes() {
+		compiled, ok := permissions.GetScopeTables(scope)
+		if !ok {
+			errs = append(errs, fmt.Errorf("%s: scope has no compiled constants", scope))
+			continue
+		}
+		errs = append(errs, verifyScope(scope, c.scopes[scope], compiled)...)
+	}
+	return errors.Join(errs...)
+}
+
+func verifyScope(scope string, sc *scopeCatalogue, compiled permissions.ScopeTables) []error {
+	var errs []error
+
+	for _, key := range sortedKeys(sc.keyToBit) {
+		bit := sc.keyToBit[key]
+		compiledBit, ok := compiled.KeyToBit[key]
+		switch {
+		case !ok:
+			errs = append(errs, fmt.Errorf("%s: permission %s (bit %d) is not compiled", scope, key, bit))
+		case compiledBit != bit:
+			errs = append(errs, fmt.Errorf("%s: permission %s is bit %d in catalogue but bit %d compiled", scope, key, bit, compiledBit))
+		}
+	}
+	for _, key := range sortedKeys(compiled.KeyToBit) {
+		if _, ok := sc.keyToBit[key]; !ok {
+			errs = append(errs, fmt.Errorf("%s: compiled permission %s is missing from catalogue", scope, key))
+		}
+	}
+
+	for _, seed := range sc.seed.Roles {
+		role, ok := roles.GetRole(scope, seed.Name)
+		if !ok {
+			errs = append(errs, fmt.Errorf("%s: role %s is not compiled", scope, seed.Name))
+			continue
+		}
+		if !slices.Equal(role.Groups, seed.Groups) {
+			errs = append(errs, fmt.Errorf("%s: role %s groups %v differ from compiled %v", scope, seed.Name, seed.Groups, role.Groups))
+		}
+		if role.Label != seed.Label {
+			errs = append(errs, fmt.Errorf("%s: role %s label %q differs from compiled %q", scope, seed.Name, seed.Label, role.Label))
+		}
+		if mask, compiledMask := sc.rolePermissions[seed.Name], compiled.RolePermissions(seed.Name); mask != compiledMask {
+			errs = append(errs, fmt.Errorf("%s: role %s mask %#x differs from compiled %#x", scope, seed.Name, mask, compiledMask))
+		}
+	}
+	for _, role := range roles.GetAllRoles(scope) {
+		if _, ok := sc.roles[role.Name]; !ok {
+			errs = append(errs, fmt.Errorf("%s: compiled role %s is missing from catalogue", scope, role.Name))
+		}
+	}
+
+	return errs
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
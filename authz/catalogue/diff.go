@@ -0,0 +1,184 @@
+package catalogue
+
+import (
+	"fmt"
+	"slices"
+)
+
+// MaskDiff lists the permissions added and removed between two masks
+type MaskDiff struct {
+	Added       []string
+	Removed     []string
+	AddedMask   uint64
+	RemovedMask uint64
+}
+
+// IsEmpty reports whether both masks were equal
+func (d MaskDiff) IsEmpty() bool {
+	return d.AddedMask == 0 && d.RemovedMask == 0
+}
+
+// DiffMasks compares two masks of the same scope, naming bits with this catalogue
+func (c *Catalogue) DiffMasks(scope string, before, after uint64) MaskDiff {
+	added := after &^ before
+	removed := before &^ after
+	return MaskDiff{
+		Added:       c.AllPermissionNames(scope, added),
+		Removed:     c.AllPermissionNames(scope, removed),
+		AddedMask:   added,
+		RemovedMask: removed,
+	}
+}
+
+// RoleDiff lists the permission keys a role gains and loses between catalogues
+type RoleDiff struct {
+	Role    string
+	Added   []string
+	Removed []string
+	// Created and Deleted are set when the role exists in only one catalogue
+	Created bool
+	Deleted bool
+}
+
+// ScopeDiff describes the changes of one scope
+type ScopeDiff struct {
+	Scope              string
+	AddedPermissions   []string
+	RemovedPermissions []string
+	// Roles holds only the roles whose permissions changed, ordered by name
+	Roles []RoleDiff
+}
+
+// Diff describes the changes between two catalogues, ordered by scope
+type Diff struct {
+	Scopes []ScopeDiff
+}
+
+// IsEmpty reports whether the catalogues grant the same permissions
+func (d Diff) IsEmpty() bool {
+	return len(d.Scopes) == 0
+}
+
+// Role returns the diff of a role, if it changed
+func (d Diff) Role(scope, role string) (RoleDiff, bool) {
+	for _, sd := range d.Scopes {
+		if sd.Scope != scope {
+			continue
+		}
+		for _, rd := range sd.Roles {
+			if rd.Role == role {
+				return rd, true
+			}
+		}
+	}
+	return RoleDiff{}, false
+}
+
+// Compare reports permissions and role grants that changed from before to
+// after. Permissions are matched by key, so the diff is independent of bits.
+// Label and description changes are not reported.
+func Compare(before, after *Catalogue) Diff {
+	var diff Diff
+	for _, scope := range unionStrings(before.Scopes(), after.Scopes()) {
+		sd := ScopeDiff{Scope: scope}
+
+		beforeKeys := before.PermissionKeyToBit(scope)
+		afterKeys := after.PermissionKeyToBit(scope)
+		sd.AddedPermissions = missingKeys(afterKeys, beforeKeys)
+		sd.RemovedPermissions = missingKeys(beforeKeys, afterKeys)
+
+		for _, role := range unionStrings(roleNames(before, scope), roleNames(after, scope)) {
+			oldKeys := before.AllPermissionNames(scope, before.RolePermissions(scope, role))
+			newKeys := after.AllPermissionNames(scope, after.RolePermissions(scope, role))
+			rd := RoleDiff{
+				Role:    role,
+				Added:   subtract(newKeys, oldKeys),
+				Removed: subtract(oldKeys, newKeys),
+				Created: !before.IsRoleValid(scope, role),
+				Deleted: !after.IsRoleValid(scope, role),
+			}
+			if len(rd.Added) > 0 || len(rd.Removed) > 0 || rd.Created || rd.Deleted {
+				sd.Roles = append(sd.Roles, rd)
+			}
+		}
+
+		if len(sd.AddedPermissions) > 0 || len(sd.RemovedPermissions) > 0 || len(sd.Roles) > 0 {
+			diff.Scopes = append(diff.Scopes, sd)
+		}
+	}
+	return diff
+}
+
+// RecalculateAllowMask migrates an allow mask stored for a membership when the
+// catalogue changes from before to after, optionally moving the member from
+// fromRole to toRole (pass the same role for a seed-only change).
+//
+// The result is the new role's default mask plus the customizations of the
+// stored mask relative to the old role: extra permissions are kept and removed
+// role permissions stay removed. Customizations are matched by key, and keys
+// that no longer exist are dropped.
+func RecalculateAllowMask(before, after *Catalogue, scope, fromRole, toRole string, stored uint64) (uint64, error) {
+	if !before.IsRoleValid(scope, fromRole) {
+		return 0, fmt.Errorf("role %s/%s not found in previous catalogue", scope, fromRole)
+	}
+	if !after.IsRoleValid(scope, toRole) {
+		return 0, fmt.Errorf("role %s/%s not found in new catalogue", scope, toRole)
+	}
+
+	oldDefault := before.RolePermissions(scope, fromRole)
+	extras := translateMask(before, after, scope, stored&^oldDefault)
+	stripped := translateMask(before, after, scope, oldDefault&^stored)
+
+	return (after.RolePermissions(scope, toRole) &^ stripped) | extras, nil
+}
+
+// translateMask maps the bits of mask from one catalogue to the other by key
+func translateMask(from, to *Catalogue, scope string, mask uint64) uint64 {
+	var result uint64
+	for _, key := range from.AllPermissionNames(scope, mask) {
+		if bit, ok := to.Permission(scope, key); ok {
+			result |= bit
+		}
+	}
+	return result
+}
+
+func roleNames(c *Catalogue, scope string) []string {
+	var names []string
+	for _, role := range c.Roles(scope) {
+		names = append(names, role.Name)
+	}
+	return names
+}
+
+func missingKeys(from, other map[string]uint8) []string {
+	var result []string
+	for _, key := range sortedKeys(from) {
+		if _, ok := other[key]; !ok {
+			result = append(result, key)
+		}
+	}
+	return result
+}
+
+// subtract returns the items of a that are not in b, keeping the order of a
+func subtract(a, b []string) []string {
+	var result []string
+	for _, item := range a {
+		if !slices.Contains(b, item) {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+func unionStrings(a, b []string) []string {
+	result := append([]string(nil), a...)
+	for _, item := range b {
+		if !slices.Contains(result, item) {
+			result = append(result, item)
+		}
+	}
+	slices.Sort(result)
+	return result
+}
@@ -0,0 +1,96 @@
+package catalogue
+
+import (
+	"strings"
+	"testing"
+)
+
+func diffTestCatalogue(t *testing.T, staffGroups []string, extra ...PermissionSeed) *Catalogue {
+	t.Helper()
+	seed := ScopeSeed{
+		Scope: testScopeTeam,
+		Permissions: append([]PermissionSeed{
+			{Key: "TEAM__VIEW", Bit: 0},
+			{Key: "TEAM__EDIT", Bit: 1},
+			{Key: "TEAM__KICK", Bit: 2},
+		}, extra...),
+		Groups: []GroupSeed{
+			{Key: "TEAM__BASIC", Permissions: []string{"TEAM__VIEW"}},
+			{Key: "TEAM__STAFF", Permissions: []string{"TEAM__EDIT"}},
+			{Key: "TEAM__MOD", Permissions: []string{"TEAM__KICK"}},
+		},
+		Roles: []RoleSeed{
+			{Name: "team_user", Groups: []string{"TEAM__BASIC"}},
+			{Name: "team_staff", Groups: staffGroups},
+		},
+	}
+	c, err := New(seed)
+	if err != nil {
+		t.Fatalf("unexpected catalogue error: %v", err)
+	}
+	return c
+}
+
+func TestCompareCatalogues(t *testing.T) {
+	before := diffTestCatalogue(t, []string{"TEAM__BASIC", "TEAM__STAFF"})
+	after := diffTestCatalogue(t, []string{"TEAM__BASIC", "TEAM__MOD"}, PermissionSeed{Key: "TEAM__BAN", Bit: 3})
+
+	if !Compare(before, before).IsEmpty() {
+		t.Fatalf("expected identical catalogues to have an empty diff")
+	}
+
+	diff := Compare(before, after)
+	if len(diff.Scopes) != 1 || strings.Join(diff.Scopes[0].AddedPermissions, ",") != "TEAM__BAN" {
+		t.Fatalf("unexpected scope diff: %+v", diff.Scopes)
+	}
+
+	rd, ok := diff.Role(testScopeTeam, "team_staff")
+	if !ok {
+		t.Fatalf("expected team_staff to change")
+	}
+	if strings.Join(rd.Added, ",") != "TEAM__KICK" || strings.Join(rd.Removed, ",") != "TEAM__EDIT" {
+		t.Fatalf("unexpected role diff: %+v", rd)
+	}
+	if _, ok := diff.Role(testScopeTeam, "team_user"); ok {
+		t.Fatalf("did not expect unchanged role in diff")
+	}
+}
+
+func TestDiffMasks(t *testing.T) {
+	c := diffTestCatalogue(t, []string{"TEAM__BASIC"})
+
+	diff := c.DiffMasks(testScopeTeam, 0b011, 0b110)
+	if strings.Join(diff.Added, ",") != "TEAM__KICK" || strings.Join(diff.Removed, ",") != "TEAM__VIEW" {
+		t.Fatalf("unexpected mask diff: %+v", diff)
+	}
+	if !c.DiffMasks(testScopeTeam, 1, 1).IsEmpty() {
+		t.Fatalf("expected empty diff for equal masks")
+	}
+}
+
+func TestRecalculateAllowMask(t *testing.T) {
+	before := diffTestCatalogue(t, []string{"TEAM__BASIC", "TEAM__STAFF"})
+	after := diffTestCatalogue(t, []string{"TEAM__BASIC", "TEAM__STAFF", "TEAM__MOD"})
+
+	// Default staff mask picks up the new group
+	mask, err := RecalculateAllowMask(before, after, testScopeTeam, "team_staff", "team_staff", 0b011)
+	if err != nil || mask != 0b111 {
+		t.Fatalf("expected 0b111, got %#b (%v)", mask, err)
+	}
+
+	// A stripped permission stays stripped
+	mask, _ = RecalculateAllowMask(before, after, testScopeTeam, "team_staff", "team_staff", 0b001)
+	if mask != 0b101 {
+		t.Fatalf("expected stripped TEAM__EDIT to stay removed, got %#b", mask)
+	}
+
+	// Upgrading a user with a custom grant keeps the grant
+	mask, _ = RecalculateAllowMask(before, after, testScopeTeam, "team_user", "team_staff", 0b101)
+	if mask != 0b111 {
+		t.Fatalf("expected upgraded mask 0b111, got %#b", mask)
+	}
+
+	if _, err := RecalculateAllowMask(before, after, testScopeTeam, "missing", "team_staff", 0); err == nil {
+		t.Fatalf("expected error for unknown role")
+	}
+}
@@ -0,0 +1,120 @@
+package catalogue
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// PermissionSeed describes a single permission bit as declared in Connect-Auth seeds
+type PermissionSeed struct {
+	Key         string `json:"key"`
+	Bit         uint8  `json:"bit"`
+	Description string `json:"description,omitempty"`
+}
+
+// GroupSeed describes a named set of permissions that roles are composed of
+type GroupSeed struct {
+	Key         string   `json:"key"`
+	Description string   `json:"description,omitempty"`
+	Permissions []string `json:"permissions"`
+}
+
+// RoleSeed describes a role as the list of permission groups it grants
+type RoleSeed struct {
+	Name        string   `json:"name"`
+	Label       string   `json:"label"`
+	Description string   `json:"description,omitempty"`
+	Groups      []string `json:"groups"`
+}
+
+// ScopeSeed is the seed document for one scope (WEB, COMMUNITY, TEAM, LOBBY).
+// Permissions, groups and roles may be split across several documents with the
+// same scope (as in Connect-Auth/seeds/permissions and seeds/roles); they are
+// merged when the catalogue is built.
+type ScopeSeed struct {
+	Scope       string           `json:"scope"`
+	Permissions []PermissionSeed `json:"permissions,omitempty"`
+	Groups      []GroupSeed      `json:"groups,omitempty"`
+	Roles       []RoleSeed       `json:"roles,omitempty"`
+}
+
+// DecodeSeed reads a single scope seed document
+func DecodeSeed(r io.Reader) (ScopeSeed, error) {
+	var seed ScopeSeed
+
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&seed); err != nil {
+		return ScopeSeed{}, fmt.Errorf("decode seed: %w", err)
+	}
+	return seed, nil
+}
+
+// Parse builds a catalogue from raw seed documents
+func Parse(documents ...[]byte) (*Catalogue, error) {
+	seeds := make([]ScopeSeed, 0, len(documents))
+	for i, document := range documents {
+		seed, err := DecodeSeed(bytes.NewReader(document))
+		if err != nil {
+			return nil, fmt.Errorf("document %d: %w", i, err)
+		}
+		seeds = append(seeds, seed)
+	}
+	return New(seeds...)
+}
+
+// LoadFiles builds a catalogue from seed files on disk
+func LoadFiles(paths ...string) (*Catalogue, error) {
+	seeds := make([]ScopeSeed, 0, len(paths))
+	for _, path := range paths {
+		seed, err := loadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		seeds = append(seeds, seed)
+	}
+	return New(seeds...)
+}
+
+// LoadDir builds a catalogue from every *.json seed file found in dir
+// (recursively, so the seeds/permissions + seeds/roles layout works as-is)
+func LoadDir(dir string) (*Catalogue, error) {
+	var paths []string
+	err := filepath.WalkDir(dir, func(path string, entry os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !entry.IsDir() && filepath.Ext(path) == ".json" {
+			paths = append(paths, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("scan seeds dir %s: %w", dir, err)
+	}
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("no seed files found in %s", dir)
+	}
+
+	sort.Strings(paths)
+	return LoadFiles(paths...)
+}
+
+func loadFile(path string) (ScopeSeed, error) {
+	file, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return ScopeSeed{}, fmt.Errorf("open seed %s: %w", path, err)
+	}
+	defer func() { _ = file.Close() }()
+
+	seed, err := DecodeSeed(file)
+	if err != nil {
+		return ScopeSeed{}, fmt.Errorf("%s: %w", path, err)
+	}
+	return seed, nil
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/AoC-Gamers/connect-libraries/authz/v2/catalogue"
+)
+
+// printDiff writes the changes from the seeds in previousDir to seedsDir, in a
+// form meant for release notes when bumping POLICY_VERSION
+func printDiff(w io.Writer, previousDir, seedsDir string) error {
+	before, err := catalogue.LoadDir(previousDir)
+	if err != nil {
+		return fmt.Errorf("load previous seeds: %w", err)
+	}
+	after, err := catalogue.LoadDir(seedsDir)
+	if err != nil {
+		return fmt.Errorf("load seeds: %w", err)
+	}
+
+	diff := catalogue.Compare(before, after)
+	if diff.IsEmpty() {
+		_, err = fmt.Fprintln(w, "no permission changes")
+		return err
+	}
+
+	var b strings.Builder
+	for _, sd := range diff.Scopes {
+		fmt.Fprintf(&b, "%s\n", sd.Scope)
+		writeKeys(&b, "  + permission", sd.AddedPermissions)
+		writeKeys(&b, "  - permission", sd.RemovedPermissions)
+		for _, rd := range sd.Roles {
+			switch {
+			case rd.Created:
+				fmt.Fprintf(&b, "  role %s (new)\n", rd.Role)
+			case rd.Deleted:
+				fmt.Fprintf(&b, "  role %s (removed)\n", rd.Role)
+			default:
+				fmt.Fprintf(&b, "  role %s\n", rd.Role)
+			}
+			writeKeys(&b, "    +", rd.Added)
+			writeKeys(&b, "    -", rd.Removed)
+		}
+	}
+	_, err = io.WriteString(w, b.String())
+	return err
+}
+
+func writeKeys(b *strings.Builder, prefix string, keys []string) {
+	for _, key := range keys {
+		fmt.Fprintf(b, "%s %s\n", prefix, key)
+	}
+}
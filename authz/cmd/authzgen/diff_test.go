@@ -0,0 +1,48 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestPrintDiff(t *testing.T) {
+	var out bytes.Buffer
+	if err := printDiff(&out, testSeedsDir, testSeedsDir); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.TrimSpace(out.String()) != "no permission changes" {
+		t.Fatalf("unexpected output for identical seeds: %q", out.String())
+	}
+
+	// Previous seeds where team staff did not have the TEAM__STAFF group
+	previous := t.TempDir()
+	team, err := os.ReadFile(filepath.Join(testSeedsDir, "team.json"))
+	if err != nil {
+		t.Fatalf("read team seed: %v", err)
+	}
+	staff := `"groups": [
+        "TEAM__BASIC",
+        "TEAM__STAFF"
+      ]`
+	if !bytes.Contains(team, []byte(staff)) {
+		t.Fatalf("team seed layout changed, update the test fixture")
+	}
+	team = bytes.Replace(team, []byte(staff), []byte(`"groups": ["TEAM__BASIC"]`), 1)
+	if err := os.WriteFile(filepath.Join(previous, "team.json"), team, 0o600); err != nil {
+		t.Fatalf("write previous seed: %v", err)
+	}
+
+	out.Reset()
+	if err := printDiff(&out, previous, testSeedsDir); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got := out.String()
+	for _, want := range []string{"TEAM\n", "  role team_staff\n", "    + TEAM__SERVER_ADD\n", "COMMUNITY\n"} {
+		if !strings.Contains(got, want) {
+			t.Fatalf("expected %q in diff output:\n%s", want, got)
+		}
+	}
+}
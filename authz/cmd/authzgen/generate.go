@@ -0,0 +1,189 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/format"
+	"path"
+	"strings"
+	"text/template"
+
+	"github.com/AoC-Gamers/connect-libraries/authz/v2/catalogue"
+)
+
+type permissionView struct {
+	Key     string
+	Ident   string
+	Bit     uint8
+	Comment string
+}
+
+type groupView struct {
+	Key         string
+	Ident       string
+	Description string
+	Members     []string
+}
+
+type roleView struct {
+	Name        string
+	Label       string
+	Title       string
+	Description string
+	KeyIdent    string
+	PresetIdent string
+	ConstIdent  string
+	VarIdent    string
+	Groups      []string
+	GroupKeys   []string
+}
+
+type scopeView struct {
+	Source      string
+	Names       scopeNames
+	Permissions []permissionView
+	Groups      []groupView
+	Roles       []roleView
+}
+
+// generate renders every file owned by the generator, keyed by path relative
+// to the authz module root
+func generate(c *catalogue.Catalogue, seedsDir string) (map[string][]byte, error) {
+	files := make(map[string][]byte)
+	scopes := c.Scopes()
+
+	for _, scope := range scopes {
+		seed, _ := c.Seed(scope)
+		view, err := buildScopeView(seed, path.Join(seedsDir, newScopeNames(scope).File+".json"))
+		if err != nil {
+			return nil, err
+		}
+
+		if err := render(files, path.Join("permissions", view.Names.File+".go"), permissionsTemplate, view); err != nil {
+			return nil, err
+		}
+		if err := render(files, path.Join("roles", view.Names.File+".go"), rolesTemplate, view); err != nil {
+			return nil, err
+		}
+	}
+
+	index := struct {
+		Source string
+		Scopes []string
+	}{Source: path.Join(seedsDir, "*.json"), Scopes: scopes}
+	if err := render(files, path.Join("roles", "roles.go"), rolesIndexTemplate, index); err != nil {
+		return nil, err
+	}
+
+	names := make([]scopeNames, 0, len(scopes))
+	for _, scope := range scopes {
+		names = append(names, newScopeNames(scope))
+	}
+	permissionsIndex := struct {
+		Source string
+		Scopes []scopeNames
+	}{Source: index.Source, Scopes: names}
+	if err := render(files, path.Join("permissions", "scopes.go"), permissionsIndexTemplate, permissionsIndex); err != nil {
+		return nil, err
+	}
+
+	return files, nil
+}
+
+func buildScopeView(seed catalogue.ScopeSeed, source string) (scopeView, error) {
+	view := scopeView{Source: source, Names: newScopeNames(seed.Scope)}
+	idents := make(map[string]string)
+	var errs []error
+	claim := func(ident, owner string) {
+		if previous, ok := idents[ident]; ok {
+			errs = append(errs, fmt.Errorf("%s: %s and %s both map to identifier %s", seed.Scope, previous, owner, ident))
+			return
+		}
+		idents[ident] = owner
+	}
+
+	permIdents := make(map[string]string, len(seed.Permissions))
+	for _, perm := range seed.Permissions {
+		ident := keyIdent(perm.Key)
+		claim(ident, perm.Key)
+		permIdents[perm.Key] = ident
+
+		comment := sentence(perm.Description)
+		if comment == "" {
+			comment = "is the " + perm.Key + " permission bit."
+		}
+		view.Permissions = append(view.Permissions, permissionView{
+			Key:     perm.Key,
+			Ident:   ident,
+			Bit:     perm.Bit,
+			Comment: comment,
+		})
+	}
+
+	groupIdents := make(map[string]string, len(seed.Groups))
+	for _, group := range seed.Groups {
+		ident := keyIdent(group.Key)
+		claim(ident, group.Key)
+		groupIdents[group.Key] = ident
+
+		members := make([]string, 0, len(group.Permissions))
+		for _, key := range group.Permissions {
+			members = append(members, permIdents[key])
+		}
+		view.Groups = append(view.Groups, groupView{
+			Key:         group.Key,
+			Ident:       ident,
+			Description: group.Description,
+			Members:     members,
+		})
+	}
+
+	for _, role := range seed.Roles {
+		name := camel(role.Name)
+		presetIdent := "Role" + name
+		claim(presetIdent, role.Name)
+
+		groups := make([]string, 0, len(role.Groups))
+		for _, key := range role.Groups {
+			groups = append(groups, groupIdents[key])
+		}
+		view.Roles = append(view.Roles, roleView{
+			Name:        role.Name,
+			Label:       role.Label,
+			Title:       titleWords(role.Name),
+			Description: role.Description,
+			KeyIdent:    presetIdent + "Key",
+			PresetIdent: presetIdent,
+			ConstIdent:  roleConst(view.Names.Scope, role.Name),
+			VarIdent:    view.Names.Scope + name,
+			Groups:      groups,
+			GroupKeys:   role.Groups,
+		})
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return scopeView{}, err
+	}
+	return view, nil
+}
+
+// roleConst builds the roles package constant (team_user -> TEAM_USER) without
+// repeating the scope when the role name already carries it
+func roleConst(scope, roleName string) string {
+	name := strings.TrimPrefix(strings.ToLower(roleName), strings.ToLower(scope)+"_")
+	return scope + "_" + strings.ToUpper(name)
+}
+
+func render(files map[string][]byte, name string, tmpl *template.Template, data any) error {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return fmt.Errorf("render %s: %w", name, err)
+	}
+	source, err := format.Source(buf.Bytes())
+	if err != nil {
+		return fmt.Errorf("format %s: %w", name, err)
+	}
+	files[name] = source
+	return nil
+}
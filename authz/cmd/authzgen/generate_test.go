@@ -0,0 +1,107 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/AoC-Gamers/connect-libraries/authz/v2/catalogue"
+)
+
+const (
+	testSeedsDir = "../../seeds"
+	testRootDir  = "../.."
+)
+
+func TestGeneratedFilesUpToDate(t *testing.T) {
+	outputs, err := build(testSeedsDir, filepath.Join(testSeedsDir, lockFileName))
+	if err != nil {
+		t.Fatalf("unexpected build error: %v", err)
+	}
+
+	for name, want := range outputs {
+		target := name
+		if !filepath.IsAbs(target) {
+			target = filepath.Join(testRootDir, name)
+		}
+		got, err := os.ReadFile(target)
+		if err != nil {
+			t.Fatalf("read %s: %v", target, err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Errorf("%s is stale, run: go run ./cmd/authzgen", target)
+		}
+	}
+}
+
+func TestLockRejectsMovedAndReusedBits(t *testing.T) {
+	c, err := catalogue.New(catalogue.ScopeSeed{
+		Scope: "TEAM",
+		Permissions: []catalogue.PermissionSeed{
+			{Key: "TEAM__SERVER_ADD", Bit: 4},
+			{Key: "TEAM__NEW", Bit: 1},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected catalogue error: %v", err)
+	}
+
+	lock := bitLock{"TEAM": {"TEAM__SERVER_ADD": 2, "TEAM__RETIRED": 1}}
+	_, err = lock.apply(c)
+	if err == nil {
+		t.Fatalf("expected lock violation")
+	}
+	for _, fragment := range []string{"moved from bit 2 to bit 4", "reuses bit 1 previously assigned to TEAM__RETIRED"} {
+		if !strings.Contains(err.Error(), fragment) {
+			t.Fatalf("expected error to mention %q, got: %v", fragment, err)
+		}
+	}
+}
+
+func TestLockRecordsNewPermissions(t *testing.T) {
+	c, err := catalogue.New(catalogue.ScopeSeed{
+		Scope:       "LOBBY",
+		Permissions: []catalogue.PermissionSeed{{Key: "LOBBY__KICK", Bit: 3}, {Key: "LOBBY__NEW", Bit: 7}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected catalogue error: %v", err)
+	}
+
+	lock := bitLock{"LOBBY": {"LOBBY__KICK": 3, "LOBBY__RETIRED": 5}}
+	updated, err := lock.apply(c)
+	if err != nil {
+		t.Fatalf("unexpected lock error: %v", err)
+	}
+	if updated["LOBBY"]["LOBBY__NEW"] != 7 {
+		t.Fatalf("expected new permission to be recorded")
+	}
+	if _, ok := updated["LOBBY"]["LOBBY__RETIRED"]; !ok {
+		t.Fatalf("expected retired permission to stay reserved")
+	}
+	if _, ok := lock["LOBBY"]["LOBBY__NEW"]; ok {
+		t.Fatalf("did not expect original lock to be mutated")
+	}
+}
+
+func TestNaming(t *testing.T) {
+	cases := map[string]string{
+		"TEAM__MISSIONLIST_ADD":               "TeamMissionListAdd",
+		"COMMUNITY__GAMEMODELIST_EDIT":        "CommunityGamemodeListEdit",
+		"WEB__COMMUNITIES_TRANSFER_OWNERSHIP": "WebCommunitiesTransferOwnership",
+		"LOBBY__BASIC":                        "LobbyBasic",
+	}
+	for key, want := range cases {
+		if got := keyIdent(key); got != want {
+			t.Fatalf("keyIdent(%s): expected %s, got %s", key, want, got)
+		}
+	}
+
+	if got := roleConst("TEAM", "team_user"); got != "TEAM_USER" {
+		t.Fatalf("unexpected role constant: %s", got)
+	}
+	if got := titleWords("community_owner"); got != "Community Owner" {
+		t.Fatalf("unexpected role title: %s", got)
+	}
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/AoC-Gamers/connect-libraries/authz/v2/catalogue"
+)
+
+// bitLock records every permission bit ever assigned per scope. Entries are
+// never removed: a retired permission keeps its bit reserved so masks inside
+// tokens that were already issued keep their meaning.
+type bitLock map[string]map[string]uint8
+
+func readLock(path string) (bitLock, error) {
+	data, err := os.ReadFile(filepath.Clean(path))
+	if errors.Is(err, os.ErrNotExist) {
+		return bitLock{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read lock %s: %w", path, err)
+	}
+
+	lock := bitLock{}
+	if err := json.Unmarshal(data, &lock); err != nil {
+		return nil, fmt.Errorf("decode lock %s: %w", path, err)
+	}
+	return lock, nil
+}
+
+func (l bitLock) encode() ([]byte, error) {
+	data, err := json.MarshalIndent(l, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("encode lock: %w", err)
+	}
+	return append(data, '\n'), nil
+}
+
+// apply checks the catalogue against the lock and returns the updated lock.
+// A permission may not change bit and a bit may not be reassigned to another key.
+func (l bitLock) apply(c *catalogue.Catalogue) (bitLock, error) {
+	updated := make(bitLock, len(l))
+	for scope, assigned := range l {
+		updated[scope] = make(map[string]uint8, len(assigned))
+		for key, bit := range assigned {
+			updated[scope][key] = bit
+		}
+	}
+
+	var errs []error
+	for _, scope := range c.Scopes() {
+		assigned := updated[scope]
+		if assigned == nil {
+			assigned = map[string]uint8{}
+			updated[scope] = assigned
+		}
+
+		owners := make(map[uint8]string, len(assigned))
+		for key, bit := range assigned {
+			owners[bit] = key
+		}
+
+		keyToBit := c.PermissionKeyToBit(scope)
+		keys := make([]string, 0, len(keyToBit))
+		for key := range keyToBit {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			bit := keyToBit[key]
+			if previous, ok := assigned[key]; ok {
+				if previous != bit {
+					errs = append(errs, fmt.Errorf("%s: permission %s moved from bit %d to bit %d", scope, key, previous, bit))
+				}
+				continue
+			}
+			if owner, ok := owners[bit]; ok {
+				errs = append(errs, fmt.Errorf("%s: permission %s reuses bit %d previously assigned to %s", scope, key, bit, owner))
+				continue
+			}
+			assigned[key] = bit
+			owners[bit] = key
+		}
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
@@ -0,0 +1,130 @@
+// Command authzgen generates authz/permissions/<scope>.go and
+// authz/roles/<scope>.go from the seed JSON in authz/seeds.
+//
+// Before writing, it validates the seeds (unique bits below 64, resolvable
+// group and role references) and checks them against the bit lock so that a
+// permission never changes bit and a retired bit is never reused.
+//
+// Usage (from the authz module root):
+//
+//	go run ./cmd/authzgen            # regenerate files and update the lock
+//	go run ./cmd/authzgen -check     # fail if generated files or lock are stale
+//	go run ./cmd/authzgen -diff DIR  # print role changes from the seeds in DIR
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/AoC-Gamers/connect-libraries/authz/v2/catalogue"
+)
+
+const lockFileName = "permission-bits.lock"
+
+func main() {
+	seedsDir := flag.String("seeds", "seeds", "directory with <scope>.json seed files")
+	outDir := flag.String("out", ".", "authz module root where permissions/ and roles/ live")
+	lockPath := flag.String("lock", "", "bit lock file (default <seeds>/"+lockFileName+")")
+	check := flag.Bool("check", false, "do not write; exit with error if any output is stale")
+	diffDir := flag.String("diff", "", "print permission and role changes from the seeds in this directory and exit")
+	flag.Parse()
+
+	if *diffDir != "" {
+		if err := printDiff(os.Stdout, *diffDir, *seedsDir); err != nil {
+			fmt.Fprintf(os.Stderr, "authzgen: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if *lockPath == "" {
+		*lockPath = filepath.Join(*seedsDir, lockFileName)
+	}
+
+	if err := run(*seedsDir, *outDir, *lockPath, *check); err != nil {
+		fmt.Fprintf(os.Stderr, "authzgen: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(seedsDir, outDir, lockPath string, check bool) error {
+	outputs, err := build(seedsDir, lockPath)
+	if err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(outputs))
+	for name := range outputs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var stale []string
+	for _, name := range names {
+		target := name
+		if !filepath.IsAbs(target) {
+			target = filepath.Join(outDir, name)
+		}
+		current, err := os.ReadFile(filepath.Clean(target))
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("read %s: %w", target, err)
+		}
+		if bytes.Equal(current, outputs[name]) {
+			continue
+		}
+		if check {
+			stale = append(stale, target)
+			continue
+		}
+		// #nosec G306 -- generated files are checked in and must stay world-readable
+		if err := os.WriteFile(target, outputs[name], 0o644); err != nil {
+			return fmt.Errorf("write %s: %w", target, err)
+		}
+		fmt.Printf("authzgen: wrote %s\n", target)
+	}
+
+	if len(stale) > 0 {
+		return fmt.Errorf("stale generated files (run go run ./cmd/authzgen): %v", stale)
+	}
+	return nil
+}
+
+// build validates the seeds against the lock and renders every output,
+// including the updated lock file (keyed by its own path)
+func build(seedsDir, lockPath string) (map[string][]byte, error) {
+	cat, err := catalogue.LoadDir(seedsDir)
+	if err != nil {
+		return nil, fmt.Errorf("load seeds: %w", err)
+	}
+
+	lock, err := readLock(lockPath)
+	if err != nil {
+		return nil, err
+	}
+	lock, err = lock.apply(cat)
+	if err != nil {
+		return nil, fmt.Errorf("bit lock violation: %w", err)
+	}
+
+	outputs, err := generate(cat, filepath.ToSlash(filepath.Base(filepath.Clean(seedsDir))))
+	if err != nil {
+		return nil, err
+	}
+
+	encoded, err := lock.encode()
+	if err != nil {
+		return nil, err
+	}
+	absLock, err := filepath.Abs(lockPath)
+	if err != nil {
+		return nil, fmt.Errorf("resolve lock path: %w", err)
+	}
+	outputs[absLock] = encoded
+
+	return outputs, nil
+}
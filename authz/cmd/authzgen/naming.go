@@ -0,0 +1,88 @@
+package main
+
+import "strings"
+
+// wordOverrides keeps the CamelCase normalization agreed for compound words
+// in permission keys (authz v2.0.0: MissionList/GamemodeList).
+var wordOverrides = map[string]string{
+	"MISSIONLIST":  "MissionList",
+	"GAMEMODELIST": "GamemodeList",
+}
+
+// unprefixedAPIScopes lists scopes whose lookup helpers predate the per-scope
+// naming (GetRolePermissions instead of GetWebRolePermissions).
+var unprefixedAPIScopes = map[string]bool{
+	"WEB": true,
+}
+
+// scopeNames holds the Go identifiers derived from a scope name
+type scopeNames struct {
+	Scope  string // WEB
+	File   string // web
+	Prefix string // Web (constants, groups, role presets)
+	API    string // Web, or "" for unprefixed scopes (lookup helpers)
+	Lower  string // web (unexported maps)
+}
+
+func newScopeNames(scope string) scopeNames {
+	prefix := camel(scope)
+	api := prefix
+	if unprefixedAPIScopes[scope] {
+		api = ""
+	}
+	return scopeNames{
+		Scope:  scope,
+		File:   strings.ToLower(scope),
+		Prefix: prefix,
+		API:    api,
+		Lower:  strings.ToLower(scope),
+	}
+}
+
+// camel converts an UPPER_SNAKE (or lower_snake) identifier to CamelCase
+func camel(value string) string {
+	var b strings.Builder
+	for _, word := range strings.Split(value, "_") {
+		if word == "" {
+			continue
+		}
+		upper := strings.ToUpper(word)
+		if override, ok := wordOverrides[upper]; ok {
+			b.WriteString(override)
+			continue
+		}
+		b.WriteString(upper[:1])
+		b.WriteString(strings.ToLower(upper[1:]))
+	}
+	return b.String()
+}
+
+// keyIdent converts a scoped key (TEAM__SERVER_ADD, TEAM__STAFF) to its Go
+// identifier (TeamServerAdd, TeamStaff)
+func keyIdent(key string) string {
+	scope, name, ok := strings.Cut(key, "__")
+	if !ok {
+		return camel(key)
+	}
+	return camel(scope) + camel(name)
+}
+
+// titleWords converts team_user into "Team User"
+func titleWords(value string) string {
+	words := strings.Split(value, "_")
+	for i, word := range words {
+		if word != "" {
+			words[i] = strings.ToUpper(word[:1]) + strings.ToLower(word[1:])
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// sentence lowercases the first letter so a description can follow an identifier
+func sentence(description string) string {
+	description = strings.TrimSpace(description)
+	if description == "" {
+		return ""
+	}
+	return strings.ToLower(description[:1]) + description[1:]
+}
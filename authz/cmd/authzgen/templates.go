@@ -0,0 +1,258 @@
+package main
+
+import "text/template"
+
+var permissionsTemplate = template.Must(template.New("permissions").Parse(`// Code generated by authzgen from {{.Source}}. DO NOT EDIT.
+
+package permissions
+
+// {{.Names.Prefix}} Permission Bitmask Constants
+// Each constant represents a bit in uint64 (max 64 permissions)
+const (
+{{- range .Permissions}}
+	// {{.Ident}} {{.Comment}}
+	{{.Ident}} uint64 = 1 << {{.Bit}}
+{{- end}}
+)
+
+// ============================================
+// {{.Names.Prefix}} Permission Names (for API calls)
+// ============================================
+// String constants for use with CheckUserPermission() and similar API methods
+
+const (
+{{- range .Permissions}}
+	// Perm{{.Ident}} is the permission key for {{.Key}}.
+	Perm{{.Ident}} = "{{.Key}}"
+{{- end}}
+)
+
+// ============================================
+// {{.Names.Prefix}} Role String Constants
+// ============================================
+// String constants for {{.Names.Lower}} role identifiers
+
+const (
+{{- range .Roles}}
+	// {{.KeyIdent}} is the role key for {{.Name}}.
+	{{.KeyIdent}} = "{{.Name}}"
+{{- end}}
+)
+
+// ============================================
+// Permission Groups
+// ============================================
+var (
+{{- range $i, $g := .Groups}}
+{{- if $i}}
+{{end}}
+	// {{$g.Ident}} - {{$g.Description}}
+	{{$g.Ident}}{{if not $g.Members}} uint64 = 0{{else}} = {{range $j, $m := $g.Members}}{{if $j}} |
+		{{end}}{{$m}}{{end}}{{end}}
+{{- end}}
+)
+
+// Role Presets - Calculated bitmasks for each role
+var (
+{{- range $i, $r := .Roles}}
+{{- if $i}}
+{{end}}
+	// {{$r.PresetIdent}} - {{$r.Description}}{{if $r.Groups}} ({{range $j, $g := $r.Groups}}{{if $j}} + {{end}}{{$g}}{{end}} groups){{end}}
+	{{$r.PresetIdent}}{{if not $r.Groups}} uint64 = 0{{else}} = {{range $j, $g := $r.Groups}}{{if $j}} | {{end}}{{$g}}{{end}}{{end}}
+{{- end}}
+)
+
+// {{.Names.API}}PermissionGroups maps each permission group key to its bitmask
+var {{.Names.API}}PermissionGroups = map[string]uint64{
+{{- range .Groups}}
+	"{{.Key}}": {{.Ident}},
+{{- end}}
+}
+
+var {{.Names.Lower}}RolePermissions = map[string]uint64{
+{{- range .Roles}}
+	{{.KeyIdent}}: {{.PresetIdent}},
+{{- end}}
+}
+
+var {{.Names.Lower}}RoleNames = map[string]string{
+{{- range .Roles}}
+	{{.KeyIdent}}: "{{.Title}}",
+{{- end}}
+}
+
+// Get{{.Names.API}}RolePermissions returns the permission bitmask for a given {{.Names.Lower}} role
+func Get{{.Names.API}}RolePermissions(role string) uint64 {
+	return getRolePermissions(role, {{.Names.Lower}}RolePermissions)
+}
+
+// Get{{.Names.API}}RoleName returns the human-readable name of a {{.Names.Lower}} role
+func Get{{.Names.API}}RoleName(role string) string {
+	return getRoleName(role, {{.Names.Lower}}RoleNames)
+}
+
+// Is{{.Names.API}}RoleValid checks if a {{.Names.Lower}} role identifier is valid
+func Is{{.Names.API}}RoleValid(role string) bool {
+	return isRoleValid(role, {{.Names.Lower}}RoleNames)
+}
+
+// {{.Names.API}}PermissionNames maps each permission bit to its key name (for debugging/logging)
+var {{.Names.API}}PermissionNames = map[uint64]string{
+{{- range .Permissions}}
+	{{.Ident}}: Perm{{.Ident}},
+{{- end}}
+}
+
+// {{.Names.API}}PermissionKeyToBit maps permission key names to their bit values (reverse lookup)
+var {{.Names.API}}PermissionKeyToBit = map[string]uint8{
+{{- range .Permissions}}
+	Perm{{.Ident}}: {{.Bit}},
+{{- end}}
+}
+
+// Get{{.Names.API}}PermissionName returns the key name of a single permission bit
+func Get{{.Names.API}}PermissionName(permission uint64) string {
+	return getPermissionName(permission, {{.Names.API}}PermissionNames)
+}
+
+// GetAll{{.Names.API}}PermissionNames returns all permission key names in a bitmask
+func GetAll{{.Names.API}}PermissionNames(mask uint64) []string {
+	return getAllPermissionNames(mask, {{.Names.API}}PermissionNames)
+}
+`))
+
+var rolesTemplate = template.Must(template.New("roles").Parse(`// Code generated by authzgen from {{.Source}}. DO NOT EDIT.
+
+package roles
+
+// {{.Names.Scope}} Scope Role Constants
+const (
+{{- range .Roles}}
+	{{.ConstIdent}} = "{{.Name}}" // {{.Label}}
+{{- end}}
+)
+
+// {{.Names.Scope}} Role Definitions
+var (
+{{- range .Roles}}
+	{{.VarIdent}} = Role{
+		Scope:       "{{$.Names.Scope}}",
+		Name:        {{.ConstIdent}},
+		Label:       {{printf "%q" .Label}},
+		Description: {{printf "%q" .Description}},
+		Groups:      []string{ {{- range $j, $g := .GroupKeys}}{{if $j}}, {{end}}"{{$g}}"{{end -}} },
+	}
+{{- end}}
+)
+
+// Get{{.Names.Scope}}Role returns a role definition by name
+func Get{{.Names.Scope}}Role(name string) (Role, bool) {
+	switch name {
+{{- range .Roles}}
+	case {{.ConstIdent}}:
+		return {{.VarIdent}}, true
+{{- end}}
+	default:
+		return Role{}, false
+	}
+}
+
+// Get{{.Names.Scope}}Roles returns all {{.Names.Scope}} roles
+func Get{{.Names.Scope}}Roles() []Role {
+	return []Role{
+{{- range .Roles}}
+		{{.VarIdent}},
+{{- end}}
+	}
+}
+`))
+
+var rolesIndexTemplate = template.Must(template.New("roles-index").Parse(`// Code generated by authzgen from {{.Source}}. DO NOT EDIT.
+
+package roles
+
+// Role defines a role with its associated permission groups
+type Role struct {
+	Scope       string
+	Name        string
+	Label       string
+	Description string
+	Groups      []string // Permission group keys
+}
+
+// HasRole checks if a role name exists in a list of roles
+func HasRole(roleName string, userRoles []string) bool {
+	for _, r := range userRoles {
+		if r == roleName {
+			return true
+		}
+	}
+	return false
+}
+
+// GetRole returns a role definition by scope and name
+func GetRole(scope, name string) (Role, bool) {
+	switch scope {
+{{- range .Scopes}}
+	case "{{.}}":
+		return Get{{.}}Role(name)
+{{- end}}
+	default:
+		return Role{}, false
+	}
+}
+
+// GetAllRoles returns all roles for a scope
+func GetAllRoles(scope string) []Role {
+	switch scope {
+{{- range .Scopes}}
+	case "{{.}}":
+		return Get{{.}}Roles()
+{{- end}}
+	default:
+		return []Role{}
+	}
+}
+`))
+
+var permissionsIndexTemplate = template.Must(template.New("permissions-index").Parse(`// Code generated by authzgen from {{.Source}}. DO NOT EDIT.
+
+package permissions
+
+// ScopeTables groups the generated lookup tables of one scope, so helpers
+// that work across scopes never keep their own copy of the name tables
+type ScopeTables struct {
+	Scope           string
+	Names           map[uint64]string
+	KeyToBit        map[string]uint8
+	Groups          map[string]uint64
+	RolePermissions func(role string) uint64
+}
+
+// GetScopeTables returns the generated tables of a scope
+func GetScopeTables(scope string) (ScopeTables, bool) {
+	switch scope {
+{{- range .Scopes}}
+	case "{{.Scope}}":
+		return ScopeTables{
+			Scope:           "{{.Scope}}",
+			Names:           {{.API}}PermissionNames,
+			KeyToBit:        {{.API}}PermissionKeyToBit,
+			Groups:          {{.API}}PermissionGroups,
+			RolePermissions: Get{{.API}}RolePermissions,
+		}, true
+{{- end}}
+	default:
+		return ScopeTables{}, false
+	}
+}
+
+// GetAllScopes returns the scopes with generated tables
+func GetAllScopes() []string {
+	return []string{
+{{- range .Scopes}}
+		"{{.Scope}}",
+{{- end}}
+	}
+}
+`))
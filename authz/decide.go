@@ -0,0 +1,99 @@
+// Package authz exposes authorization decisions built on top of the
+// generated permission bitmasks (authz/permissions) and roles (authz/roles).
+package authz
+
+import (
+	"github.com/AoC-Gamers/connect-libraries/authz/v2/permissions"
+	"github.com/AoC-Gamers/connect-libraries/authz/v2/roles"
+)
+
+// Decision reasons
+const (
+	ReasonAllowed            = "allowed"
+	ReasonMissingPermissions = "missing_permissions"
+	ReasonDeniedByPolicy     = "denied_by_policy"
+)
+
+// Decision explains the outcome of a permission check
+type Decision struct {
+	Allowed bool
+	Scope   string
+	Role    string
+
+	// Required lists the permission keys the action needs, named and ordered
+	// like GetAll*PermissionNames (bits without a name only show in the masks)
+	Required []string
+	// Missing lists required permissions the allow mask never granted
+	Missing []string
+	// Denied lists required permissions granted by the allow mask but removed by the deny mask
+	Denied []string
+
+	MissingMask uint64
+	DeniedMask  uint64
+
+	// Groups lists the groups of Role that grant at least one required permission
+	Groups []string
+}
+
+// Decide evaluates requiredPermission against allow/deny masks the same way as
+// permissions.CanPerformAction, and reports why the check passed or failed
+func Decide(scope, role string, allowMask, denyMask, requiredPermission uint64) Decision {
+	tables, _ := permissions.GetScopeTables(scope)
+
+	missingMask := requiredPermission &^ allowMask
+	deniedMask := requiredPermission & allowMask & denyMask
+
+	decision := Decision{
+		Allowed:     permissions.CanPerformAction(allowMask, denyMask, requiredPermission),
+		Scope:       scope,
+		Role:        role,
+		Required:    tables.PermissionNames(requiredPermission),
+		Missing:     tables.PermissionNames(missingMask),
+		Denied:      tables.PermissionNames(deniedMask),
+		MissingMask: missingMask,
+		DeniedMask:  deniedMask,
+		Groups:      []string{},
+	}
+
+	if definition, ok := roles.GetRole(scope, role); ok {
+		for _, group := range definition.Groups {
+			if tables.Groups[group]&requiredPermission != 0 {
+				decision.Groups = append(decision.Groups, group)
+			}
+		}
+	}
+
+	return decision
+}
+
+// Reason returns a stable machine-readable reason for the decision
+func (d Decision) Reason() string {
+	switch {
+	case d.Allowed:
+		return ReasonAllowed
+	case d.MissingMask != 0:
+		return ReasonMissingPermissions
+	default:
+		return ReasonDeniedByPolicy
+	}
+}
+
+// SanitizedMeta returns the part of the decision that is safe to send to the
+// caller in error metadata: permission keys and the reason, without masks,
+// role groups or any other internal policy detail
+func (d Decision) SanitizedMeta() map[string]interface{} {
+	return map[string]interface{}{
+		"reason":               d.Reason(),
+		"required_permissions": d.Required,
+		"missing_permissions":  d.Missing,
+		"denied_permissions":   d.Denied,
+	}
+}
+
+// Explainer returns a function producing sanitized decision metadata for a
+// scope, suitable for middleware that only knows the role and masks
+func Explainer(scope string) func(role string, allowMask, denyMask, requiredPermission uint64) map[string]interface{} {
+	return func(role string, allowMask, denyMask, requiredPermission uint64) map[string]interface{} {
+		return Decide(scope, role, allowMask, denyMask, requiredPermission).SanitizedMeta()
+	}
+}
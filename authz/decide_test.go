@@ -0,0 +1,80 @@
+package authz
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/AoC-Gamers/connect-libraries/authz/v2/permissions"
+	"github.com/AoC-Gamers/connect-libraries/authz/v2/roles"
+)
+
+func TestDecideAllowed(t *testing.T) {
+	decision := Decide("TEAM", roles.TEAM_STAFF, permissions.RoleTeamStaff, 0, permissions.TeamServerAdd)
+
+	if !decision.Allowed || decision.Reason() != ReasonAllowed {
+		t.Fatalf("expected decision to allow, got %+v", decision)
+	}
+	if !reflect.DeepEqual(decision.Required, []string{permissions.PermTeamServerAdd}) {
+		t.Fatalf("unexpected required names: %v", decision.Required)
+	}
+	if !reflect.DeepEqual(decision.Groups, []string{"TEAM__STAFF"}) {
+		t.Fatalf("unexpected contributing groups: %v", decision.Groups)
+	}
+	if len(decision.Missing) != 0 || len(decision.Denied) != 0 {
+		t.Fatalf("did not expect missing/denied permissions: %+v", decision)
+	}
+}
+
+func TestDecideMissingAndDenied(t *testing.T) {
+	required := permissions.TeamServerAdd | permissions.TeamSuspend
+	decision := Decide("TEAM", roles.TEAM_STAFF, permissions.RoleTeamStaff, permissions.TeamServerAdd, required)
+
+	if decision.Allowed {
+		t.Fatalf("did not expect decision to allow")
+	}
+	if decision.Reason() != ReasonMissingPermissions {
+		t.Fatalf("unexpected reason %s", decision.Reason())
+	}
+	if !reflect.DeepEqual(decision.Missing, []string{permissions.PermTeamSuspend}) || decision.MissingMask != permissions.TeamSuspend {
+		t.Fatalf("unexpected missing: %v", decision.Missing)
+	}
+	if !reflect.DeepEqual(decision.Denied, []string{permissions.PermTeamServerAdd}) || decision.DeniedMask != permissions.TeamServerAdd {
+		t.Fatalf("unexpected denied: %v", decision.Denied)
+	}
+	if !reflect.DeepEqual(decision.Groups, []string{"TEAM__STAFF"}) {
+		t.Fatalf("unexpected contributing groups: %v", decision.Groups)
+	}
+
+	onlyDenied := Decide("TEAM", roles.TEAM_STAFF, permissions.RoleTeamStaff, permissions.TeamServerAdd, permissions.TeamServerAdd)
+	if onlyDenied.Reason() != ReasonDeniedByPolicy {
+		t.Fatalf("expected denied_by_policy, got %s", onlyDenied.Reason())
+	}
+}
+
+func TestDecideNamesMatchPermissionHelpers(t *testing.T) {
+	required := permissions.WebTeamsSuspend | permissions.WebRolesEdit | 1<<63
+	decision := Decide("WEB", "", 0, 0, required)
+
+	if !reflect.DeepEqual(decision.Required, permissions.GetAllPermissionNames(required)) {
+		t.Fatalf("expected names of GetAllPermissionNames, got %v", decision.Required)
+	}
+	if decision.MissingMask != required {
+		t.Fatalf("expected unnamed bits to stay in the mask, got %b", decision.MissingMask)
+	}
+}
+
+func TestSanitizedMetaAndExplainer(t *testing.T) {
+	meta := Explainer("WEB")(roles.WEB_USER, permissions.RoleWebUser, 0, permissions.WebRolesEdit)
+
+	if meta["reason"] != ReasonMissingPermissions {
+		t.Fatalf("unexpected reason: %v", meta["reason"])
+	}
+	if !reflect.DeepEqual(meta["missing_permissions"], []string{permissions.PermWebRolesEdit}) {
+		t.Fatalf("unexpected missing permissions: %v", meta["missing_permissions"])
+	}
+	for _, internal := range []string{"groups", "allow_mask", "deny_mask", "role"} {
+		if _, ok := meta[internal]; ok {
+			t.Fatalf("did not expect %s in sanitized meta", internal)
+		}
+	}
+}
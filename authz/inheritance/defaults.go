@@ -0,0 +1,45 @@
+package inheritance
+
+import "github.com/AoC-Gamers/connect-libraries/authz/v2/permissions"
+
+// DefaultMappings returns the platform moderation rules:
+//   - web staff/owner permissions over communities, teams and lobbies
+//     (the web-level "edit/suspend/transfer/roles" bits act inside any instance)
+//   - community owners over the teams that belong to their community
+//
+// Services may use them as-is or pass their own rules to NewEvaluator.
+func DefaultMappings() []Mapping {
+	return []Mapping{
+		// WEB → COMMUNITY
+		{From: ScopeWeb, To: ScopeCommunity, Require: permissions.WebCommunitiesEdit, Grant: permissions.CommunityInfoEdit},
+		{From: ScopeWeb, To: ScopeCommunity, Require: permissions.WebCommunitiesSuspend, Grant: permissions.CommunitySuspend},
+		{From: ScopeWeb, To: ScopeCommunity, Require: permissions.WebCommunitiesTransferOwnership, Grant: permissions.CommunityTransferOwnership},
+		{From: ScopeWeb, To: ScopeCommunity, Require: permissions.WebMembershipInvite, Grant: permissions.CommunityMembershipInvite},
+		{From: ScopeWeb, To: ScopeCommunity, Require: permissions.WebMembershipDelete, Grant: permissions.CommunityMembershipDelete},
+		{From: ScopeWeb, To: ScopeCommunity, Require: permissions.WebViewAuditLog, Grant: permissions.CommunityAuditView},
+		{From: ScopeWeb, To: ScopeCommunity, Require: permissions.WebRolesView, Grant: permissions.CommunityRolesView},
+		{From: ScopeWeb, To: ScopeCommunity, Require: permissions.WebRolesEdit, Grant: permissions.CommunityRolesEdit},
+
+		// WEB → TEAM
+		{From: ScopeWeb, To: ScopeTeam, Require: permissions.WebTeamsEdit, Grant: permissions.TeamInfoEdit},
+		{From: ScopeWeb, To: ScopeTeam, Require: permissions.WebTeamsSuspend, Grant: permissions.TeamSuspend},
+		{From: ScopeWeb, To: ScopeTeam, Require: permissions.WebTeamsTransferOwnership, Grant: permissions.TeamTransferOwnership},
+		{From: ScopeWeb, To: ScopeTeam, Require: permissions.WebMembershipInvite, Grant: permissions.TeamMembershipInvite},
+		{From: ScopeWeb, To: ScopeTeam, Require: permissions.WebMembershipDelete, Grant: permissions.TeamMembershipDelete},
+		{From: ScopeWeb, To: ScopeTeam, Require: permissions.WebViewAuditLog, Grant: permissions.TeamAuditView},
+		{From: ScopeWeb, To: ScopeTeam, Require: permissions.WebRolesView, Grant: permissions.TeamRolesView},
+		{From: ScopeWeb, To: ScopeTeam, Require: permissions.WebRolesEdit, Grant: permissions.TeamRolesEdit},
+
+		// WEB → LOBBY
+		{From: ScopeWeb, To: ScopeLobby, Require: permissions.WebMembershipView, Grant: permissions.LobbyMembershipView},
+		{From: ScopeWeb, To: ScopeLobby, Require: permissions.WebSanctionsSuspend, Grant: permissions.LobbyKick},
+		{From: ScopeWeb, To: ScopeLobby, Require: permissions.WebMembershipDelete, Grant: permissions.LobbyMembershipDelete | permissions.LobbyDisband},
+
+		// COMMUNITY → TEAM
+		{From: ScopeCommunity, To: ScopeTeam, Require: permissions.CommunityInfoEdit, Grant: permissions.TeamInfoEdit},
+		{From: ScopeCommunity, To: ScopeTeam, Require: permissions.CommunitySuspend, Grant: permissions.TeamSuspend},
+		{From: ScopeCommunity, To: ScopeTeam, Require: permissions.CommunityMembershipDelete, Grant: permissions.TeamMembershipDelete},
+		{From: ScopeCommunity, To: ScopeTeam, Require: permissions.CommunityAuditView, Grant: permissions.TeamAuditView},
+		{From: ScopeCommunity, To: ScopeTeam, Require: permissions.CommunityRolesView, Grant: permissions.TeamRolesView},
+	}
+}
@@ -0,0 +1,240 @@
+// Package inheritance computes effective permissions across the scope
+// hierarchy WEB → COMMUNITY → TEAM/LOBBY. A membership in an outer scope
+// (e.g. web staff) can grant permissions inside an inner scope (a community or
+// team) through explicit mapping rules, and every granted bit is explainable.
+package inheritance
+
+import (
+	"math/bits"
+	"sort"
+
+	"github.com/AoC-Gamers/connect-libraries/authz/v2/permissions"
+)
+
+// Scope names used by the hierarchy (same values as roles.Role.Scope)
+const (
+	ScopeWeb       = "WEB"
+	ScopeCommunity = "COMMUNITY"
+	ScopeTeam      = "TEAM"
+	ScopeLobby     = "LOBBY"
+)
+
+// ScopeRef identifies one scope instance (WEB has no ID)
+type ScopeRef struct {
+	Scope string
+	ID    string
+}
+
+// Membership is what a user holds inside a scope instance
+type Membership struct {
+	Scope   string
+	ScopeID string
+	Role    string
+	Allow   uint64
+	Deny    uint64
+}
+
+// Ref returns the scope instance the membership belongs to
+func (m Membership) Ref() ScopeRef {
+	return ScopeRef{Scope: m.Scope, ID: m.ScopeID}
+}
+
+// Mapping grants permissions in an inner scope to holders of permissions in
+// the enclosing scope: holding all Require bits in From grants Grant in To.
+type Mapping struct {
+	From    string
+	To      string
+	Require uint64
+	Grant   uint64
+}
+
+// Grant explains where a permission bit came from
+type Grant struct {
+	Membership ScopeRef
+	Role       string
+	Inherited  bool // false when granted by the membership in the target scope itself
+}
+
+// Result is the effective permission mask for a target scope instance
+type Result struct {
+	Target ScopeRef
+	Mask   uint64
+	// Denied holds bits that were granted (directly or inherited) but removed
+	// by the deny mask of the target membership
+	Denied uint64
+	grants map[uint64][]Grant
+}
+
+// HasPermission checks the effective mask, see permissions.HasPermission
+func (r Result) HasPermission(permission uint64) bool {
+	return permissions.HasPermission(r.Mask, permission)
+}
+
+// GrantedBy returns the memberships that granted a single permission bit
+func (r Result) GrantedBy(permission uint64) []Grant {
+	return append([]Grant(nil), r.grants[permission]...)
+}
+
+// Explain maps every effective permission key to the memberships that granted it.
+// names is one of the *PermissionNames maps for the target scope.
+func (r Result) Explain(names map[uint64]string) map[string][]Grant {
+	result := make(map[string][]Grant)
+	for bit, grants := range r.grants {
+		if r.Mask&bit == 0 {
+			continue
+		}
+		name, ok := names[bit]
+		if !ok {
+			name = "UNKNOWN_PERMISSION"
+		}
+		result[name] = append(result[name], grants...)
+	}
+	return result
+}
+
+// Evaluator applies mapping rules along a scope path
+type Evaluator struct {
+	mappings map[[2]string][]Mapping
+}
+
+// NewEvaluator builds an evaluator from mapping rules. Without rules every
+// scope is evaluated in isolation, as with permissions.CanPerformAction.
+func NewEvaluator(mappings ...Mapping) *Evaluator {
+	e := &Evaluator{mappings: make(map[[2]string][]Mapping)}
+	for _, m := range mappings {
+		key := [2]string{m.From, m.To}
+		e.mappings[key] = append(e.mappings[key], m)
+	}
+	return e
+}
+
+// Evaluate computes the effective mask for the last scope in path.
+// path lists the scope instances from the outermost to the target, e.g.
+// {WEB}, {COMMUNITY c1}, {TEAM t1}. At each level the membership's own allow
+// mask is combined with what every enclosing level grants through the
+// mappings (so WEB→TEAM rules apply on the path WEB → COMMUNITY → TEAM), and
+// the level's deny mask is applied before propagating further.
+func (e *Evaluator) Evaluate(memberships []Membership, path ...ScopeRef) Result {
+	if len(path) == 0 {
+		return Result{}
+	}
+
+	byRef := make(map[ScopeRef]Membership, len(memberships))
+	for _, m := range memberships {
+		byRef[m.Ref()] = m
+	}
+
+	var (
+		mask    uint64
+		denied  uint64
+		sources map[uint64][]Grant
+	)
+	masks := make([]uint64, len(path))
+	levelSources := make([]map[uint64][]Grant, len(path))
+	for level, ref := range path {
+		inherited := make(map[uint64][]Grant)
+		var inheritedMask uint64
+		for ancestor := 0; ancestor < level; ancestor++ {
+			inheritedMask |= e.propagate(path[ancestor].Scope, ref.Scope, masks[ancestor], levelSources[ancestor], inherited)
+		}
+
+		current := make(map[uint64][]Grant, len(inherited))
+		for bit, grants := range inherited {
+			current[bit] = markInherited(grants)
+		}
+
+		var allow, deny uint64
+		if m, ok := byRef[ref]; ok {
+			allow, deny = m.Allow, m.Deny
+			own := Grant{Membership: ref, Role: m.Role}
+			for _, bit := range splitBits(allow) {
+				current[bit] = append(current[bit], own)
+			}
+		}
+
+		granted := allow | inheritedMask
+		mask = permissions.ApplyDenyMask(granted, deny)
+		denied = granted & deny
+		sources = current
+		masks[level] = mask
+		levelSources[level] = current
+	}
+
+	for bit := range sources {
+		if mask&bit == 0 {
+			delete(sources, bit)
+		}
+	}
+
+	return Result{
+		Target: path[len(path)-1],
+		Mask:   mask,
+		Denied: denied,
+		grants: sources,
+	}
+}
+
+// propagate applies the From→To mappings to mask, recording the grants
+func (e *Evaluator) propagate(from, to string, mask uint64, sources, inherited map[uint64][]Grant) uint64 {
+	var result uint64
+	for _, m := range e.mappings[[2]string{from, to}] {
+		if m.Require == 0 || !permissions.HasPermission(mask, m.Require) {
+			continue
+		}
+		origins := collectGrants(sources, m.Require)
+		for _, bit := range splitBits(m.Grant) {
+			inherited[bit] = mergeGrants(inherited[bit], origins)
+		}
+		result |= m.Grant
+	}
+	return result
+}
+
+func collectGrants(sources map[uint64][]Grant, mask uint64) []Grant {
+	var result []Grant
+	for _, bit := range splitBits(mask) {
+		result = mergeGrants(result, sources[bit])
+	}
+	return result
+}
+
+func mergeGrants(existing, added []Grant) []Grant {
+	for _, grant := range added {
+		found := false
+		for _, current := range existing {
+			if current.Membership == grant.Membership && current.Role == grant.Role {
+				found = true
+				break
+			}
+		}
+		if !found {
+			existing = append(existing, grant)
+		}
+	}
+	sort.SliceStable(existing, func(i, j int) bool {
+		if existing[i].Membership.Scope != existing[j].Membership.Scope {
+			return existing[i].Membership.Scope < existing[j].Membership.Scope
+		}
+		return existing[i].Membership.ID < existing[j].Membership.ID
+	})
+	return existing
+}
+
+func markInherited(grants []Grant) []Grant {
+	result := make([]Grant, len(grants))
+	for i, grant := range grants {
+		grant.Inherited = true
+		result[i] = grant
+	}
+	return result
+}
+
+func splitBits(mask uint64) []uint64 {
+	result := make([]uint64, 0, bits.OnesCount64(mask))
+	for mask != 0 {
+		bit := mask & -mask
+		result = append(result, bit)
+		mask &^= bit
+	}
+	return result
+}
@@ -0,0 +1,114 @@
+package inheritance
+
+import (
+	"testing"
+
+	"github.com/AoC-Gamers/connect-libraries/authz/v2/permissions"
+	"github.com/AoC-Gamers/connect-libraries/authz/v2/roles"
+)
+
+var (
+	testWeb       = ScopeRef{Scope: ScopeWeb}
+	testCommunity = ScopeRef{Scope: ScopeCommunity, ID: "c1"}
+	testTeam      = ScopeRef{Scope: ScopeTeam, ID: "t1"}
+)
+
+func TestEvaluateWithoutMappingsIsIsolated(t *testing.T) {
+	memberships := []Membership{
+		{Scope: ScopeWeb, Role: roles.WEB_OWNER, Allow: permissions.RoleWebOwner},
+		{Scope: ScopeTeam, ScopeID: "t1", Role: roles.TEAM_STAFF, Allow: permissions.RoleTeamStaff, Deny: permissions.TeamServerAdd},
+	}
+
+	result := NewEvaluator().Evaluate(memberships, testWeb, testCommunity, testTeam)
+	if result.Mask != permissions.ApplyDenyMask(permissions.RoleTeamStaff, permissions.TeamServerAdd) {
+		t.Fatalf("expected only the team membership to count, got %#x", result.Mask)
+	}
+	if result.Denied != permissions.TeamServerAdd {
+		t.Fatalf("expected denied bits to be reported, got %#x", result.Denied)
+	}
+	if result.Target != testTeam {
+		t.Fatalf("unexpected target %+v", result.Target)
+	}
+}
+
+func TestEvaluateInheritsFromOuterScopes(t *testing.T) {
+	memberships := []Membership{
+		{Scope: ScopeWeb, Role: roles.WEB_STAFF, Allow: permissions.RoleWebStaff},
+		{Scope: ScopeCommunity, ScopeID: "c1", Role: roles.COMMUNITY_OWNER, Allow: permissions.RoleCommunityOwner},
+		{Scope: ScopeCommunity, ScopeID: "other", Role: roles.COMMUNITY_OWNER, Allow: permissions.RoleCommunityOwner},
+	}
+	evaluator := NewEvaluator(DefaultMappings()...)
+
+	result := evaluator.Evaluate(memberships, testWeb, testCommunity, testTeam)
+	if !result.HasPermission(permissions.TeamSuspend) {
+		t.Fatalf("expected community owner to suspend teams of the community")
+	}
+	if !result.HasPermission(permissions.TeamRolesView) {
+		t.Fatalf("expected roles view to be inherited")
+	}
+	if result.HasPermission(permissions.TeamServerAdd) {
+		t.Fatalf("did not expect unmapped permission to be inherited")
+	}
+
+	grants := result.GrantedBy(permissions.TeamSuspend)
+	if len(grants) != 1 || grants[0].Membership != testCommunity || !grants[0].Inherited || grants[0].Role != roles.COMMUNITY_OWNER {
+		t.Fatalf("unexpected grants for TeamSuspend: %+v", grants)
+	}
+
+	// TeamRolesView comes from both web staff (WEB__ROLES_VIEW) and the community owner
+	explained := result.Explain(permissions.TeamPermissionNames)
+	if len(explained[permissions.PermTeamRolesView]) != 2 {
+		t.Fatalf("expected two grants for %s, got %+v", permissions.PermTeamRolesView, explained[permissions.PermTeamRolesView])
+	}
+
+	// Outside the community, the community ownership grants nothing
+	unrelated := evaluator.Evaluate(memberships, testWeb, ScopeRef{Scope: ScopeCommunity, ID: "c2"}, testTeam)
+	if unrelated.HasPermission(permissions.TeamSuspend) {
+		t.Fatalf("did not expect ownership of another community to apply")
+	}
+}
+
+func TestEvaluateDenyBlocksInheritance(t *testing.T) {
+	memberships := []Membership{
+		{Scope: ScopeWeb, Role: roles.WEB_OWNER, Allow: permissions.RoleWebOwner},
+		{Scope: ScopeTeam, ScopeID: "t1", Role: roles.TEAM_USER, Deny: permissions.TeamSuspend},
+	}
+
+	result := NewEvaluator(DefaultMappings()...).Evaluate(memberships, testWeb, testTeam)
+	if result.HasPermission(permissions.TeamSuspend) {
+		t.Fatalf("expected target deny mask to remove inherited permission")
+	}
+	if result.Denied != permissions.TeamSuspend {
+		t.Fatalf("expected TeamSuspend to be reported as denied, got %#x", result.Denied)
+	}
+	if len(result.GrantedBy(permissions.TeamSuspend)) != 0 {
+		t.Fatalf("did not expect grants for a denied permission")
+	}
+	if !result.HasPermission(permissions.TeamTransferOwnership) {
+		t.Fatalf("expected web owner to transfer team ownership")
+	}
+}
+
+func TestEvaluateInheritsFromNonAdjacentScopes(t *testing.T) {
+	memberships := []Membership{
+		{Scope: ScopeWeb, Role: roles.WEB_STAFF, Allow: permissions.WebTeamsSuspend},
+	}
+
+	// WEB → TEAM without any COMMUNITY relay
+	evaluator := NewEvaluator(Mapping{From: ScopeWeb, To: ScopeTeam, Require: permissions.WebTeamsSuspend, Grant: permissions.TeamSuspend})
+	result := evaluator.Evaluate(memberships, testWeb, testCommunity, testTeam)
+	if !result.HasPermission(permissions.TeamSuspend) {
+		t.Fatalf("expected WEB grant to reach TEAM through the community level, got %#x", result.Mask)
+	}
+	grants := result.GrantedBy(permissions.TeamSuspend)
+	if len(grants) != 1 || grants[0].Membership != testWeb || !grants[0].Inherited {
+		t.Fatalf("unexpected grants for TeamSuspend: %+v", grants)
+	}
+
+	// The default WEB → TEAM rules also apply on the full path
+	owner := []Membership{{Scope: ScopeWeb, Role: roles.WEB_OWNER, Allow: permissions.RoleWebOwner}}
+	full := NewEvaluator(DefaultMappings()...).Evaluate(owner, testWeb, testCommunity, testTeam)
+	if !full.HasPermission(permissions.TeamTransferOwnership) {
+		t.Fatalf("expected web owner to transfer team ownership inside a community")
+	}
+}
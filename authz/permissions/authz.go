@@ -1,5 +1,7 @@
 package permissions
 
+//go:generate go run ../cmd/authzgen -seeds ../seeds -out ..
+
 // HasPermission checks if a given bitmask has a specific permission
 // This is the core authorization check used across all services
 func HasPermission(mask, permission uint64) bool {
@@ -1,34 +1,28 @@
+// Code generated by authzgen from seeds/community.json. DO NOT EDIT.
+
 package permissions
 
-// ============================================
 // Community Permission Bitmask Constants
-// ============================================
-// Generated from Connect-Auth/seeds/permissions/community.json
 // Each constant represents a bit in uint64 (max 64 permissions)
-
 const (
 	// CommunityMembershipInvite invites users to the community (membership invite).
 	CommunityMembershipInvite uint64 = 1 << 0
 	// CommunityMembershipDelete removes memberships from the community.
 	CommunityMembershipDelete uint64 = 1 << 1
-
 	// CommunityServerAdd adds a server to the community's server list.
 	CommunityServerAdd uint64 = 1 << 2
 	// CommunityServerEdit edits an existing server in the community's server list.
 	CommunityServerEdit uint64 = 1 << 3
 	// CommunityServerDelete deletes a server from the community's server list.
 	CommunityServerDelete uint64 = 1 << 4
-
 	// CommunityMissionListAdd adds a new mission to the community's mission list.
 	CommunityMissionListAdd uint64 = 1 << 5
 	// CommunityMissionListEdit edits an existing mission in the community's mission list.
 	CommunityMissionListEdit uint64 = 1 << 6
-
 	// CommunityGamemodeListAdd adds a new gamemode to the community's gamemode list.
 	CommunityGamemodeListAdd uint64 = 1 << 7
 	// CommunityGamemodeListEdit edits an existing gamemode in the community's gamemode list.
 	CommunityGamemodeListEdit uint64 = 1 << 8
-
 	// CommunityInfoEdit edits community information.
 	CommunityInfoEdit uint64 = 1 << 9
 	// CommunityAnalytics views community statistics and analytics.
@@ -39,7 +33,6 @@ const (
 	CommunitySuspend uint64 = 1 << 12
 	// CommunityAuditView views audit logs for this community.
 	CommunityAuditView uint64 = 1 << 13
-
 	// CommunityRolesView views roles and permissions for this community.
 	CommunityRolesView uint64 = 1 << 14
 	// CommunityRolesEdit edits roles and permissions for this community.
@@ -50,31 +43,26 @@ const (
 // Community Permission Names (for API calls)
 // ============================================
 // String constants for use with CheckUserPermission() and similar API methods
-// These match the permission keys in Connect-Auth
 
 const (
 	// PermCommunityMembershipInvite is the permission key for COMMUNITY__MEMBERSHIP_INVITE.
 	PermCommunityMembershipInvite = "COMMUNITY__MEMBERSHIP_INVITE"
 	// PermCommunityMembershipDelete is the permission key for COMMUNITY__MEMBERSHIP_DELETE.
 	PermCommunityMembershipDelete = "COMMUNITY__MEMBERSHIP_DELETE"
-
 	// PermCommunityServerAdd is the permission key for COMMUNITY__SERVER_ADD.
 	PermCommunityServerAdd = "COMMUNITY__SERVER_ADD"
 	// PermCommunityServerEdit is the permission key for COMMUNITY__SERVER_EDIT.
 	PermCommunityServerEdit = "COMMUNITY__SERVER_EDIT"
 	// PermCommunityServerDelete is the permission key for COMMUNITY__SERVER_DELETE.
 	PermCommunityServerDelete = "COMMUNITY__SERVER_DELETE"
-
 	// PermCommunityMissionListAdd is the permission key for COMMUNITY__MISSIONLIST_ADD.
 	PermCommunityMissionListAdd = "COMMUNITY__MISSIONLIST_ADD"
 	// PermCommunityMissionListEdit is the permission key for COMMUNITY__MISSIONLIST_EDIT.
 	PermCommunityMissionListEdit = "COMMUNITY__MISSIONLIST_EDIT"
-
 	// PermCommunityGamemodeListAdd is the permission key for COMMUNITY__GAMEMODELIST_ADD.
 	PermCommunityGamemodeListAdd = "COMMUNITY__GAMEMODELIST_ADD"
 	// PermCommunityGamemodeListEdit is the permission key for COMMUNITY__GAMEMODELIST_EDIT.
 	PermCommunityGamemodeListEdit = "COMMUNITY__GAMEMODELIST_EDIT"
-
 	// PermCommunityInfoEdit is the permission key for COMMUNITY__INFO_EDIT.
 	PermCommunityInfoEdit = "COMMUNITY__INFO_EDIT"
 	// PermCommunityAnalytics is the permission key for COMMUNITY__ANALYTICS.
@@ -85,7 +73,6 @@ const (
 	PermCommunitySuspend = "COMMUNITY__SUSPEND"
 	// PermCommunityAuditView is the permission key for COMMUNITY__AUDIT_VIEW.
 	PermCommunityAuditView = "COMMUNITY__AUDIT_VIEW"
-
 	// PermCommunityRolesView is the permission key for COMMUNITY__ROLES_VIEW.
 	PermCommunityRolesView = "COMMUNITY__ROLES_VIEW"
 	// PermCommunityRolesEdit is the permission key for COMMUNITY__ROLES_EDIT.
@@ -98,18 +85,17 @@ const (
 // String constants for community role identifiers
 
 const (
-	// RoleCommunityOwnerKey is the role key for a community owner.
-	RoleCommunityOwnerKey = "community_owner"
-	// RoleCommunityStaffKey is the role key for a community staff member.
+	// RoleCommunityStaffKey is the role key for community_staff.
 	RoleCommunityStaffKey = "community_staff"
-	// RoleCommunityUserKey is the role key for a community user.
+	// RoleCommunityOwnerKey is the role key for community_owner.
+	RoleCommunityOwnerKey = "community_owner"
+	// RoleCommunityUserKey is the role key for community_user.
 	RoleCommunityUserKey = "community_user"
 )
 
 // ============================================
 // Permission Groups
 // ============================================
-// Matches seeds/permissions/community.json groups
 var (
 	// CommunityBasic - Basic community membership (no permissions)
 	CommunityBasic uint64 = 0
@@ -137,25 +123,32 @@ var (
 
 // Role Presets - Calculated bitmasks for each role
 var (
-	// RoleCommunityUser - Basic community member (CommunityBasic group - no permissions)
-	RoleCommunityUser = CommunityBasic
-
-	// RoleCommunityStaff - Community staff member (CommunityBasic + CommunityStaff groups)
+	// RoleCommunityStaff - Community moderator with management capabilities (CommunityBasic + CommunityStaff groups)
 	RoleCommunityStaff = CommunityBasic | CommunityStaff
 
-	// RoleCommunityOwner - Community owner (CommunityBasic + CommunityStaff + CommunityOwner groups)
+	// RoleCommunityOwner - Community owner with full administrative control (CommunityBasic + CommunityStaff + CommunityOwner groups)
 	RoleCommunityOwner = CommunityBasic | CommunityStaff | CommunityOwner
+
+	// RoleCommunityUser - Basic community member with participation rights (CommunityBasic groups)
+	RoleCommunityUser = CommunityBasic
 )
 
+// CommunityPermissionGroups maps each permission group key to its bitmask
+var CommunityPermissionGroups = map[string]uint64{
+	"COMMUNITY__BASIC": CommunityBasic,
+	"COMMUNITY__STAFF": CommunityStaff,
+	"COMMUNITY__OWNER": CommunityOwner,
+}
+
 var communityRolePermissions = map[string]uint64{
-	RoleCommunityOwnerKey: RoleCommunityOwner,
 	RoleCommunityStaffKey: RoleCommunityStaff,
+	RoleCommunityOwnerKey: RoleCommunityOwner,
 	RoleCommunityUserKey:  RoleCommunityUser,
 }
 
 var communityRoleNames = map[string]string{
-	RoleCommunityOwnerKey: "Community Owner",
 	RoleCommunityStaffKey: "Community Staff",
+	RoleCommunityOwnerKey: "Community Owner",
 	RoleCommunityUserKey:  "Community User",
 }
 
@@ -0,0 +1,147 @@
+package permissions
+
+import (
+	"errors"
+	"fmt"
+	"math/bits"
+	"sort"
+	"strings"
+)
+
+var (
+	// ErrUnknownScope is returned when a scope has no permission tables
+	ErrUnknownScope = errors.New("unknown permission scope")
+
+	// ErrUnknownPermission is returned for keys, patterns or bits that match no permission of the scope
+	ErrUnknownPermission = errors.New("unknown permission")
+)
+
+// ParseMask parses a comma or whitespace separated list of permission keys
+// ("WEB__TEAM_VIEW,WEB__TEAMS_EDIT"), wildcard patterns ("WEB__MISSION_*")
+// and group names ("TEAM__STAFF") of a scope into a mask. Every unknown item
+// is reported, wrapped in ErrUnknownPermission. An empty list is mask 0.
+func ParseMask(scope, list string) (uint64, error) {
+	items := strings.FieldsFunc(list, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t' || r == '\n' || r == '\r'
+	})
+	return ParseMaskList(scope, items)
+}
+
+// ParseMaskList is ParseMask for an already split list
+func ParseMaskList(scope string, items []string) (uint64, error) {
+	table, ok := GetScopeTables(scope)
+	if !ok {
+		return 0, fmt.Errorf("%w: %s", ErrUnknownScope, scope)
+	}
+
+	var (
+		mask uint64
+		errs []error
+	)
+	for _, item := range items {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		value, err := resolveKey(table, item)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		mask |= value
+	}
+	if len(errs) > 0 {
+		return 0, errors.Join(errs...)
+	}
+	return mask, nil
+}
+
+func resolveKey(t ScopeTables, item string) (uint64, error) {
+	if bit, ok := t.KeyToBit[item]; ok {
+		return uint64(1) << bit, nil
+	}
+	if group, ok := t.Groups[item]; ok {
+		return group, nil
+	}
+	if prefix, ok := strings.CutSuffix(item, "*"); ok && !strings.Contains(prefix, "*") {
+		var mask uint64
+		for key, bit := range t.KeyToBit {
+			if strings.HasPrefix(key, prefix) {
+				mask |= uint64(1) << bit
+			}
+		}
+		if mask != 0 {
+			return mask, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: %s", ErrUnknownPermission, item)
+}
+
+// FormatMask returns the permission keys of a mask ordered by bit and joined
+// with commas, the inverse of ParseMask. Bits without a permission in the scope
+// are an error wrapping ErrUnknownPermission.
+func FormatMask(scope string, mask uint64) (string, error) {
+	keys, err := formatKeys(scope, mask)
+	if err != nil {
+		return "", err
+	}
+	return strings.Join(keys, ","), nil
+}
+
+// FormatMaskCompact is FormatMask using group names for groups fully contained
+// in the mask (largest groups first) followed by the remaining keys
+func FormatMaskCompact(scope string, mask uint64) (string, error) {
+	table, ok := GetScopeTables(scope)
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrUnknownScope, scope)
+	}
+
+	groupNames := make([]string, 0, len(table.Groups))
+	for name, group := range table.Groups {
+		if group != 0 {
+			groupNames = append(groupNames, name)
+		}
+	}
+	sort.Slice(groupNames, func(i, j int) bool {
+		ci := bits.OnesCount64(table.Groups[groupNames[i]])
+		cj := bits.OnesCount64(table.Groups[groupNames[j]])
+		if ci != cj {
+			return ci > cj
+		}
+		return groupNames[i] < groupNames[j]
+	})
+
+	var parts []string
+	remaining := mask
+	for _, name := range groupNames {
+		group := table.Groups[name]
+		if remaining&group == group {
+			parts = append(parts, name)
+			remaining &^= group
+		}
+	}
+
+	keys, err := formatKeys(scope, remaining)
+	if err != nil {
+		return "", err
+	}
+	return strings.Join(append(parts, keys...), ","), nil
+}
+
+func formatKeys(scope string, mask uint64) ([]string, error) {
+	table, ok := GetScopeTables(scope)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownScope, scope)
+	}
+
+	var keys []string
+	for remaining := mask; remaining != 0; remaining &= remaining - 1 {
+		bit := remaining & -remaining
+		name, ok := table.Names[bit]
+		if !ok {
+			return nil, fmt.Errorf("%w: bit %d in %s", ErrUnknownPermission, bits.TrailingZeros64(bit), scope)
+		}
+		keys = append(keys, name)
+	}
+	return keys, nil
+}
@@ -0,0 +1,114 @@
+package permissions
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestParseMask(t *testing.T) {
+	mask, err := ParseMask("WEB", "WEB__TEAM_VIEW, WEB__TEAMS_EDIT")
+	if err != nil || mask != WebTeamView|WebTeamsEdit {
+		t.Fatalf("unexpected mask %#x (%v)", mask, err)
+	}
+
+	mask, err = ParseMask("WEB", "WEB__MISSION_*")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := WebMissionView | WebMissionAdd | WebMissionEdit | WebMissionDelete | WebMissionSuspend
+	if mask&want != want {
+		t.Fatalf("expected wildcard to include every mission permission, got %#x", mask)
+	}
+	for _, name := range GetAllPermissionNames(mask) {
+		if !strings.HasPrefix(name, "WEB__MISSION_") {
+			t.Fatalf("wildcard matched unrelated permission %s", name)
+		}
+	}
+
+	mask, err = ParseMask("TEAM", "TEAM__BASIC TEAM__STAFF")
+	if err != nil || mask != RoleTeamStaff {
+		t.Fatalf("expected group names to resolve to the staff preset, got %#x (%v)", mask, err)
+	}
+
+	if mask, err := ParseMask("LOBBY", " "); err != nil || mask != 0 {
+		t.Fatalf("expected empty list to be mask 0, got %#x (%v)", mask, err)
+	}
+}
+
+func TestParseMaskErrors(t *testing.T) {
+	_, err := ParseMask("TEAM", "TEAM__SUSPEND,TEAM__NOPE,WEB__TEAM_VIEW,TEAM__NOTHING_*")
+	if !errors.Is(err, ErrUnknownPermission) {
+		t.Fatalf("expected ErrUnknownPermission, got %v", err)
+	}
+	for _, item := range []string{"TEAM__NOPE", "WEB__TEAM_VIEW", "TEAM__NOTHING_*"} {
+		if !strings.Contains(err.Error(), item) {
+			t.Fatalf("expected %s to be reported in %v", item, err)
+		}
+	}
+
+	if _, err := ParseMask("GUILD", "GUILD__VIEW"); !errors.Is(err, ErrUnknownScope) {
+		t.Fatalf("expected ErrUnknownScope, got %v", err)
+	}
+}
+
+func TestFormatMask(t *testing.T) {
+	text, err := FormatMask("WEB", WebTeamsEdit|WebTeamView)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if text != strings.Join(GetAllPermissionNames(WebTeamsEdit|WebTeamView), ",") {
+		t.Fatalf("unexpected format %q", text)
+	}
+
+	parsed, err := ParseMask("WEB", text)
+	if err != nil || parsed != WebTeamsEdit|WebTeamView {
+		t.Fatalf("expected round trip, got %#x (%v)", parsed, err)
+	}
+
+	if _, err := FormatMask("LOBBY", 1<<63); !errors.Is(err, ErrUnknownPermission) {
+		t.Fatalf("expected unknown bit error, got %v", err)
+	}
+}
+
+func TestFormatMaskCompact(t *testing.T) {
+	mask := RoleTeamStaff | TeamSuspend
+	text, err := FormatMaskCompact("TEAM", mask)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !slices.Contains(strings.Split(text, ","), "TEAM__STAFF") {
+		t.Fatalf("expected the staff group in compact format %q", text)
+	}
+
+	full, _ := FormatMask("TEAM", mask)
+	if len(text) >= len(full) {
+		t.Fatalf("expected compact format %q to be shorter than %q", text, full)
+	}
+
+	parsed, err := ParseMask("TEAM", text)
+	if err != nil || parsed != mask {
+		t.Fatalf("expected compact round trip, got %#x (%v)", parsed, err)
+	}
+}
+
+func TestFormatMaskRoundTripsEveryScope(t *testing.T) {
+	for _, scope := range GetAllScopes() {
+		tables, ok := GetScopeTables(scope)
+		if !ok {
+			t.Fatalf("%s: missing scope tables", scope)
+		}
+		var all uint64
+		for bit := range tables.Names {
+			all |= bit
+		}
+		list, err := FormatMask(scope, all)
+		if err != nil {
+			t.Fatalf("%s: %v", scope, err)
+		}
+		if mask, err := ParseMask(scope, list); err != nil || mask != all {
+			t.Fatalf("%s: round trip %#x != %#x (%v)", scope, mask, all, err)
+		}
+	}
+}
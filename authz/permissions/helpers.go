@@ -28,6 +28,12 @@ func getAllPermissionNames(mask uint64, names map[uint64]string) []string {
 	return result
 }
 
+// PermissionNames returns the names of the permissions in mask in the same
+// order as the GetAll*PermissionNames helpers; bits without a name are skipped
+func (t ScopeTables) PermissionNames(mask uint64) []string {
+	return getAllPermissionNames(mask, t.Names)
+}
+
 func getRolePermissions(role string, roles map[string]uint64) uint64 {
 	if value, ok := roles[role]; ok {
 		return value
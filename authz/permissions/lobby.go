@@ -1,9 +1,9 @@
+// Code generated by authzgen from seeds/lobby.json. DO NOT EDIT.
+
 package permissions
 
 // Lobby Permission Bitmask Constants
-// Generated from Connect-Auth/seeds/permissions/lobby.json
 // Each constant represents a bit in uint64 (max 64 permissions)
-
 const (
 	// LobbyMembershipView views membership details from the lobby interface.
 	LobbyMembershipView uint64 = 1 << 0
@@ -11,7 +11,6 @@ const (
 	LobbyMembershipInvite uint64 = 1 << 1
 	// LobbyMembershipDelete deletes memberships from the lobby interface.
 	LobbyMembershipDelete uint64 = 1 << 2
-
 	// LobbyKick removes users from the lobby.
 	LobbyKick uint64 = 1 << 3
 	// LobbyManage grants full lobby management (slots, mission, server).
@@ -22,7 +21,11 @@ const (
 	LobbyTransferOwnership uint64 = 1 << 6
 )
 
-// Used for CheckUserPermission() API calls and string-based permission checks
+// ============================================
+// Lobby Permission Names (for API calls)
+// ============================================
+// String constants for use with CheckUserPermission() and similar API methods
+
 const (
 	// PermLobbyMembershipView is the permission key for LOBBY__MEMBERSHIP_VIEW.
 	PermLobbyMembershipView = "LOBBY__MEMBERSHIP_VIEW"
@@ -46,15 +49,17 @@ const (
 // String constants for lobby role identifiers
 
 const (
-	// RoleLobbyOwnerKey is the role key for a lobby owner.
-	RoleLobbyOwnerKey = "lobby_owner"
-	// RoleLobbyStaffKey is the role key for a lobby staff member.
+	// RoleLobbyStaffKey is the role key for lobby_staff.
 	RoleLobbyStaffKey = "lobby_staff"
-	// RoleLobbyUserKey is the role key for a lobby user.
+	// RoleLobbyOwnerKey is the role key for lobby_owner.
+	RoleLobbyOwnerKey = "lobby_owner"
+	// RoleLobbyUserKey is the role key for lobby_user.
 	RoleLobbyUserKey = "lobby_user"
 )
 
-// Permission Groups - Matches seeds/permissions/lobby.json groups
+// ============================================
+// Permission Groups
+// ============================================
 var (
 	// LobbyBasic - Basic lobby access
 	LobbyBasic = LobbyMembershipView |
@@ -72,25 +77,32 @@ var (
 
 // Role Presets - Calculated bitmasks for each role
 var (
-	// RoleLobbyUser - Basic lobby participant (LobbyBasic group)
-	RoleLobbyUser = LobbyBasic
-
-	// RoleLobbyStaff - Lobby staff member (LobbyBasic + LobbyStaff groups)
+	// RoleLobbyStaff - Lobby moderator with management capabilities (LobbyBasic + LobbyStaff groups)
 	RoleLobbyStaff = LobbyBasic | LobbyStaff
 
-	// RoleLobbyOwner - Lobby owner (LobbyBasic + LobbyStaff + LobbyOwner groups)
+	// RoleLobbyOwner - Lobby owner with full administrative control (LobbyBasic + LobbyStaff + LobbyOwner groups)
 	RoleLobbyOwner = LobbyBasic | LobbyStaff | LobbyOwner
+
+	// RoleLobbyUser - Basic lobby user with game participation rights (LobbyBasic groups)
+	RoleLobbyUser = LobbyBasic
 )
 
+// LobbyPermissionGroups maps each permission group key to its bitmask
+var LobbyPermissionGroups = map[string]uint64{
+	"LOBBY__BASIC": LobbyBasic,
+	"LOBBY__STAFF": LobbyStaff,
+	"LOBBY__OWNER": LobbyOwner,
+}
+
 var lobbyRolePermissions = map[string]uint64{
-	RoleLobbyOwnerKey: RoleLobbyOwner,
 	RoleLobbyStaffKey: RoleLobbyStaff,
+	RoleLobbyOwnerKey: RoleLobbyOwner,
 	RoleLobbyUserKey:  RoleLobbyUser,
 }
 
 var lobbyRoleNames = map[string]string{
-	RoleLobbyOwnerKey: "Lobby Owner",
 	RoleLobbyStaffKey: "Lobby Staff",
+	RoleLobbyOwnerKey: "Lobby Owner",
 	RoleLobbyUserKey:  "Lobby User",
 }
 
@@ -0,0 +1,63 @@
+// Code generated by authzgen from seeds/*.json. DO NOT EDIT.
+
+package permissions
+
+// ScopeTables groups the generated lookup tables of one scope, so helpers
+// that work across scopes never keep their own copy of the name tables
+type ScopeTables struct {
+	Scope           string
+	Names           map[uint64]string
+	KeyToBit        map[string]uint8
+	Groups          map[string]uint64
+	RolePermissions func(role string) uint64
+}
+
+// GetScopeTables returns the generated tables of a scope
+func GetScopeTables(scope string) (ScopeTables, bool) {
+	switch scope {
+	case "COMMUNITY":
+		return ScopeTables{
+			Scope:           "COMMUNITY",
+			Names:           CommunityPermissionNames,
+			KeyToBit:        CommunityPermissionKeyToBit,
+			Groups:          CommunityPermissionGroups,
+			RolePermissions: GetCommunityRolePermissions,
+		}, true
+	case "LOBBY":
+		return ScopeTables{
+			Scope:           "LOBBY",
+			Names:           LobbyPermissionNames,
+			KeyToBit:        LobbyPermissionKeyToBit,
+			Groups:          LobbyPermissionGroups,
+			RolePermissions: GetLobbyRolePermissions,
+		}, true
+	case "TEAM":
+		return ScopeTables{
+			Scope:           "TEAM",
+			Names:           TeamPermissionNames,
+			KeyToBit:        TeamPermissionKeyToBit,
+			Groups:          TeamPermissionGroups,
+			RolePermissions: GetTeamRolePermissions,
+		}, true
+	case "WEB":
+		return ScopeTables{
+			Scope:           "WEB",
+			Names:           PermissionNames,
+			KeyToBit:        PermissionKeyToBit,
+			Groups:          PermissionGroups,
+			RolePermissions: GetRolePermissions,
+		}, true
+	default:
+		return ScopeTables{}, false
+	}
+}
+
+// GetAllScopes returns the scopes with generated tables
+func GetAllScopes() []string {
+	return []string{
+		"COMMUNITY",
+		"LOBBY",
+		"TEAM",
+		"WEB",
+	}
+}
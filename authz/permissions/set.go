@@ -0,0 +1,366 @@
+package permissions
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/bits"
+	"strconv"
+)
+
+const (
+	wordBits = 64
+	// maxWordFloat is 2^64, the first float64 that no longer fits in a uint64 word
+	maxWordFloat float64 = 1 << 64
+)
+
+// PermissionSet is a permission bitset of arbitrary width, for scopes that
+// outgrow the 64 bits of a uint64 mask. Bit n has the same meaning as 1<<n in
+// the uint64 constants, so existing masks convert without remapping.
+//
+// A PermissionSet is immutable: every operation returns a new set.
+// The zero value is an empty set.
+type PermissionSet struct {
+	words []uint64
+}
+
+// NewPermissionSet returns a set with the given bit indices enabled
+func NewPermissionSet(bitIndices ...uint) PermissionSet {
+	return PermissionSet{}.With(bitIndices...)
+}
+
+// PermissionSetFromMask converts a legacy uint64 mask into a set
+func PermissionSetFromMask(mask uint64) PermissionSet {
+	return PermissionSetFromWords(mask)
+}
+
+// PermissionSetFromWords builds a set from 64-bit words, least significant word first
+func PermissionSetFromWords(words ...uint64) PermissionSet {
+	return PermissionSet{words: trimWords(append([]uint64(nil), words...))}
+}
+
+// Words returns the 64-bit words of the set, least significant word first
+func (s PermissionSet) Words() []uint64 {
+	return append([]uint64(nil), s.words...)
+}
+
+// Mask returns the set as a legacy uint64 mask.
+// ok is false when the set uses bits beyond 63, which a uint64 cannot represent.
+func (s PermissionSet) Mask() (mask uint64, ok bool) {
+	switch len(s.words) {
+	case 0:
+		return 0, true
+	case 1:
+		return s.words[0], true
+	default:
+		return s.words[0], false
+	}
+}
+
+// Width returns the number of bits needed to represent the set
+func (s PermissionSet) Width() int {
+	if len(s.words) == 0 {
+		return 0
+	}
+	last := len(s.words) - 1
+	return last*wordBits + bits.Len64(s.words[last])
+}
+
+// IsEmpty checks if no permission is enabled
+func (s PermissionSet) IsEmpty() bool {
+	return len(s.words) == 0
+}
+
+// Count returns the number of enabled permissions
+func (s PermissionSet) Count() int {
+	count := 0
+	for _, word := range s.words {
+		count += bits.OnesCount64(word)
+	}
+	return count
+}
+
+// Bits returns the enabled bit indices in ascending order
+func (s PermissionSet) Bits() []uint {
+	result := make([]uint, 0, s.Count())
+	for i, word := range s.words {
+		for word != 0 {
+			bit := uint(bits.TrailingZeros64(word))
+			result = append(result, uint(i)*wordBits+bit)
+			word &^= 1 << bit
+		}
+	}
+	return result
+}
+
+// Has checks if a single bit index is enabled
+func (s PermissionSet) Has(bitIndex uint) bool {
+	word := int(bitIndex / wordBits)
+	if word >= len(s.words) {
+		return false
+	}
+	return s.words[word]&(1<<(bitIndex%wordBits)) != 0
+}
+
+// With returns a copy of the set with the given bit indices enabled
+func (s PermissionSet) With(bitIndices ...uint) PermissionSet {
+	words := append([]uint64(nil), s.words...)
+	for _, bitIndex := range bitIndices {
+		word := int(bitIndex / wordBits)
+		for len(words) <= word {
+			words = append(words, 0)
+		}
+		words[word] |= 1 << (bitIndex % wordBits)
+	}
+	return PermissionSet{words: trimWords(words)}
+}
+
+// Without returns a copy of the set with the given bit indices disabled
+func (s PermissionSet) Without(bitIndices ...uint) PermissionSet {
+	words := append([]uint64(nil), s.words...)
+	for _, bitIndex := range bitIndices {
+		word := int(bitIndex / wordBits)
+		if word < len(words) {
+			words[word] &^= 1 << (bitIndex % wordBits)
+		}
+	}
+	return PermissionSet{words: trimWords(words)}
+}
+
+// Union returns the permissions present in either set
+func (s PermissionSet) Union(other PermissionSet) PermissionSet {
+	longer, shorter := s.words, other.words
+	if len(shorter) > len(longer) {
+		longer, shorter = shorter, longer
+	}
+	words := append([]uint64(nil), longer...)
+	for i, word := range shorter {
+		words[i] |= word
+	}
+	return PermissionSet{words: words}
+}
+
+// Intersect returns the permissions present in both sets
+func (s PermissionSet) Intersect(other PermissionSet) PermissionSet {
+	words := make([]uint64, min(len(s.words), len(other.words)))
+	for i := range words {
+		words[i] = s.words[i] & other.words[i]
+	}
+	return PermissionSet{words: trimWords(words)}
+}
+
+// ApplyDenyMask removes the denied permissions from the set.
+// Deny permissions take precedence over allow permissions.
+func (s PermissionSet) ApplyDenyMask(deny PermissionSet) PermissionSet {
+	words := append([]uint64(nil), s.words...)
+	for i := 0; i < len(words) && i < len(deny.words); i++ {
+		words[i] &^= deny.words[i]
+	}
+	return PermissionSet{words: trimWords(words)}
+}
+
+// HasPermission checks if the set contains every bit of permission
+func (s PermissionSet) HasPermission(permission PermissionSet) bool {
+	if len(permission.words) > len(s.words) {
+		return false
+	}
+	for i, word := range permission.words {
+		if s.words[i]&word != word {
+			return false
+		}
+	}
+	return true
+}
+
+// HasAnyPermission checks if the set has at least one of the specified permissions
+func (s PermissionSet) HasAnyPermission(permissions ...PermissionSet) bool {
+	for _, perm := range permissions {
+		if s.HasPermission(perm) {
+			return true
+		}
+	}
+	return false
+}
+
+// HasAllPermissions checks if the set has all of the specified permissions
+func (s PermissionSet) HasAllPermissions(permissions ...PermissionSet) bool {
+	for _, perm := range permissions {
+		if !s.HasPermission(perm) {
+			return false
+		}
+	}
+	return true
+}
+
+// Equal checks if both sets enable exactly the same bits
+func (s PermissionSet) Equal(other PermissionSet) bool {
+	if len(s.words) != len(other.words) {
+		return false
+	}
+	for i, word := range s.words {
+		if other.words[i] != word {
+			return false
+		}
+	}
+	return true
+}
+
+// CanPerformActionSet is the PermissionSet counterpart of CanPerformAction
+func CanPerformActionSet(allow, deny, requiredPermission PermissionSet) bool {
+	return allow.ApplyDenyMask(deny).HasPermission(requiredPermission)
+}
+
+// CanPerformAnyActionSet is the PermissionSet counterpart of CanPerformAnyAction
+func CanPerformAnyActionSet(allow, deny PermissionSet, requiredPermissions ...PermissionSet) bool {
+	return allow.ApplyDenyMask(deny).HasAnyPermission(requiredPermissions...)
+}
+
+// CanPerformAllActionsSet is the PermissionSet counterpart of CanPerformAllActions
+func CanPerformAllActionsSet(allow, deny PermissionSet, requiredPermissions ...PermissionSet) bool {
+	return allow.ApplyDenyMask(deny).HasAllPermissions(requiredPermissions...)
+}
+
+// ============================================
+// Serialization
+// ============================================
+
+// ErrInvalidPermissionSet is returned when an encoded permission set cannot be decoded
+var ErrInvalidPermissionSet = errors.New("invalid permission set encoding")
+
+// Encode returns the compact claim representation: unpadded base64url of the
+// little-endian bytes of the set, without trailing zero bytes ("" when empty)
+func (s PermissionSet) Encode() string {
+	raw := make([]byte, len(s.words)*8)
+	for i, word := range s.words {
+		binary.LittleEndian.PutUint64(raw[i*8:], word)
+	}
+	return base64.RawURLEncoding.EncodeToString(bytes.TrimRight(raw, "\x00"))
+}
+
+// DecodePermissionSet parses the output of Encode
+func DecodePermissionSet(encoded string) (PermissionSet, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return PermissionSet{}, fmt.Errorf("%w: %v", ErrInvalidPermissionSet, err)
+	}
+
+	words := make([]uint64, (len(raw)+7)/8)
+	padded := make([]byte, len(words)*8)
+	copy(padded, raw)
+	for i := range words {
+		words[i] = binary.LittleEndian.Uint64(padded[i*8:])
+	}
+	return PermissionSet{words: trimWords(words)}, nil
+}
+
+// String returns the compact encoding (see Encode)
+func (s PermissionSet) String() string {
+	return s.Encode()
+}
+
+// MarshalJSON encodes the set as its compact base64url string
+func (s PermissionSet) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.Encode())
+}
+
+// UnmarshalJSON accepts every claim format issued so far:
+//   - a number: legacy uint64 mask (allow_permissions/deny_permissions)
+//   - a string: compact base64url encoding (see Encode)
+//   - an array of numbers: 64-bit words, least significant word first
+//   - null: empty set
+func (s *PermissionSet) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 {
+		return ErrInvalidPermissionSet
+	}
+
+	switch data[0] {
+	case 'n':
+		*s = PermissionSet{}
+		return nil
+	case '"':
+		var encoded string
+		if err := json.Unmarshal(data, &encoded); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidPermissionSet, err)
+		}
+		set, err := DecodePermissionSet(encoded)
+		if err != nil {
+			return err
+		}
+		*s = set
+		return nil
+	case '[':
+		var numbers []json.Number
+		if err := json.Unmarshal(data, &numbers); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidPermissionSet, err)
+		}
+		words := make([]uint64, len(numbers))
+		for i, number := range numbers {
+			word, err := parseWord(number.String())
+			if err != nil {
+				return err
+			}
+			words[i] = word
+		}
+		*s = PermissionSetFromWords(words...)
+		return nil
+	default:
+		mask, err := parseWord(string(data))
+		if err != nil {
+			return err
+		}
+		*s = PermissionSetFromMask(mask)
+		return nil
+	}
+}
+
+// PermissionSetFromClaim decodes a claim value already parsed into Go values
+// (as found in jwt.MapClaims): float64 legacy masks, encoded strings or word arrays.
+func PermissionSetFromClaim(value any) (PermissionSet, error) {
+	if value == nil {
+		return PermissionSet{}, nil
+	}
+	if number, ok := value.(float64); ok {
+		if number < 0 || number >= maxWordFloat || number != math.Trunc(number) {
+			return PermissionSet{}, fmt.Errorf("%w: mask %v out of range", ErrInvalidPermissionSet, number)
+		}
+		return PermissionSetFromMask(uint64(number)), nil
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return PermissionSet{}, fmt.Errorf("%w: %v", ErrInvalidPermissionSet, err)
+	}
+	var set PermissionSet
+	if err := set.UnmarshalJSON(data); err != nil {
+		return PermissionSet{}, err
+	}
+	return set, nil
+}
+
+// parseWord parses a JSON number into a uint64 word; tokens signed by JS or
+// decoded through float64 may carry exponent notation (1.099511627776e+12)
+func parseWord(value string) (uint64, error) {
+	if word, err := strconv.ParseUint(value, 10, 64); err == nil {
+		return word, nil
+	}
+	number, err := strconv.ParseFloat(value, 64)
+	if err != nil || number < 0 || number >= maxWordFloat || number != math.Trunc(number) {
+		return 0, fmt.Errorf("%w: invalid mask word %q", ErrInvalidPermissionSet, value)
+	}
+	return uint64(number), nil
+}
+
+func trimWords(words []uint64) []uint64 {
+	for len(words) > 0 && words[len(words)-1] == 0 {
+		words = words[:len(words)-1]
+	}
+	if len(words) == 0 {
+		return nil
+	}
+	return words
+}
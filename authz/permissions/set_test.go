@@ -0,0 +1,149 @@
+package permissions
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestPermissionSetOperations(t *testing.T) {
+	allow := NewPermissionSet(0, 2, 70, 130)
+	deny := NewPermissionSet(2, 130)
+
+	if !allow.Has(70) || allow.Has(71) {
+		t.Fatalf("unexpected bit membership")
+	}
+	if allow.Width() != 131 || allow.Count() != 4 {
+		t.Fatalf("unexpected width=%d count=%d", allow.Width(), allow.Count())
+	}
+
+	effective := allow.ApplyDenyMask(deny)
+	if got := effective.Bits(); len(got) != 2 || got[0] != 0 || got[1] != 70 {
+		t.Fatalf("unexpected effective bits: %v", got)
+	}
+	if len(effective.Words()) != 2 {
+		t.Fatalf("expected trailing empty words to be trimmed, got %v", effective.Words())
+	}
+
+	if !CanPerformActionSet(allow, deny, NewPermissionSet(70)) {
+		t.Fatalf("expected wide permission to be allowed")
+	}
+	if CanPerformActionSet(allow, deny, NewPermissionSet(130)) {
+		t.Fatalf("did not expect denied wide permission to be allowed")
+	}
+	if !CanPerformAnyActionSet(allow, deny, NewPermissionSet(130), NewPermissionSet(0)) {
+		t.Fatalf("expected any-action check to pass")
+	}
+	if CanPerformAllActionsSet(allow, deny, NewPermissionSet(0), NewPermissionSet(2)) {
+		t.Fatalf("did not expect all-actions check to pass")
+	}
+
+	if !allow.Union(NewPermissionSet(5)).Has(5) || allow.Intersect(deny).Count() != 2 {
+		t.Fatalf("unexpected union/intersection")
+	}
+	if allow.Without(70).Has(70) || !allow.Has(70) {
+		t.Fatalf("expected Without to return a modified copy")
+	}
+}
+
+func TestPermissionSetMatchesUint64Helpers(t *testing.T) {
+	allow := TeamMembershipInvite | TeamServerAdd | TeamRolesView
+	deny := TeamServerAdd
+
+	set := PermissionSetFromMask(allow).ApplyDenyMask(PermissionSetFromMask(deny))
+	mask, ok := set.Mask()
+	if !ok || mask != ApplyDenyMask(allow, deny) {
+		t.Fatalf("expected set to match ApplyDenyMask, got %#x", mask)
+	}
+
+	for _, perm := range []uint64{TeamServerAdd, TeamMembershipInvite, TeamRolesEdit} {
+		want := CanPerformAction(allow, deny, perm)
+		got := CanPerformActionSet(PermissionSetFromMask(allow), PermissionSetFromMask(deny), PermissionSetFromMask(perm))
+		if got != want {
+			t.Fatalf("mismatch for %s: set=%v mask=%v", GetTeamPermissionName(perm), got, want)
+		}
+	}
+
+	if _, ok := NewPermissionSet(64).Mask(); ok {
+		t.Fatalf("expected wide set to not fit in uint64")
+	}
+}
+
+func TestPermissionSetEncoding(t *testing.T) {
+	set := NewPermissionSet(0, 39, 64, 200)
+
+	decoded, err := DecodePermissionSet(set.Encode())
+	if err != nil {
+		t.Fatalf("unexpected decode error: %v", err)
+	}
+	if !decoded.Equal(set) {
+		t.Fatalf("round trip mismatch: %v vs %v", decoded.Bits(), set.Bits())
+	}
+	if (PermissionSet{}).Encode() != "" {
+		t.Fatalf("expected empty encoding for empty set")
+	}
+	if _, err := DecodePermissionSet("***"); !errors.Is(err, ErrInvalidPermissionSet) {
+		t.Fatalf("expected ErrInvalidPermissionSet, got %v", err)
+	}
+}
+
+func TestPermissionSetJSONBackwardCompatibility(t *testing.T) {
+	var claims struct {
+		Legacy   PermissionSet `json:"legacy"`
+		Exponent PermissionSet `json:"exponent"`
+		Encoded  PermissionSet `json:"encoded"`
+		Words    PermissionSet `json:"words"`
+		Null     PermissionSet `json:"null"`
+	}
+
+	encoded, _ := json.Marshal(NewPermissionSet(65))
+	payload := `{"legacy": 12, "exponent": 1.099511627776e+12, "encoded": ` + string(encoded) +
+		`, "words": [1, 18446744073709551615], "null": null}`
+	if err := json.Unmarshal([]byte(payload), &claims); err != nil {
+		t.Fatalf("unexpected unmarshal error: %v", err)
+	}
+
+	if mask, _ := claims.Legacy.Mask(); mask != 12 {
+		t.Fatalf("unexpected legacy mask %d", mask)
+	}
+	if mask, _ := claims.Exponent.Mask(); mask != 1<<40 {
+		t.Fatalf("unexpected exponent mask %d", mask)
+	}
+	if !claims.Encoded.Equal(NewPermissionSet(65)) {
+		t.Fatalf("unexpected encoded set %v", claims.Encoded.Bits())
+	}
+	if claims.Words.Count() != 65 || !claims.Words.Has(127) {
+		t.Fatalf("unexpected words set %v", claims.Words.Words())
+	}
+	if !claims.Null.IsEmpty() {
+		t.Fatalf("expected null to decode as empty set")
+	}
+
+	if err := json.Unmarshal([]byte(`-1`), &claims.Legacy); !errors.Is(err, ErrInvalidPermissionSet) {
+		t.Fatalf("expected negative mask to be rejected, got %v", err)
+	}
+}
+
+func TestPermissionSetFromClaim(t *testing.T) {
+	set, err := PermissionSetFromClaim(float64(WebRolesEdit | WebCommunityView))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if mask, _ := set.Mask(); mask != WebRolesEdit|WebCommunityView {
+		t.Fatalf("unexpected mask %#x", mask)
+	}
+
+	set, err = PermissionSetFromClaim(NewPermissionSet(90).Encode())
+	if err != nil || !set.Has(90) {
+		t.Fatalf("expected encoded claim to decode, err=%v", err)
+	}
+
+	set, err = PermissionSetFromClaim([]any{float64(1), float64(2)})
+	if err != nil || !set.Has(0) || !set.Has(65) {
+		t.Fatalf("expected word claim to decode, err=%v", err)
+	}
+
+	if _, err := PermissionSetFromClaim(true); !errors.Is(err, ErrInvalidPermissionSet) {
+		t.Fatalf("expected invalid claim error, got %v", err)
+	}
+}
@@ -1,32 +1,28 @@
+// Code generated by authzgen from seeds/team.json. DO NOT EDIT.
+
 package permissions
 
 // Team Permission Bitmask Constants
-// Generated from Connect-Auth/seeds/permissions/team.json
 // Each constant represents a bit in uint64 (max 64 permissions)
-
 const (
 	// TeamMembershipInvite invites users to the team (membership invite).
 	TeamMembershipInvite uint64 = 1 << 0
 	// TeamMembershipDelete removes memberships from the team.
 	TeamMembershipDelete uint64 = 1 << 1
-
 	// TeamServerAdd adds a server to the team's server list.
 	TeamServerAdd uint64 = 1 << 2
 	// TeamServerEdit edits an existing server in the team's server list.
 	TeamServerEdit uint64 = 1 << 3
 	// TeamServerDelete deletes a server from the team's server list.
 	TeamServerDelete uint64 = 1 << 4
-
 	// TeamMissionListAdd adds a new mission to the team's mission list.
 	TeamMissionListAdd uint64 = 1 << 5
 	// TeamMissionListEdit edits an existing mission in the team's mission list.
 	TeamMissionListEdit uint64 = 1 << 6
-
 	// TeamGamemodeListAdd adds a new gamemode to the team's gamemode list.
 	TeamGamemodeListAdd uint64 = 1 << 7
 	// TeamGamemodeListEdit edits an existing gamemode in the team's gamemode list.
 	TeamGamemodeListEdit uint64 = 1 << 8
-
 	// TeamInfoEdit edits team information.
 	TeamInfoEdit uint64 = 1 << 9
 	// TeamAnalytics views team statistics and analytics.
@@ -39,7 +35,6 @@ const (
 	TeamAuditView uint64 = 1 << 13
 	// TeamLobbyCreate creates a lobby associated with the team.
 	TeamLobbyCreate uint64 = 1 << 14
-
 	// TeamRolesView views roles and permissions for this team.
 	TeamRolesView uint64 = 1 << 15
 	// TeamRolesEdit edits roles and permissions for this team.
@@ -56,24 +51,20 @@ const (
 	PermTeamMembershipInvite = "TEAM__MEMBERSHIP_INVITE"
 	// PermTeamMembershipDelete is the permission key for TEAM__MEMBERSHIP_DELETE.
 	PermTeamMembershipDelete = "TEAM__MEMBERSHIP_DELETE"
-
 	// PermTeamServerAdd is the permission key for TEAM__SERVER_ADD.
 	PermTeamServerAdd = "TEAM__SERVER_ADD"
 	// PermTeamServerEdit is the permission key for TEAM__SERVER_EDIT.
 	PermTeamServerEdit = "TEAM__SERVER_EDIT"
 	// PermTeamServerDelete is the permission key for TEAM__SERVER_DELETE.
 	PermTeamServerDelete = "TEAM__SERVER_DELETE"
-
 	// PermTeamMissionListAdd is the permission key for TEAM__MISSIONLIST_ADD.
 	PermTeamMissionListAdd = "TEAM__MISSIONLIST_ADD"
 	// PermTeamMissionListEdit is the permission key for TEAM__MISSIONLIST_EDIT.
 	PermTeamMissionListEdit = "TEAM__MISSIONLIST_EDIT"
-
 	// PermTeamGamemodeListAdd is the permission key for TEAM__GAMEMODELIST_ADD.
 	PermTeamGamemodeListAdd = "TEAM__GAMEMODELIST_ADD"
 	// PermTeamGamemodeListEdit is the permission key for TEAM__GAMEMODELIST_EDIT.
 	PermTeamGamemodeListEdit = "TEAM__GAMEMODELIST_EDIT"
-
 	// PermTeamInfoEdit is the permission key for TEAM__INFO_EDIT.
 	PermTeamInfoEdit = "TEAM__INFO_EDIT"
 	// PermTeamAnalytics is the permission key for TEAM__ANALYTICS.
@@ -86,7 +77,6 @@ const (
 	PermTeamAuditView = "TEAM__AUDIT_VIEW"
 	// PermTeamLobbyCreate is the permission key for TEAM__LOBBY_CREATE.
 	PermTeamLobbyCreate = "TEAM__LOBBY_CREATE"
-
 	// PermTeamRolesView is the permission key for TEAM__ROLES_VIEW.
 	PermTeamRolesView = "TEAM__ROLES_VIEW"
 	// PermTeamRolesEdit is the permission key for TEAM__ROLES_EDIT.
@@ -99,18 +89,17 @@ const (
 // String constants for team role identifiers
 
 const (
-	// RoleTeamOwnerKey is the role key for a team owner.
-	RoleTeamOwnerKey = "team_owner"
-	// RoleTeamStaffKey is the role key for a team staff member.
-	RoleTeamStaffKey = "team_staff"
-	// RoleTeamUserKey is the role key for a team user.
+	// RoleTeamUserKey is the role key for team_user.
 	RoleTeamUserKey = "team_user"
+	// RoleTeamStaffKey is the role key for team_staff.
+	RoleTeamStaffKey = "team_staff"
+	// RoleTeamOwnerKey is the role key for team_owner.
+	RoleTeamOwnerKey = "team_owner"
 )
 
 // ============================================
 // Permission Groups
 // ============================================
-// Matches seeds/permissions/team.json groups
 var (
 	// TeamBasic - Basic team membership
 	TeamBasic uint64 = 0
@@ -139,26 +128,33 @@ var (
 
 // Role Presets - Calculated bitmasks for each role
 var (
-	// RoleTeamUser - Basic team member (TeamBasic group)
+	// RoleTeamUser - Basic team member with participation rights (TeamBasic groups)
 	RoleTeamUser = TeamBasic
 
-	// RoleTeamStaff - Team staff member (TeamBasic + TeamStaff groups)
+	// RoleTeamStaff - Team moderator with management capabilities (TeamBasic + TeamStaff groups)
 	RoleTeamStaff = TeamBasic | TeamStaff
 
-	// RoleTeamOwner - Team owner (TeamBasic + TeamStaff + TeamOwner groups)
+	// RoleTeamOwner - Team owner with full administrative control (TeamBasic + TeamStaff + TeamOwner groups)
 	RoleTeamOwner = TeamBasic | TeamStaff | TeamOwner
 )
 
+// TeamPermissionGroups maps each permission group key to its bitmask
+var TeamPermissionGroups = map[string]uint64{
+	"TEAM__BASIC": TeamBasic,
+	"TEAM__STAFF": TeamStaff,
+	"TEAM__OWNER": TeamOwner,
+}
+
 var teamRolePermissions = map[string]uint64{
-	RoleTeamOwnerKey: RoleTeamOwner,
-	RoleTeamStaffKey: RoleTeamStaff,
 	RoleTeamUserKey:  RoleTeamUser,
+	RoleTeamStaffKey: RoleTeamStaff,
+	RoleTeamOwnerKey: RoleTeamOwner,
 }
 
 var teamRoleNames = map[string]string{
-	RoleTeamOwnerKey: "Team Owner",
-	RoleTeamStaffKey: "Team Staff",
 	RoleTeamUserKey:  "Team User",
+	RoleTeamStaffKey: "Team Staff",
+	RoleTeamOwnerKey: "Team Owner",
 }
 
 // GetTeamRolePermissions returns the permission bitmask for a given team role
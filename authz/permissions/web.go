@@ -1,9 +1,9 @@
+// Code generated by authzgen from seeds/web.json. DO NOT EDIT.
+
 package permissions
 
 // Web Permission Bitmask Constants
-// Generated from Connect-Auth/seeds/permissions/web.json
 // Each constant represents a bit in uint64 (max 64 permissions)
-
 const (
 	// WebCommunityView views community listing and details from the web.
 	WebCommunityView uint64 = 1 << 0
@@ -17,7 +17,6 @@ const (
 	WebCommunitiesTransferOwnership uint64 = 1 << 5
 	// WebCommunitiesSuspend suspends or unsuspends communities.
 	WebCommunitiesSuspend uint64 = 1 << 6
-
 	// WebTeamView views team listing and details from the web.
 	WebTeamView uint64 = 1 << 7
 	// WebTeamsEdit edits team information from the web.
@@ -28,14 +27,12 @@ const (
 	WebTeamsTransferOwnership uint64 = 1 << 10
 	// WebTeamsSuspend suspends or unsuspends teams.
 	WebTeamsSuspend uint64 = 1 << 11
-
 	// WebMembershipView views membership details from the web interface.
 	WebMembershipView uint64 = 1 << 12
 	// WebMembershipInvite invites users to scopes (communities, teams, web) via the web interface.
 	WebMembershipInvite uint64 = 1 << 13
 	// WebMembershipDelete removes memberships from the web interface.
 	WebMembershipDelete uint64 = 1 << 14
-
 	// WebSanctionsView views sanctions applied to users.
 	WebSanctionsView uint64 = 1 << 15
 	// WebSanctionsAdd applies a sanction to a user.
@@ -46,7 +43,6 @@ const (
 	WebSanctionsDelete uint64 = 1 << 18
 	// WebSanctionsSuspend temporarily suspends users via sanctions.
 	WebSanctionsSuspend uint64 = 1 << 19
-
 	// WebMissionView views mission listings and details from the web.
 	WebMissionView uint64 = 1 << 20
 	// WebMissionAdd creates new missions from the web.
@@ -57,7 +53,6 @@ const (
 	WebMissionDelete uint64 = 1 << 23
 	// WebMissionSuspend suspends or unsuspends missions from the web.
 	WebMissionSuspend uint64 = 1 << 24
-
 	// WebGamemodeView views gamemode listings and details from the web.
 	WebGamemodeView uint64 = 1 << 25
 	// WebGamemodeAdd creates new gamemodes from the web.
@@ -68,7 +63,6 @@ const (
 	WebGamemodeDelete uint64 = 1 << 28
 	// WebGamemodeSuspend suspends or unsuspends gamemodes from the web.
 	WebGamemodeSuspend uint64 = 1 << 29
-
 	// WebLobbyView views lobby listings and details from the web.
 	WebLobbyView uint64 = 1 << 30
 	// WebLobbyCreatePublic creates new public lobbies from the web.
@@ -79,14 +73,12 @@ const (
 	WebLobbyJoin uint64 = 1 << 33
 	// WebLobbySpectate spectates lobbies from the web.
 	WebLobbySpectate uint64 = 1 << 34
-
 	// WebViewAuditLog accesses audit logs.
 	WebViewAuditLog uint64 = 1 << 35
 	// WebViewMetrics accesses platform metrics and statistics.
 	WebViewMetrics uint64 = 1 << 36
 	// WebSettings modifies platform settings.
 	WebSettings uint64 = 1 << 37
-
 	// WebRolesView views roles and permissions.
 	WebRolesView uint64 = 1 << 38
 	// WebRolesEdit edits roles and permissions.
@@ -111,7 +103,6 @@ const (
 	PermWebCommunitiesTransferOwnership = "WEB__COMMUNITIES_TRANSFER_OWNERSHIP"
 	// PermWebCommunitiesSuspend is the permission key for WEB__COMMUNITIES_SUSPEND.
 	PermWebCommunitiesSuspend = "WEB__COMMUNITIES_SUSPEND"
-
 	// PermWebTeamView is the permission key for WEB__TEAM_VIEW.
 	PermWebTeamView = "WEB__TEAM_VIEW"
 	// PermWebTeamsEdit is the permission key for WEB__TEAMS_EDIT.
@@ -122,14 +113,12 @@ const (
 	PermWebTeamsTransferOwnership = "WEB__TEAMS_TRANSFER_OWNERSHIP"
 	// PermWebTeamsSuspend is the permission key for WEB__TEAMS_SUSPEND.
 	PermWebTeamsSuspend = "WEB__TEAMS_SUSPEND"
-
 	// PermWebMembershipView is the permission key for WEB__MEMBERSHIP_VIEW.
 	PermWebMembershipView = "WEB__MEMBERSHIP_VIEW"
 	// PermWebMembershipInvite is the permission key for WEB__MEMBERSHIP_INVITE.
 	PermWebMembershipInvite = "WEB__MEMBERSHIP_INVITE"
 	// PermWebMembershipDelete is the permission key for WEB__MEMBERSHIP_DELETE.
 	PermWebMembershipDelete = "WEB__MEMBERSHIP_DELETE"
-
 	// PermWebSanctionsView is the permission key for WEB__SANCTIONS_VIEW.
 	PermWebSanctionsView = "WEB__SANCTIONS_VIEW"
 	// PermWebSanctionsAdd is the permission key for WEB__SANCTIONS_ADD.
@@ -140,7 +129,6 @@ const (
 	PermWebSanctionsDelete = "WEB__SANCTIONS_DELETE"
 	// PermWebSanctionsSuspend is the permission key for WEB__SANCTIONS_SUSPEND.
 	PermWebSanctionsSuspend = "WEB__SANCTIONS_SUSPEND"
-
 	// PermWebMissionView is the permission key for WEB__MISSION_VIEW.
 	PermWebMissionView = "WEB__MISSION_VIEW"
 	// PermWebMissionAdd is the permission key for WEB__MISSION_ADD.
@@ -151,7 +139,6 @@ const (
 	PermWebMissionDelete = "WEB__MISSION_DELETE"
 	// PermWebMissionSuspend is the permission key for WEB__MISSION_SUSPEND.
 	PermWebMissionSuspend = "WEB__MISSION_SUSPEND"
-
 	// PermWebGamemodeView is the permission key for WEB__GAMEMODE_VIEW.
 	PermWebGamemodeView = "WEB__GAMEMODE_VIEW"
 	// PermWebGamemodeAdd is the permission key for WEB__GAMEMODE_ADD.
@@ -162,7 +149,6 @@ const (
 	PermWebGamemodeDelete = "WEB__GAMEMODE_DELETE"
 	// PermWebGamemodeSuspend is the permission key for WEB__GAMEMODE_SUSPEND.
 	PermWebGamemodeSuspend = "WEB__GAMEMODE_SUSPEND"
-
 	// PermWebLobbyView is the permission key for WEB__LOBBY_VIEW.
 	PermWebLobbyView = "WEB__LOBBY_VIEW"
 	// PermWebLobbyCreatePublic is the permission key for WEB__LOBBY_CREATE_PUBLIC.
@@ -173,14 +159,12 @@ const (
 	PermWebLobbyJoin = "WEB__LOBBY_JOIN"
 	// PermWebLobbySpectate is the permission key for WEB__LOBBY_SPECTATE.
 	PermWebLobbySpectate = "WEB__LOBBY_SPECTATE"
-
 	// PermWebViewAuditLog is the permission key for WEB__VIEW_AUDIT_LOG.
 	PermWebViewAuditLog = "WEB__VIEW_AUDIT_LOG"
 	// PermWebViewMetrics is the permission key for WEB__VIEW_METRICS.
 	PermWebViewMetrics = "WEB__VIEW_METRICS"
 	// PermWebSettings is the permission key for WEB__SETTINGS.
 	PermWebSettings = "WEB__SETTINGS"
-
 	// PermWebRolesView is the permission key for WEB__ROLES_VIEW.
 	PermWebRolesView = "WEB__ROLES_VIEW"
 	// PermWebRolesEdit is the permission key for WEB__ROLES_EDIT.
@@ -193,18 +177,17 @@ const (
 // String constants for web role identifiers
 
 const (
-	// RoleWebOwnerKey is the role key for a web owner.
-	RoleWebOwnerKey = "web_owner"
-	// RoleWebStaffKey is the role key for a web staff member.
-	RoleWebStaffKey = "web_staff"
-	// RoleWebUserKey is the role key for a web user.
+	// RoleWebUserKey is the role key for web_user.
 	RoleWebUserKey = "web_user"
+	// RoleWebStaffKey is the role key for web_staff.
+	RoleWebStaffKey = "web_staff"
+	// RoleWebOwnerKey is the role key for web_owner.
+	RoleWebOwnerKey = "web_owner"
 )
 
 // ============================================
 // Permission Groups
 // ============================================
-// Matches seeds/permissions/web.json groups
 var (
 	// WebBasic - Basic web user permissions
 	WebBasic = WebCommunityView |
@@ -254,39 +237,46 @@ var (
 
 // Role Presets - Calculated bitmasks for each role
 var (
-	// RoleWebUser - Basic web user (WebBasic group)
+	// RoleWebUser - Basic web platform user with read access (WebBasic groups)
 	RoleWebUser = WebBasic
 
-	// RoleWebStaff - Staff member (WebBasic + WebStaff groups)
+	// RoleWebStaff - Staff member with moderation capabilities (WebBasic + WebStaff groups)
 	RoleWebStaff = WebBasic | WebStaff
 
-	// RoleWebOwner - Platform owner (WebBasic + WebStaff + WebOwner groups)
+	// RoleWebOwner - Platform owner with full administrative access (WebBasic + WebStaff + WebOwner groups)
 	RoleWebOwner = WebBasic | WebStaff | WebOwner
 )
 
+// PermissionGroups maps each permission group key to its bitmask
+var PermissionGroups = map[string]uint64{
+	"WEB__BASIC": WebBasic,
+	"WEB__STAFF": WebStaff,
+	"WEB__OWNER": WebOwner,
+}
+
 var webRolePermissions = map[string]uint64{
-	RoleWebOwnerKey: RoleWebOwner,
-	RoleWebStaffKey: RoleWebStaff,
 	RoleWebUserKey:  RoleWebUser,
+	RoleWebStaffKey: RoleWebStaff,
+	RoleWebOwnerKey: RoleWebOwner,
 }
 
 var webRoleNames = map[string]string{
-	RoleWebOwnerKey: "Web Owner",
-	RoleWebStaffKey: "Web Staff",
 	RoleWebUserKey:  "Web User",
+	RoleWebStaffKey: "Web Staff",
+	RoleWebOwnerKey: "Web Owner",
 }
 
-// GetRolePermissions returns the permission bitmask for a given role
+// GetRolePermissions returns the permission bitmask for a given web role
 func GetRolePermissions(role string) uint64 {
 	return getRolePermissions(role, webRolePermissions)
 }
 
-// GetRoleName returns the human-readable name of a role
+// GetRoleName returns the human-readable name of a web role
 func GetRoleName(role string) string {
 	return getRoleName(role, webRoleNames)
 }
 
-// IsRoleValid checks if a role identifier is valid
+// IsRoleValid checks if a web role identifier is valid
 func IsRoleValid(role string) bool {
 	return isRoleValid(role, webRoleNames)
 }
@@ -0,0 +1,298 @@
+package policy
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Condition is a named predicate over a request. The name is reported in
+// Result.FailedCondition.
+type Condition struct {
+	Name  string
+	Check func(Request) bool
+}
+
+// Func wraps a custom predicate
+func Func(name string, check func(Request) bool) Condition {
+	return Condition{Name: name, Check: check}
+}
+
+// Operand resolves a value from a request; ok is false when the attribute is missing
+type Operand struct {
+	Name    string
+	Resolve func(Request) (value interface{}, ok bool)
+}
+
+// SubjectAttr reads a subject attribute
+func SubjectAttr(key string) Operand {
+	return attribute("subject."+key, key, func(req Request) Attributes { return req.Subject })
+}
+
+// ResourceAttr reads a resource attribute
+func ResourceAttr(key string) Operand {
+	return attribute("resource."+key, key, func(req Request) Attributes { return req.Resource })
+}
+
+// EnvAttr reads an environment attribute
+func EnvAttr(key string) Operand {
+	return attribute("env."+key, key, func(req Request) Attributes { return req.Environment })
+}
+
+// Value is a constant operand
+func Value(v interface{}) Operand {
+	return Operand{
+		Name:    fmt.Sprintf("%v", v),
+		Resolve: func(Request) (interface{}, bool) { return v, true },
+	}
+}
+
+func attribute(name, key string, source func(Request) Attributes) Operand {
+	return Operand{
+		Name: name,
+		Resolve: func(req Request) (interface{}, bool) {
+			value, ok := source(req)[key]
+			return value, ok
+		},
+	}
+}
+
+// Eq holds when both operands are present and equal (numbers compare by
+// value; integers exactly, so large IDs such as SteamID64 never collide)
+func Eq(a, b Operand) Condition {
+	return compare(a, "==", b, func(x, y interface{}) bool { return equal(x, y) })
+}
+
+// Ne holds when both operands are present and different
+func Ne(a, b Operand) Condition {
+	return compare(a, "!=", b, func(x, y interface{}) bool { return !equal(x, y) })
+}
+
+// Lt holds when both operands are numbers and a < b
+func Lt(a, b Operand) Condition {
+	return numeric(a, "<", b, func(c int) bool { return c < 0 })
+}
+
+// Lte holds when both operands are numbers and a <= b
+func Lte(a, b Operand) Condition {
+	return numeric(a, "<=", b, func(c int) bool { return c <= 0 })
+}
+
+// Gt holds when both operands are numbers and a > b
+func Gt(a, b Operand) Condition {
+	return numeric(a, ">", b, func(c int) bool { return c > 0 })
+}
+
+// Gte holds when both operands are numbers and a >= b
+func Gte(a, b Operand) Condition {
+	return numeric(a, ">=", b, func(c int) bool { return c >= 0 })
+}
+
+// In holds when the operand equals one of values
+func In(a Operand, values ...interface{}) Condition {
+	return Condition{
+		Name: fmt.Sprintf("%s in %v", a.Name, values),
+		Check: func(req Request) bool {
+			x, ok := a.Resolve(req)
+			if !ok {
+				return false
+			}
+			for _, v := range values {
+				if equal(x, v) {
+					return true
+				}
+			}
+			return false
+		},
+	}
+}
+
+// IsSet holds when the attribute is present
+func IsSet(a Operand) Condition {
+	return Condition{
+		Name: a.Name + " is set",
+		Check: func(req Request) bool {
+			_, ok := a.Resolve(req)
+			return ok
+		},
+	}
+}
+
+// Not negates a condition
+func Not(c Condition) Condition {
+	return Condition{
+		Name:  "not (" + c.Name + ")",
+		Check: func(req Request) bool { return !c.Check(req) },
+	}
+}
+
+// All holds when every condition holds
+func All(conditions ...Condition) Condition {
+	return Condition{
+		Name: joinNames(conditions, " and "),
+		Check: func(req Request) bool {
+			for _, c := range conditions {
+				if !c.Check(req) {
+					return false
+				}
+			}
+			return true
+		},
+	}
+}
+
+// Any holds when at least one condition holds
+func Any(conditions ...Condition) Condition {
+	return Condition{
+		Name: joinNames(conditions, " or "),
+		Check: func(req Request) bool {
+			for _, c := range conditions {
+				if c.Check(req) {
+					return true
+				}
+			}
+			return false
+		},
+	}
+}
+
+func compare(a Operand, op string, b Operand, check func(x, y interface{}) bool) Condition {
+	return Condition{
+		Name: a.Name + " " + op + " " + b.Name,
+		Check: func(req Request) bool {
+			x, okA := a.Resolve(req)
+			y, okB := b.Resolve(req)
+			return okA && okB && check(x, y)
+		},
+	}
+}
+
+func numeric(a Operand, op string, b Operand, check func(c int) bool) Condition {
+	return compare(a, op, b, func(x, y interface{}) bool {
+		c, ok := compareNumbers(x, y)
+		return ok && check(c)
+	})
+}
+
+func equal(x, y interface{}) bool {
+	if c, ok := compareNumbers(x, y); ok {
+		return c == 0
+	}
+	return reflect.DeepEqual(x, y)
+}
+
+// number is a numeric attribute kept in its widest exact representation, so
+// IDs above 2^53 (SteamID64) are not rounded through float64
+type number struct {
+	kind  numberKind
+	i     int64
+	u     uint64
+	float float64
+}
+
+type numberKind int
+
+const (
+	kindInt numberKind = iota
+	kindUint
+	kindFloat
+)
+
+// compareNumbers returns -1, 0 or 1 when both values are numbers. Integers are
+// compared exactly; float64 is used only when one of them is a float. ok is
+// false for non-numeric values and NaN.
+func compareNumbers(x, y interface{}) (int, bool) {
+	a, okA := toNumber(x)
+	b, okB := toNumber(y)
+	if !okA || !okB {
+		return 0, false
+	}
+
+	switch {
+	case a.kind == kindFloat || b.kind == kindFloat:
+		fa, fb := a.toFloat(), b.toFloat()
+		if math.IsNaN(fa) || math.IsNaN(fb) {
+			return 0, false
+		}
+		return cmp.Compare(fa, fb), true
+	case a.kind == kindInt && b.kind == kindInt:
+		return cmp.Compare(a.i, b.i), true
+	case a.kind == kindUint && b.kind == kindUint:
+		return cmp.Compare(a.u, b.u), true
+	case a.kind == kindInt:
+		if a.i < 0 {
+			return -1, true
+		}
+		return cmp.Compare(uint64(a.i), b.u), true
+	default:
+		if b.i < 0 {
+			return 1, true
+		}
+		return cmp.Compare(a.u, uint64(b.i)), true
+	}
+}
+
+func (n number) toFloat() float64 {
+	switch n.kind {
+	case kindInt:
+		return float64(n.i)
+	case kindUint:
+		return float64(n.u)
+	default:
+		return n.float
+	}
+}
+
+func toNumber(v interface{}) (number, bool) {
+	switch n := v.(type) {
+	case int:
+		return number{kind: kindInt, i: int64(n)}, true
+	case int8:
+		return number{kind: kindInt, i: int64(n)}, true
+	case int16:
+		return number{kind: kindInt, i: int64(n)}, true
+	case int32:
+		return number{kind: kindInt, i: int64(n)}, true
+	case int64:
+		return number{kind: kindInt, i: n}, true
+	case uint:
+		return number{kind: kindUint, u: uint64(n)}, true
+	case uint8:
+		return number{kind: kindUint, u: uint64(n)}, true
+	case uint16:
+		return number{kind: kindUint, u: uint64(n)}, true
+	case uint32:
+		return number{kind: kindUint, u: uint64(n)}, true
+	case uint64:
+		return number{kind: kindUint, u: n}, true
+	case float32:
+		return number{kind: kindFloat, float: float64(n)}, true
+	case float64:
+		return number{kind: kindFloat, float: n}, true
+	case json.Number:
+		if i, err := n.Int64(); err == nil {
+			return number{kind: kindInt, i: i}, true
+		}
+		if u, err := strconv.ParseUint(string(n), 10, 64); err == nil {
+			return number{kind: kindUint, u: u}, true
+		}
+		if f, err := n.Float64(); err == nil {
+			return number{kind: kindFloat, float: f}, true
+		}
+		return number{}, false
+	default:
+		return number{}, false
+	}
+}
+
+func joinNames(conditions []Condition, sep string) string {
+	names := make([]string, len(conditions))
+	for i, c := range conditions {
+		names[i] = c.Name
+	}
+	return "(" + strings.Join(names, sep) + ")"
+}
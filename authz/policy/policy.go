@@ -0,0 +1,133 @@
+// Package policy adds attribute-based conditions on top of the permission
+// bitmasks. A Rule requires a permission bit (checked with
+// permissions.CanPerformAction) and, optionally, predicates over subject,
+// resource and environment attributes, e.g. "team staff may kick only members
+// with a lower role" or "the lobby owner may transfer ownership only while the
+// lobby is not in-game".
+package policy
+
+import (
+	"github.com/AoC-Gamers/connect-libraries/authz/v2/permissions"
+)
+
+// Decision reasons
+const (
+	ReasonAllowed            = "allowed"
+	ReasonNoRule             = "no_rule"
+	ReasonMissingPermissions = "missing_permissions"
+	ReasonConditionFailed    = "condition_failed"
+	// ReasonNoPermission is reported by rules built without WithPermission
+	ReasonNoPermission = "no_permission"
+)
+
+// Attributes holds arbitrary attributes of the subject, resource or environment
+type Attributes map[string]interface{}
+
+// Request is the input of a policy evaluation
+type Request struct {
+	Action string
+
+	// AllowMask and DenyMask are the subject's masks in the scope of the resource
+	AllowMask uint64
+	DenyMask  uint64
+
+	Subject     Attributes
+	Resource    Attributes
+	Environment Attributes
+}
+
+// Rule allows Action when the subject holds Permission and every condition
+// holds. A rule without Permission never allows: conditions alone are not
+// enough, so a forgotten WithPermission fails closed.
+type Rule struct {
+	Action     string
+	Permission uint64
+	Conditions []Condition
+}
+
+// Allow starts a rule for an action
+func Allow(action string) Rule {
+	return Rule{Action: action}
+}
+
+// WithPermission returns a copy of the rule requiring permission (bits are OR-ed)
+func (r Rule) WithPermission(permission uint64) Rule {
+	r.Permission |= permission
+	return r
+}
+
+// When returns a copy of the rule with additional conditions
+func (r Rule) When(conditions ...Condition) Rule {
+	r.Conditions = append(append([]Condition(nil), r.Conditions...), conditions...)
+	return r
+}
+
+// Evaluate checks the rule against a request, ignoring req.Action
+func (r Rule) Evaluate(req Request) Result {
+	if r.Permission == 0 {
+		return Result{Action: r.Action, Reason: ReasonNoPermission}
+	}
+	if !permissions.CanPerformAction(req.AllowMask, req.DenyMask, r.Permission) {
+		return Result{Action: r.Action, Reason: ReasonMissingPermissions}
+	}
+	for _, condition := range r.Conditions {
+		if !condition.Check(req) {
+			return Result{Action: r.Action, Reason: ReasonConditionFailed, FailedCondition: condition.Name}
+		}
+	}
+	return Result{Allowed: true, Action: r.Action, Reason: ReasonAllowed}
+}
+
+// Result is the outcome of a policy evaluation
+type Result struct {
+	Allowed bool
+	Action  string
+	Reason  string
+	// FailedCondition is the name of the first condition that did not hold
+	FailedCondition string
+}
+
+// Engine evaluates requests against a set of rules. Rules for the same action
+// are alternatives: the first one that passes allows the request.
+type Engine struct {
+	rules map[string][]Rule
+}
+
+// NewEngine builds an engine from rules
+func NewEngine(rules ...Rule) *Engine {
+	e := &Engine{rules: make(map[string][]Rule)}
+	for _, rule := range rules {
+		e.rules[rule.Action] = append(e.rules[rule.Action], rule)
+	}
+	return e
+}
+
+// Evaluate returns the result of the first passing rule for req.Action or, when
+// none passes, the result of the last rule tried. Actions without rules are denied.
+func (e *Engine) Evaluate(req Request) Result {
+	rules := e.rules[req.Action]
+	if len(rules) == 0 {
+		return Result{Action: req.Action, Reason: ReasonNoRule}
+	}
+
+	var result Result
+	for _, rule := range rules {
+		result = rule.Evaluate(req)
+		if result.Allowed {
+			return result
+		}
+	}
+	return result
+}
+
+// Allowed reports whether the request is allowed
+func (e *Engine) Allowed(req Request) bool {
+	return e.Evaluate(req).Allowed
+}
+
+// CanPerformAction composes permissions.CanPerformAction with conditions. A
+// zero requiredPermission is denied, like a Rule without permission.
+func CanPerformAction(allowMask, denyMask, requiredPermission uint64, req Request, conditions ...Condition) bool {
+	req.AllowMask, req.DenyMask = allowMask, denyMask
+	return Allow(req.Action).WithPermission(requiredPermission).When(conditions...).Evaluate(req).Allowed
+}
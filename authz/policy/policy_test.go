@@ -0,0 +1,181 @@
+package policy
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/AoC-Gamers/connect-libraries/authz/v2/permissions"
+)
+
+const (
+	actionKick     = "team.member.kick"
+	actionTransfer = "lobby.transfer_ownership"
+)
+
+func testEngine() *Engine {
+	return NewEngine(
+		Allow(actionKick).
+			WithPermission(permissions.TeamMembershipDelete).
+			When(Gt(SubjectAttr("role_rank"), ResourceAttr("role_rank"))),
+		Allow(actionTransfer).
+			WithPermission(permissions.LobbyTransferOwnership).
+			When(Not(Eq(ResourceAttr("in_game"), Value(true)))),
+	)
+}
+
+func TestEngineTeamKickRequiresLowerRole(t *testing.T) {
+	engine := testEngine()
+	req := Request{
+		Action:    actionKick,
+		AllowMask: permissions.RoleTeamStaff | permissions.TeamMembershipDelete,
+		Subject:   Attributes{"role_rank": 2},
+		Resource:  Attributes{"role_rank": int64(1)},
+	}
+
+	if result := engine.Evaluate(req); !result.Allowed {
+		t.Fatalf("expected staff to kick a lower role, got %+v", result)
+	}
+
+	req.Resource = Attributes{"role_rank": 2}
+	result := engine.Evaluate(req)
+	if result.Allowed || result.Reason != ReasonConditionFailed {
+		t.Fatalf("expected condition failure for same role, got %+v", result)
+	}
+	if result.FailedCondition != "subject.role_rank > resource.role_rank" {
+		t.Fatalf("unexpected failed condition %q", result.FailedCondition)
+	}
+
+	req.Resource = Attributes{"role_rank": 1}
+	req.DenyMask = permissions.TeamMembershipDelete
+	if result := engine.Evaluate(req); result.Reason != ReasonMissingPermissions {
+		t.Fatalf("expected deny mask to win over conditions, got %+v", result)
+	}
+}
+
+func TestEngineLobbyTransferWhileNotInGame(t *testing.T) {
+	engine := testEngine()
+	req := Request{
+		Action:    actionTransfer,
+		AllowMask: permissions.RoleLobbyOwner,
+		Resource:  Attributes{"in_game": false},
+	}
+
+	if !engine.Allowed(req) {
+		t.Fatalf("expected transfer outside a game to be allowed")
+	}
+
+	req.Resource["in_game"] = true
+	if engine.Allowed(req) {
+		t.Fatalf("did not expect transfer while in-game")
+	}
+}
+
+func TestEngineAlternativesAndUnknownActions(t *testing.T) {
+	engine := NewEngine(
+		Allow("team.edit").WithPermission(permissions.TeamInfoEdit),
+		Allow("team.edit").
+			WithPermission(permissions.TeamRolesView).
+			When(Eq(SubjectAttr("steam_id"), ResourceAttr("owner_steam_id"))),
+	)
+
+	owner := Request{
+		Action:    "team.edit",
+		AllowMask: permissions.TeamRolesView,
+		Subject:   Attributes{"steam_id": "7656"},
+		Resource:  Attributes{"owner_steam_id": "7656"},
+	}
+	if !engine.Allowed(owner) {
+		t.Fatalf("expected second rule to allow the owner")
+	}
+
+	if result := engine.Evaluate(Request{Action: "team.delete"}); result.Reason != ReasonNoRule {
+		t.Fatalf("expected no_rule, got %+v", result)
+	}
+}
+
+func TestConditions(t *testing.T) {
+	req := Request{
+		Subject:     Attributes{"role": "TEAM_STAFF", "rank": uint8(2)},
+		Environment: Attributes{"maintenance": false},
+	}
+
+	cases := []struct {
+		name      string
+		condition Condition
+		want      bool
+	}{
+		{"in", In(SubjectAttr("role"), "TEAM_STAFF", "TEAM_OWNER"), true},
+		{"not in", In(SubjectAttr("role"), "TEAM_OWNER"), false},
+		{"missing attribute", Eq(SubjectAttr("missing"), Value(nil)), false},
+		{"is set", IsSet(EnvAttr("maintenance")), true},
+		{"lte mixed numbers", Lte(SubjectAttr("rank"), Value(2.0)), true},
+		{"non numeric comparison", Lt(SubjectAttr("role"), Value(3)), false},
+		{"all", All(Eq(EnvAttr("maintenance"), Value(false)), Gte(SubjectAttr("rank"), Value(1))), true},
+		{"any", Any(Ne(SubjectAttr("role"), Value("TEAM_STAFF")), Lt(SubjectAttr("rank"), Value(1))), false},
+	}
+
+	for _, tc := range cases {
+		if got := tc.condition.Check(req); got != tc.want {
+			t.Errorf("%s (%s): got %v, want %v", tc.name, tc.condition.Name, got, tc.want)
+		}
+	}
+}
+
+func TestRuleWithoutPermissionDenies(t *testing.T) {
+	engine := NewEngine(Allow("team.edit").When(Eq(SubjectAttr("steam_id"), ResourceAttr("owner_steam_id"))))
+	req := Request{
+		Action:    "team.edit",
+		AllowMask: permissions.RoleTeamOwner,
+		Subject:   Attributes{"steam_id": "7656"},
+		Resource:  Attributes{"owner_steam_id": "7656"},
+	}
+	if result := engine.Evaluate(req); result.Allowed || result.Reason != ReasonNoPermission {
+		t.Fatalf("expected a rule without permission to deny, got %+v", result)
+	}
+	if CanPerformAction(permissions.RoleTeamOwner, 0, 0, req) {
+		t.Fatal("expected a zero required permission to deny")
+	}
+}
+
+func TestEqComparesLargeIDsExactly(t *testing.T) {
+	owner := Eq(SubjectAttr("steam_id"), ResourceAttr("owner_id"))
+
+	// Adjacent SteamID64 values round to the same float64
+	cases := []struct {
+		name     string
+		subject  interface{}
+		resource interface{}
+		want     bool
+	}{
+		{"int64 adjacent", int64(76561198000000001), int64(76561198000000002), false},
+		{"uint64 adjacent", uint64(76561198000000001), uint64(76561198000000002), false},
+		{"mixed signedness adjacent", int64(76561198000000001), uint64(76561198000000002), false},
+		{"json number adjacent", json.Number("76561198000000001"), uint64(76561198000000002), false},
+		{"same id mixed types", int64(76561198000000001), uint64(76561198000000001), true},
+		{"negative vs large unsigned", int64(-1), uint64(math.MaxUint64), false},
+	}
+	for _, tc := range cases {
+		req := Request{Subject: Attributes{"steam_id": tc.subject}, Resource: Attributes{"owner_id": tc.resource}}
+		if got := owner.Check(req); got != tc.want {
+			t.Errorf("%s: got %v, want %v", tc.name, got, tc.want)
+		}
+	}
+
+	req := Request{Subject: Attributes{"id": uint64(76561198000000002)}, Resource: Attributes{"id": int64(76561198000000001)}}
+	if !Gt(SubjectAttr("id"), ResourceAttr("id")).Check(req) || Lte(SubjectAttr("id"), ResourceAttr("id")).Check(req) {
+		t.Fatal("expected ordering of adjacent large IDs to be exact")
+	}
+}
+
+func TestCanPerformAction(t *testing.T) {
+	req := Request{Resource: Attributes{"in_game": true}}
+	notInGame := Not(Eq(ResourceAttr("in_game"), Value(true)))
+
+	if CanPerformAction(permissions.RoleLobbyOwner, 0, permissions.LobbyTransferOwnership, req, notInGame) {
+		t.Fatalf("expected condition to block the action")
+	}
+	if !CanPerformAction(permissions.RoleLobbyOwner, 0, permissions.LobbyTransferOwnership, req) {
+		t.Fatalf("expected plain permission check without conditions")
+	}
+}
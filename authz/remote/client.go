@@ -0,0 +1,316 @@
+// Package remote is a client for the Connect-Auth permission check endpoint
+// (POST /auth/internal/permissions/check). Results are cached per
+// (steamid, scope, scopeID, permission) with a TTL and can be invalidated by
+// the cache invalidation events published on the `cache.>` NATS stream.
+//
+// The package does not depend on NATS: the service must subscribe to
+// InvalidationSubject and pass each message to HandleInvalidation. Without
+// that subscription a revoked permission stays cached for up to the TTL.
+package remote
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// CheckPath is the Connect-Auth endpoint used for permission checks
+	CheckPath = "/auth/internal/permissions/check"
+
+	// DefaultHeaderName is the header carrying the internal API key
+	DefaultHeaderName = "X-Internal-API-Key"
+
+	// DefaultTTL is used when Config.TTL is zero
+	DefaultTTL = 30 * time.Second
+
+	// DefaultMaxEntries is used when Config.MaxEntries is zero
+	DefaultMaxEntries = 10000
+
+	// DefaultBatchConcurrency is used when Config.BatchConcurrency is zero
+	DefaultBatchConcurrency = 8
+
+	// maxResponseBytes bounds the decoded check response
+	maxResponseBytes = 1 << 16
+)
+
+// ErrMissingBaseURL is returned by New when Config.BaseURL is empty
+var ErrMissingBaseURL = errors.New("remote: base URL is required")
+
+// Check identifies a single permission check. It is also the cache key.
+type Check struct {
+	SteamID    string `json:"steamId"`
+	Scope      string `json:"scope"`
+	ScopeID    string `json:"scopeId,omitempty"`
+	Permission string `json:"permission"`
+}
+
+// Config configures the client
+type Config struct {
+	// BaseURL of Connect-Auth (e.g. "http://connect-auth:8080")
+	BaseURL string
+
+	// APIKey sent in HeaderName on every request
+	APIKey     string
+	HeaderName string
+
+	// TTL of cached results; negative disables caching
+	TTL time.Duration
+
+	// MaxEntries bounds the cache; expired entries are pruned first and the
+	// entry closest to expiring is evicted when it is still full
+	MaxEntries int
+
+	// BatchConcurrency limits concurrent requests issued by CheckBatch
+	BatchConcurrency int
+
+	HTTPClient *http.Client
+}
+
+type cacheEntry struct {
+	allowed bool
+	expires time.Time
+}
+
+// Client checks permissions against Connect-Auth with a local cache
+type Client struct {
+	baseURL          string
+	apiKey           string
+	headerName       string
+	ttl              time.Duration
+	maxEntries       int
+	batchConcurrency int
+	httpClient       *http.Client
+	now              func() time.Time
+
+	mu    sync.Mutex
+	cache map[Check]cacheEntry
+	// generation is bumped by every invalidation; a fetch started in an older
+	// generation is not cached, so it cannot restore a revoked result
+	generation uint64
+}
+
+// New creates a client from cfg, applying defaults for unset fields
+func New(cfg Config) (*Client, error) {
+	if cfg.BaseURL == "" {
+		return nil, ErrMissingBaseURL
+	}
+
+	c := &Client{
+		baseURL:          strings.TrimRight(cfg.BaseURL, "/"),
+		apiKey:           cfg.APIKey,
+		headerName:       cfg.HeaderName,
+		ttl:              cfg.TTL,
+		maxEntries:       cfg.MaxEntries,
+		batchConcurrency: cfg.BatchConcurrency,
+		httpClient:       cfg.HTTPClient,
+		now:              time.Now,
+		cache:            make(map[Check]cacheEntry),
+	}
+	if c.headerName == "" {
+		c.headerName = DefaultHeaderName
+	}
+	if c.ttl == 0 {
+		c.ttl = DefaultTTL
+	}
+	if c.maxEntries <= 0 {
+		c.maxEntries = DefaultMaxEntries
+	}
+	if c.batchConcurrency <= 0 {
+		c.batchConcurrency = DefaultBatchConcurrency
+	}
+	if c.httpClient == nil {
+		c.httpClient = &http.Client{Timeout: 5 * time.Second}
+	}
+	return c, nil
+}
+
+// HasPermission returns the cached result for check or asks Connect-Auth
+func (c *Client) HasPermission(ctx context.Context, check Check) (bool, error) {
+	if allowed, ok := c.lookup(check); ok {
+		return allowed, nil
+	}
+
+	generation := c.currentGeneration()
+	allowed, err := c.fetch(ctx, check)
+	if err != nil {
+		return false, err
+	}
+	c.store(check, allowed, generation)
+	return allowed, nil
+}
+
+// CheckBatch resolves several checks, returning results in the same order.
+// Cached checks are answered locally and the rest are requested concurrently;
+// the first error aborts the batch.
+func (c *Client) CheckBatch(ctx context.Context, checks []Check) ([]bool, error) {
+	results := make([]bool, len(checks))
+	pending := make(map[Check][]int)
+	for i, check := range checks {
+		if allowed, ok := c.lookup(check); ok {
+			results[i] = allowed
+			continue
+		}
+		pending[check] = append(pending[check], i)
+	}
+	if len(pending) == 0 {
+		return results, nil
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+		slots    = make(chan struct{}, c.batchConcurrency)
+	)
+	for check, indexes := range pending {
+		wg.Add(1)
+		slots <- struct{}{}
+		go func(check Check, indexes []int) {
+			defer wg.Done()
+			defer func() { <-slots }()
+
+			allowed, err := c.HasPermission(ctx, check)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				if firstErr == nil {
+					firstErr = err
+					cancel()
+				}
+				return
+			}
+			for _, i := range indexes {
+				results[i] = allowed
+			}
+		}(check, indexes)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	return results, nil
+}
+
+// Purge removes every cached result
+func (c *Client) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.cache = make(map[Check]cacheEntry)
+	c.generation++
+}
+
+func (c *Client) currentGeneration() uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.generation
+}
+
+func (c *Client) lookup(check Check) (bool, bool) {
+	if c.ttl < 0 {
+		return false, false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.cache[check]
+	if !ok {
+		return false, false
+	}
+	if !c.now().Before(entry.expires) {
+		delete(c.cache, check)
+		return false, false
+	}
+	return entry.allowed, true
+}
+
+func (c *Client) store(check Check, allowed bool, generation uint64) {
+	if c.ttl < 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.generation != generation {
+		return
+	}
+	now := c.now()
+	if len(c.cache) >= c.maxEntries {
+		for key, entry := range c.cache {
+			if !now.Before(entry.expires) {
+				delete(c.cache, key)
+			}
+		}
+		if len(c.cache) >= c.maxEntries {
+			c.evictOldestLocked()
+		}
+	}
+	c.cache[check] = cacheEntry{allowed: allowed, expires: now.Add(c.ttl)}
+}
+
+// evictOldestLocked drops the entry closest to expiring, which with a single
+// TTL is the oldest one
+func (c *Client) evictOldestLocked() {
+	var (
+		oldest  Check
+		expires time.Time
+		found   bool
+	)
+	for key, entry := range c.cache {
+		if !found || entry.expires.Before(expires) {
+			oldest, expires, found = key, entry.expires, true
+		}
+	}
+	if found {
+		delete(c.cache, oldest)
+	}
+}
+
+type checkResponse struct {
+	HasPermission bool `json:"hasPermission"`
+}
+
+func (c *Client) fetch(ctx context.Context, check Check) (bool, error) {
+	body, err := json.Marshal(check)
+	if err != nil {
+		return false, fmt.Errorf("remote: encode check: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+CheckPath, bytes.NewReader(body))
+	if err != nil {
+		return false, fmt.Errorf("remote: build request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.apiKey != "" {
+		req.Header.Set(c.headerName, c.apiKey)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return false, fmt.Errorf("remote: permission check: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return false, fmt.Errorf("remote: permission check returned status %d", resp.StatusCode)
+	}
+
+	var decoded checkResponse
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxResponseBytes)).Decode(&decoded); err != nil {
+		return false, fmt.Errorf("remote: decode response: %w", err)
+	}
+	return decoded.HasPermission, nil
+}
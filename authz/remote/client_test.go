@@ -0,0 +1,239 @@
+package remote
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+const testAPIKey = "internal-key"
+
+// newTestServer grants permissions listed in allowed and counts requests
+func newTestServer(t *testing.T, allowed map[string]bool, calls *int64) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt64(calls, 1)
+		if r.Method != http.MethodPost || r.URL.Path != CheckPath {
+			http.NotFound(w, r)
+			return
+		}
+		if r.Header.Get(DefaultHeaderName) != testAPIKey {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		var check Check
+		if err := json.NewDecoder(r.Body).Decode(&check); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]bool{"hasPermission": allowed[check.SteamID+"/"+check.Permission]})
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func newTestClient(t *testing.T, baseURL string, ttl time.Duration) *Client {
+	t.Helper()
+	client, err := New(Config{BaseURL: baseURL, APIKey: testAPIKey, TTL: ttl})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return client
+}
+
+func TestHasPermissionCachesResults(t *testing.T) {
+	var calls int64
+	server := newTestServer(t, map[string]bool{"1/TEAM__SUSPEND": true}, &calls)
+	client := newTestClient(t, server.URL, time.Minute)
+
+	now := time.Unix(1000, 0)
+	client.now = func() time.Time { return now }
+
+	check := Check{SteamID: "1", Scope: "TEAM", ScopeID: "10", Permission: "TEAM__SUSPEND"}
+	for i := 0; i < 3; i++ {
+		allowed, err := client.HasPermission(context.Background(), check)
+		if err != nil || !allowed {
+			t.Fatalf("expected permission, got %v %v", allowed, err)
+		}
+	}
+	if calls != 1 {
+		t.Fatalf("expected a single remote call, got %d", calls)
+	}
+
+	now = now.Add(time.Minute)
+	if _, err := client.HasPermission(context.Background(), check); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls != 2 {
+		t.Fatalf("expected expired entry to be refreshed, got %d calls", calls)
+	}
+}
+
+func TestHasPermissionErrors(t *testing.T) {
+	if _, err := New(Config{}); err != ErrMissingBaseURL {
+		t.Fatalf("expected ErrMissingBaseURL, got %v", err)
+	}
+
+	var calls int64
+	server := newTestServer(t, nil, &calls)
+	client, _ := New(Config{BaseURL: server.URL, APIKey: "wrong"})
+	if _, err := client.HasPermission(context.Background(), Check{SteamID: "1"}); err == nil {
+		t.Fatalf("expected error on unauthorized response")
+	}
+}
+
+func TestCheckBatch(t *testing.T) {
+	var calls int64
+	server := newTestServer(t, map[string]bool{"1/A": true, "2/B": true}, &calls)
+	client := newTestClient(t, server.URL, time.Minute)
+
+	checks := []Check{
+		{SteamID: "1", Scope: "WEB", Permission: "A"},
+		{SteamID: "1", Scope: "WEB", Permission: "B"},
+		{SteamID: "2", Scope: "WEB", Permission: "B"},
+		{SteamID: "1", Scope: "WEB", Permission: "A"},
+	}
+	results, err := client.CheckBatch(context.Background(), checks)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []bool{true, false, true, true}
+	for i := range want {
+		if results[i] != want[i] {
+			t.Fatalf("result %d: got %v, want %v", i, results[i], want[i])
+		}
+	}
+	if calls != 3 {
+		t.Fatalf("expected duplicated checks to be requested once, got %d calls", calls)
+	}
+
+	if _, err := client.CheckBatch(context.Background(), checks); err != nil || calls != 3 {
+		t.Fatalf("expected cached batch, got err=%v calls=%d", err, calls)
+	}
+}
+
+func TestHandleInvalidation(t *testing.T) {
+	var calls int64
+	server := newTestServer(t, map[string]bool{"1/A": true}, &calls)
+	client := newTestClient(t, server.URL, time.Minute)
+	ctx := context.Background()
+
+	user1 := Check{SteamID: "1", Scope: "TEAM", ScopeID: "10", Permission: "A"}
+	user2 := Check{SteamID: "2", Scope: "TEAM", ScopeID: "10", Permission: "A"}
+	_, _ = client.CheckBatch(ctx, []Check{user1, user2})
+
+	event := []byte(`{"type":"permissions.changed","version":1,"data":{"steamId":"1","scope":"TEAM"},"meta":{"ts":1,"by":"connect-auth"}}`)
+	if err := client.HandleInvalidation(event); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := client.lookup(user1); ok {
+		t.Fatalf("expected user 1 to be invalidated")
+	}
+	if _, ok := client.lookup(user2); !ok {
+		t.Fatalf("did not expect user 2 to be invalidated")
+	}
+
+	if err := client.HandleInvalidation([]byte(`{"scopeId":"10"}`)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := client.lookup(user2); ok {
+		t.Fatalf("expected bare invalidation by scope ID to apply")
+	}
+
+	if err := client.HandleInvalidation([]byte(`not json`)); err == nil {
+		t.Fatalf("expected decode error")
+	}
+}
+
+func TestCachingDisabled(t *testing.T) {
+	var calls int64
+	server := newTestServer(t, nil, &calls)
+	client := newTestClient(t, server.URL, -1)
+
+	for i := 0; i < 2; i++ {
+		_, _ = client.HasPermission(context.Background(), Check{SteamID: "1"})
+	}
+	if calls != 2 {
+		t.Fatalf("expected every check to reach the server, got %d", calls)
+	}
+}
+
+func TestInvalidateDuringFetchIsNotCached(t *testing.T) {
+	var calls int64
+	started := make(chan struct{})
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt64(&calls, 1) == 1 {
+			close(started)
+			<-release
+		}
+		_ = json.NewEncoder(w).Encode(map[string]bool{"hasPermission": true})
+	}))
+	t.Cleanup(server.Close)
+	client := newTestClient(t, server.URL, time.Minute)
+
+	check := Check{SteamID: "1", Scope: "TEAM", ScopeID: "10", Permission: "TEAM__SUSPEND"}
+	done := make(chan error, 1)
+	go func() {
+		_, err := client.HasPermission(context.Background(), check)
+		done <- err
+	}()
+
+	<-started
+	client.Invalidate(Invalidation{SteamID: "1"})
+	close(release)
+	if err := <-done; err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, ok := client.lookup(check); ok {
+		t.Fatal("expected result fetched before the invalidation not to be cached")
+	}
+	if _, err := client.HasPermission(context.Background(), check); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls != 2 {
+		t.Fatalf("expected a fresh remote call after invalidation, got %d calls", calls)
+	}
+}
+
+func TestFullCacheEvictsOldestEntry(t *testing.T) {
+	client, err := New(Config{BaseURL: "http://connect-auth", TTL: time.Minute, MaxEntries: 2})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	now := time.Now()
+	client.now = func() time.Time { return now }
+
+	checks := []Check{{SteamID: "1"}, {SteamID: "2"}, {SteamID: "3"}}
+	for _, check := range checks {
+		client.store(check, true, 0)
+		now = now.Add(time.Second)
+	}
+
+	if _, ok := client.lookup(checks[0]); ok {
+		t.Fatal("expected the oldest entry to be evicted")
+	}
+	for _, check := range checks[1:] {
+		if _, ok := client.lookup(check); !ok {
+			t.Fatalf("expected %+v to stay cached", check)
+		}
+	}
+}
+
+func TestOversizedResponseIsRejected(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(strings.Repeat(" ", maxResponseBytes) + `{"hasPermission":true}`))
+	}))
+	t.Cleanup(server.Close)
+	client := newTestClient(t, server.URL, time.Minute)
+
+	if _, err := client.HasPermission(context.Background(), Check{SteamID: "1"}); err == nil {
+		t.Fatal("expected an error for a response over the size limit")
+	}
+}
@@ -0,0 +1,75 @@
+package remote
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// InvalidationSubject is the NATS subject the client expects invalidation
+// events on, inside the `cache.>` stream of connectnats.DefaultStreamConfigs
+const InvalidationSubject = "cache.permissions"
+
+// Invalidation selects cached results to drop. Empty fields match everything,
+// so an empty Invalidation purges the whole cache and one with only SteamID
+// drops every result of that user.
+type Invalidation struct {
+	SteamID    string `json:"steamId,omitempty"`
+	Scope      string `json:"scope,omitempty"`
+	ScopeID    string `json:"scopeId,omitempty"`
+	Permission string `json:"permission,omitempty"`
+}
+
+func (inv Invalidation) matches(check Check) bool {
+	return (inv.SteamID == "" || inv.SteamID == check.SteamID) &&
+		(inv.Scope == "" || inv.Scope == check.Scope) &&
+		(inv.ScopeID == "" || inv.ScopeID == check.ScopeID) &&
+		(inv.Permission == "" || inv.Permission == check.Permission)
+}
+
+// Invalidate drops the cached results matching inv. Results fetched while it
+// runs are returned to their callers but not cached.
+func (c *Client) Invalidate(inv Invalidation) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.generation++
+	for check := range c.cache {
+		if inv.matches(check) {
+			delete(c.cache, check)
+		}
+	}
+}
+
+// HandleInvalidation decodes an invalidation message and applies it. The
+// payload may be a bare Invalidation or a connectnats.Event envelope whose
+// data holds one; an empty payload purges the cache. The service must call it
+// from its own NATS subscription (the package does not depend on NATS):
+//
+//	nc.Subscribe(remote.InvalidationSubject, func(m *nats.Msg) {
+//		if err := client.HandleInvalidation(m.Data); err != nil {
+//			client.Purge() // an unreadable event must not leave stale grants
+//		}
+//	})
+func (c *Client) HandleInvalidation(data []byte) error {
+	if len(data) == 0 {
+		c.Purge()
+		return nil
+	}
+
+	var envelope struct {
+		Type string          `json:"type"`
+		Data json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(data, &envelope); err != nil {
+		return fmt.Errorf("remote: decode invalidation: %w", err)
+	}
+	if envelope.Type != "" && len(envelope.Data) > 0 {
+		data = envelope.Data
+	}
+
+	var inv Invalidation
+	if err := json.Unmarshal(data, &inv); err != nil {
+		return fmt.Errorf("remote: decode invalidation: %w", err)
+	}
+	c.Invalidate(inv)
+	return nil
+}
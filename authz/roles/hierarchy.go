@@ -0,0 +1,94 @@
+package roles
+
+import "slices"
+
+// The role hierarchy is derived from group containment: a role ranks at least
+// as high as another role of the same scope when its groups are a superset of
+// the other's groups (TEAM_OWNER ⊇ TEAM_STAFF ⊇ TEAM_USER). Roles whose groups
+// are not contained in each other are incomparable, so the order is partial.
+
+// Compare orders two roles of a scope. It returns -1, 0 or 1 when a ranks
+// below, equal to or above b, and ok=false when either role is unknown or the
+// roles are incomparable.
+func Compare(scope, a, b string) (result int, ok bool) {
+	roleA, okA := GetRole(scope, a)
+	roleB, okB := GetRole(scope, b)
+	if !okA || !okB {
+		return 0, false
+	}
+
+	aContainsB := containsGroups(roleA.Groups, roleB.Groups)
+	bContainsA := containsGroups(roleB.Groups, roleA.Groups)
+	switch {
+	case aContainsB && bContainsA:
+		return 0, true
+	case aContainsB:
+		return 1, true
+	case bContainsA:
+		return -1, true
+	default:
+		return 0, false
+	}
+}
+
+// AtLeast reports whether role ranks equal to or above minimum
+func AtLeast(scope, role, minimum string) bool {
+	result, ok := Compare(scope, role, minimum)
+	return ok && result >= 0
+}
+
+// Outranks reports whether a ranks strictly above b
+func Outranks(scope, a, b string) bool {
+	result, ok := Compare(scope, a, b)
+	return ok && result > 0
+}
+
+// CanManage reports whether an actor with actorRole may moderate a member with
+// targetRole (kick, change role, ...). Managing requires outranking the target,
+// so peers cannot manage each other.
+func CanManage(scope, actorRole, targetRole string) bool {
+	return Outranks(scope, actorRole, targetRole)
+}
+
+// HighestRole returns the highest of the given roles in a scope. Unknown roles
+// are ignored; between incomparable roles the first one listed wins.
+func HighestRole(scope string, roleNames ...string) (Role, bool) {
+	var (
+		highest Role
+		found   bool
+	)
+	for _, name := range roleNames {
+		role, ok := GetRole(scope, name)
+		if !ok {
+			continue
+		}
+		if !found || Outranks(scope, role.Name, highest.Name) {
+			highest, found = role, true
+		}
+	}
+	return highest, found
+}
+
+// Rank returns the number of roles of the scope that role outranks, a stable
+// numeric level for sorting or storing (TEAM_USER=0, TEAM_STAFF=1, TEAM_OWNER=2)
+func Rank(scope, role string) (int, bool) {
+	if _, ok := GetRole(scope, role); !ok {
+		return 0, false
+	}
+	rank := 0
+	for _, other := range GetAllRoles(scope) {
+		if Outranks(scope, role, other.Name) {
+			rank++
+		}
+	}
+	return rank, true
+}
+
+func containsGroups(groups, subset []string) bool {
+	for _, wanted := range subset {
+		if !slices.Contains(groups, wanted) {
+			return false
+		}
+	}
+	return true
+}
@@ -49,3 +49,47 @@ func TestGetAllRoles(t *testing.T) {
 		t.Fatalf("expected empty roles for unknown scope")
 	}
 }
+
+func TestRoleHierarchy(t *testing.T) {
+	if result, ok := Compare("TEAM", TEAM_OWNER, TEAM_USER); !ok || result != 1 {
+		t.Fatalf("expected owner above user, got %d %v", result, ok)
+	}
+	if result, ok := Compare("TEAM", TEAM_STAFF, TEAM_STAFF); !ok || result != 0 {
+		t.Fatalf("expected staff equal to itself, got %d %v", result, ok)
+	}
+	if _, ok := Compare("TEAM", TEAM_OWNER, "invalid"); ok {
+		t.Fatalf("did not expect unknown role to be comparable")
+	}
+	if _, ok := Compare("TEAM", TEAM_OWNER, COMMUNITY_USER); ok {
+		t.Fatalf("did not expect roles of another scope to be comparable")
+	}
+
+	if !CanManage("COMMUNITY", COMMUNITY_STAFF, COMMUNITY_USER) {
+		t.Fatalf("expected staff to manage users")
+	}
+	if CanManage("COMMUNITY", COMMUNITY_STAFF, COMMUNITY_STAFF) {
+		t.Fatalf("did not expect peers to manage each other")
+	}
+	if Outranks("WEB", WEB_STAFF, WEB_OWNER) {
+		t.Fatalf("did not expect staff to outrank owner")
+	}
+	if !AtLeast("LOBBY", LOBBY_OWNER, LOBBY_STAFF) || AtLeast("LOBBY", LOBBY_USER, LOBBY_STAFF) {
+		t.Fatalf("unexpected AtLeast results")
+	}
+}
+
+func TestHighestRoleAndRank(t *testing.T) {
+	role, ok := HighestRole("TEAM", TEAM_USER, "invalid", TEAM_OWNER, TEAM_STAFF)
+	if !ok || role.Name != TEAM_OWNER {
+		t.Fatalf("expected team owner, got %+v %v", role, ok)
+	}
+	if _, ok := HighestRole("TEAM", "invalid"); ok {
+		t.Fatalf("did not expect a highest role without known roles")
+	}
+
+	for name, want := range map[string]int{TEAM_USER: 0, TEAM_STAFF: 1, TEAM_OWNER: 2} {
+		if rank, ok := Rank("TEAM", name); !ok || rank != want {
+			t.Fatalf("unexpected rank for %s: %d %v", name, rank, ok)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package errors
+
+import (
+	"net/http"
+	"strconv"
+)
+
+// AuthResponder implementa la interfaz ErrorResponder del módulo responder (y
+// por lo tanto las de apikey y middleware/chi) renderizando con este paquete:
+// respeta Responder.Middleware (problem+json) y los mensajes localizados.
+//
+//	r.Use(apikey.RequireAPIKeyWithResponder(validator, errors.AuthResponder{}))
+//	r.Use(chi.RequireAuthWithResponder(cfg, errors.AuthResponder{}))
+type AuthResponder struct{}
+
+// Unauthorized responde con 401 genérico
+func (AuthResponder) Unauthorized(w http.ResponseWriter, detail string) {
+	respond(w, Unauthorized(detail))
+}
+
+// TokenExpired responde cuando el JWT ha expirado
+func (AuthResponder) TokenExpired(w http.ResponseWriter) {
+	respond(w, TokenExpired())
+}
+
+// InvalidTokenClaims responde TOKEN_INVALID cuando el token fue rechazado por
+// sus claims (issuer, audience, antigüedad); reason queda en meta.reason
+func (AuthResponder) InvalidTokenClaims(w http.ResponseWriter, reason, detail string) {
+	respond(w, TokenInvalid(detail).WithMeta("reason", reason))
+}
+
+// PolicyVersionMismatch responde cuando hay mismatch de policy version
+func (AuthResponder) PolicyVersionMismatch(w http.ResponseWriter, tokenVersion, currentVersion int) {
+	respond(w, PolicyVersionMismatch(tokenVersion, currentVersion))
+}
+
+// InsufficientPermissions responde con permisos insuficientes
+func (AuthResponder) InsufficientPermissions(w http.ResponseWriter, action string) {
+	respond(w, InsufficientPermissions(action))
+}
+
+// InsufficientPermissionsWithMeta responde con permisos insuficientes incluyendo
+// meta["decision"] con la explicación sanitizada
+func (AuthResponder) InsufficientPermissionsWithMeta(w http.ResponseWriter, action string, meta map[string]interface{}) {
+	e := InsufficientPermissions(action)
+	if meta != nil {
+		e = e.WithMeta("decision", meta)
+	}
+	respond(w, e)
+}
+
+// RateLimited responde con rate limit excedido y el header Retry-After
+func (AuthResponder) RateLimited(w http.ResponseWriter, limit int, window string, retryAfter int) {
+	if retryAfter > 0 {
+		w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+	}
+	respond(w, RateLimitExceeded(limit, window, retryAfter))
+}
+
+// ServiceUnavailable responde 503 cuando una dependencia de la autenticación
+// (ej: el backend de revocación) no está disponible
+func (AuthResponder) ServiceUnavailable(w http.ResponseWriter, service string) {
+	respond(w, ServiceUnavailable(service))
+}
@@ -0,0 +1,86 @@
+package errors_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	errors "github.com/AoC-Gamers/connect-libraries/errors"
+)
+
+func TestAuthResponderMatchesHelpers(t *testing.T) {
+	cases := []struct {
+		name   string
+		via    func(w http.ResponseWriter)
+		helper func(w http.ResponseWriter)
+	}{
+		{"unauthorized",
+			func(w http.ResponseWriter) { errors.AuthResponder{}.Unauthorized(w, "missing token") },
+			func(w http.ResponseWriter) { errors.RespondUnauthorized(w, "missing token") }},
+		{"token expired",
+			func(w http.ResponseWriter) { errors.AuthResponder{}.TokenExpired(w) },
+			errors.RespondTokenExpired},
+		{"policy mismatch",
+			func(w http.ResponseWriter) { errors.AuthResponder{}.PolicyVersionMismatch(w, 1, 2) },
+			func(w http.ResponseWriter) { errors.RespondPolicyVersionMismatch(w, 1, 2) }},
+		{"insufficient permissions",
+			func(w http.ResponseWriter) { errors.AuthResponder{}.InsufficientPermissions(w, "edit teams") },
+			func(w http.ResponseWriter) { errors.RespondInsufficientPermissions(w, "edit teams") }},
+		{"rate limited",
+			func(w http.ResponseWriter) { errors.AuthResponder{}.RateLimited(w, 100, "minute", 0) },
+			func(w http.ResponseWriter) { errors.RespondRateLimitExceeded(w, 100, "minute", 0) }},
+		{"service unavailable",
+			func(w http.ResponseWriter) { errors.AuthResponder{}.ServiceUnavailable(w, "revocation") },
+			func(w http.ResponseWriter) { errors.RespondServiceUnavailable(w, "revocation") }},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			viaResponder, viaHelper := httptest.NewRecorder(), httptest.NewRecorder()
+			tc.via(viaResponder)
+			tc.helper(viaHelper)
+
+			if viaResponder.Code != viaHelper.Code || !bytes.Equal(viaResponder.Body.Bytes(), viaHelper.Body.Bytes()) {
+				t.Fatalf("responder %d %s != helper %d %s", viaResponder.Code, viaResponder.Body, viaHelper.Code, viaHelper.Body)
+			}
+		})
+	}
+}
+
+func TestAuthResponderRateLimitedSetsRetryAfter(t *testing.T) {
+	rr := httptest.NewRecorder()
+	errors.AuthResponder{}.RateLimited(rr, 10, "second", 5)
+	if rr.Code != http.StatusTooManyRequests || rr.Header().Get("Retry-After") != "5" {
+		t.Fatalf("unexpected rate limit response %d %q", rr.Code, rr.Header().Get("Retry-After"))
+	}
+}
+
+func TestAuthResponderHonorsProblemMode(t *testing.T) {
+	handler := errors.NewResponder(errors.ModeProblem).Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errors.AuthResponder{}.TokenExpired(w)
+	}))
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/", nil))
+	if rr.Header().Get(errors.HeaderContentType) != errors.MimeProblemJSON {
+		t.Fatalf("expected problem+json, got %q", rr.Header().Get(errors.HeaderContentType))
+	}
+}
+
+func TestAuthResponderInvalidTokenClaims(t *testing.T) {
+	rr := httptest.NewRecorder()
+	errors.AuthResponder{}.InvalidTokenClaims(rr, "invalid_audience", "token is not intended for this service")
+
+	var resp errors.ErrorResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+		t.Fatalf(errParseResponse, err)
+	}
+	if rr.Code != http.StatusUnauthorized || resp.Code != errors.CodeTokenInvalid {
+		t.Fatalf("unexpected response %d %+v", rr.Code, resp)
+	}
+	if resp.Meta["reason"] != "invalid_audience" || resp.Meta["should_reauth"] != true {
+		t.Fatalf("unexpected meta %+v", resp.Meta)
+	}
+}
@@ -0,0 +1,278 @@
+package errors
+
+import (
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// MaxErrorBodyBytes limita cuánto del body de una respuesta de error lee FromResponse
+const MaxErrorBodyBytes = 1 << 20
+
+// ResponseError es la respuesta de error de otro servicio decodificada por
+// FromResponse. Code siempre es un ErrorCode público (los códigos internos se
+// mapean, ej: INTERNAL_NOT_FOUND → NOT_FOUND) para que HasCode, CodeOf e
+// IsNotFound funcionen igual a ambos lados de la llamada.
+type ResponseError struct {
+	// Status es el código HTTP recibido
+	Status int
+
+	// Code es el código público (decodificado o derivado del status)
+	Code ErrorCode
+
+	// InternalCode es el código original de un InternalErrorResponse
+	InternalCode InternalErrorCode
+
+	// Message es el campo "error", "message" o "title" según el formato
+	Message string
+
+	// Detail es el detalle de ErrorResponse o ProblemDetails
+	Detail string
+
+	// Service es el servicio que respondió (solo InternalErrorResponse)
+	Service string
+
+	// Meta es la metadata de ErrorResponse o las extensiones de ProblemDetails
+	Meta map[string]interface{}
+
+	// Details son los detalles de InternalErrorResponse
+	Details interface{}
+
+	// ErrorID identifica la respuesta en el log del servicio que respondió
+	ErrorID string
+}
+
+// Error implementa la interfaz error
+func (e *ResponseError) Error() string {
+	text := string(e.Code)
+	if e.InternalCode != "" {
+		text = string(e.InternalCode)
+	}
+	if e.Service != "" {
+		text = e.Service + ": " + text
+	}
+	if e.Message != "" {
+		text += ": " + e.Message
+	}
+	if e.Detail != "" {
+		text += ": " + e.Detail
+	}
+	if e.ErrorID != "" {
+		return fmt.Sprintf("%s (status %d, error_id %s)", text, e.Status, e.ErrorID)
+	}
+	return fmt.Sprintf("%s (status %d)", text, e.Status)
+}
+
+// Is compara por código con *Error y *ResponseError, igual que Error.Is
+func (e *ResponseError) Is(target error) bool {
+	switch t := target.(type) {
+	case *Error:
+		return t.Code == e.Code
+	case *ResponseError:
+		if t.InternalCode != "" {
+			return t.InternalCode == e.InternalCode
+		}
+		return t.Code == e.Code
+	default:
+		return false
+	}
+}
+
+// responseBody une los campos de ErrorResponse, InternalErrorResponse y ProblemDetails
+type responseBody struct {
+	Error   string                 `json:"error"`
+	Code    string                 `json:"code"`
+	Status  int                    `json:"status"`
+	Detail  string                 `json:"detail"`
+	Meta    map[string]interface{} `json:"meta"`
+	Message string                 `json:"message"`
+	Service string                 `json:"service"`
+	Details interface{}            `json:"details"`
+	Title   string                 `json:"title"`
+	ErrorID string                 `json:"error_id"`
+}
+
+// FromResponse decodifica una respuesta de error (ErrorResponse,
+// InternalErrorResponse o ProblemDetails) en un *ResponseError. Retorna nil si
+// el status es menor a 400. Un body vacío o que no es JSON produce un error con
+// el código derivado del status. No cierra el body.
+func FromResponse(resp *http.Response) error {
+	if resp == nil || resp.StatusCode < http.StatusBadRequest {
+		return nil
+	}
+
+	result := &ResponseError{
+		Status:  resp.StatusCode,
+		Code:    codeForStatus(resp.StatusCode),
+		Message: strings.ToLower(http.StatusText(resp.StatusCode)),
+	}
+	if resp.Body == nil {
+		return result
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, MaxErrorBodyBytes))
+	if err != nil || len(data) == 0 {
+		return result
+	}
+
+	var body responseBody
+	if json.Unmarshal(data, &body) != nil {
+		return result
+	}
+
+	if strings.HasPrefix(body.Code, "INTERNAL_") {
+		result.InternalCode = InternalErrorCode(body.Code)
+		result.Code = publicCode(result.InternalCode, resp.StatusCode)
+	} else if body.Code != "" {
+		result.Code = ErrorCode(body.Code)
+	}
+
+	switch {
+	case body.Error != "":
+		result.Message = body.Error
+	case body.Message != "":
+		result.Message = body.Message
+	case body.Title != "":
+		result.Message = body.Title
+	}
+	result.Detail = body.Detail
+	result.Service = body.Service
+	result.Details = body.Details
+	result.ErrorID = body.ErrorID
+	if result.ErrorID == "" {
+		result.ErrorID = resp.Header.Get(HeaderErrorID)
+	}
+	result.Meta = body.Meta
+
+	if strings.HasPrefix(resp.Header.Get(HeaderContentType), MimeProblemJSON) {
+		var problem ProblemDetails
+		if json.Unmarshal(data, &problem) == nil {
+			result.Meta = problem.Extensions
+		}
+	}
+
+	return result
+}
+
+// IsNotFound indica si err es NOT_FOUND / MEMBERSHIP_NOT_FOUND (local o remoto)
+func IsNotFound(err error) bool {
+	return HasCode(err, CodeNotFound) || HasCode(err, CodeMembershipNotFound)
+}
+
+// IsConflict indica si err es ALREADY_EXISTS, CONFLICT o RESOURCE_LOCKED
+func IsConflict(err error) bool {
+	return HasCode(err, CodeAlreadyExists) || HasCode(err, CodeConflict) || HasCode(err, CodeResourceLocked)
+}
+
+// IsUnauthorized indica si err es un error 401
+func IsUnauthorized(err error) bool {
+	return statusOf(err) == http.StatusUnauthorized
+}
+
+// IsForbidden indica si err es un error 403
+func IsForbidden(err error) bool {
+	return statusOf(err) == http.StatusForbidden
+}
+
+// IsRetryable indica si la operación puede reintentarse: status 429, 502, 503
+// o 504, códigos SERVICE_UNAVAILABLE, TIMEOUT o RATE_LIMIT_EXCEEDED, o meta
+// should_retry (ej: fallos de serialización clasificados por pgerr)
+func IsRetryable(err error) bool {
+	switch statusOf(err) {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+
+	var meta map[string]interface{}
+	var remote *ResponseError
+	var local *Error
+	switch {
+	case stderrors.As(err, &remote):
+		meta = remote.Meta
+		if details, ok := remote.Details.(map[string]interface{}); ok && details["should_retry"] == true {
+			return true
+		}
+	case stderrors.As(err, &local):
+		meta = local.Meta
+	default:
+		return false
+	}
+
+	switch CodeOf(err) {
+	case CodeServiceUnavailable, CodeTimeout, CodeRateLimitExceeded:
+		return true
+	}
+	return meta["should_retry"] == true
+}
+
+// statusOf retorna el status HTTP de un *ResponseError o *Error en la cadena de err
+func statusOf(err error) int {
+	var remote *ResponseError
+	if stderrors.As(err, &remote) {
+		return remote.Status
+	}
+	var local *Error
+	if stderrors.As(err, &local) {
+		return local.HTTPStatus()
+	}
+	return 0
+}
+
+// publicCode mapea un InternalErrorCode a su ErrorCode público
+func publicCode(code InternalErrorCode, status int) ErrorCode {
+	switch code {
+	case InternalUnauthorized:
+		return CodeUnauthorized
+	case InternalForbidden, InternalAuthzCheck:
+		return CodePermissionDenied
+	case InternalNotFound:
+		return CodeNotFound
+	case InternalConflict:
+		return CodeConflict
+	case InternalValidation:
+		return CodeValidationError
+	case InternalBadRequest:
+		return CodeBadRequest
+	case InternalDatabase:
+		return CodeDatabaseError
+	case InternalTimeout:
+		return CodeTimeout
+	case InternalServiceDown:
+		return CodeServiceUnavailable
+	case InternalRateLimit:
+		return CodeRateLimitExceeded
+	case InternalServerError:
+		return CodeInternalError
+	default:
+		return codeForStatus(status)
+	}
+}
+
+// codeForStatus deriva un ErrorCode de un status HTTP sin body reconocible
+func codeForStatus(status int) ErrorCode {
+	switch status {
+	case http.StatusUnauthorized:
+		return CodeUnauthorized
+	case http.StatusForbidden:
+		return CodePermissionDenied
+	case http.StatusNotFound:
+		return CodeNotFound
+	case http.StatusConflict:
+		return CodeConflict
+	case http.StatusGone:
+		return CodeGone
+	case http.StatusTooManyRequests:
+		return CodeRateLimitExceeded
+	case http.StatusServiceUnavailable, http.StatusBadGateway:
+		return CodeServiceUnavailable
+	case http.StatusGatewayTimeout:
+		return CodeTimeout
+	}
+	if status >= http.StatusInternalServerError {
+		return CodeInternalError
+	}
+	return CodeBadRequest
+}
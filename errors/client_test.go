@@ -0,0 +1,101 @@
+package errors_test
+
+import (
+	stderrors "errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	errors "github.com/AoC-Gamers/connect-libraries/errors"
+)
+
+func responseFrom(t *testing.T, handler http.HandlerFunc) *http.Response {
+	t.Helper()
+	rr := httptest.NewRecorder()
+	handler(rr, httptest.NewRequest(http.MethodGet, "/core/internal/teams/9", nil))
+	return rr.Result()
+}
+
+func TestFromResponseDecodesErrorResponse(t *testing.T) {
+	resp := responseFrom(t, func(w http.ResponseWriter, r *http.Request) {
+		errors.RespondNotFound(w, "team", "9")
+	})
+
+	err := errors.FromResponse(resp)
+	var remote *errors.ResponseError
+	if !stderrors.As(err, &remote) {
+		t.Fatalf("expected *ResponseError, got %T", err)
+	}
+	if remote.Status != http.StatusNotFound || remote.Code != errors.CodeNotFound || remote.Meta["identifier"] != "9" {
+		t.Fatalf("unexpected decoded error: %+v", remote)
+	}
+	if !errors.IsNotFound(fmt.Errorf("load team: %w", err)) || errors.IsRetryable(err) {
+		t.Fatalf("unexpected helper results for %v", err)
+	}
+}
+
+func TestFromResponseDecodesInternalErrorResponse(t *testing.T) {
+	resp := responseFrom(t, func(w http.ResponseWriter, r *http.Request) {
+		errors.RespondInternalServiceDown(w, r, "connect-auth")
+	})
+
+	err := errors.FromResponse(resp)
+	var remote *errors.ResponseError
+	if !stderrors.As(err, &remote) {
+		t.Fatalf("expected *ResponseError, got %T", err)
+	}
+	if remote.InternalCode != errors.InternalServiceDown || remote.Code != errors.CodeServiceUnavailable || remote.Service != "connect-core" {
+		t.Fatalf("unexpected decoded error: %+v", remote)
+	}
+	details, ok := remote.Details.(map[string]interface{})
+	if !ok || details["unavailable_service"] != "connect-auth" {
+		t.Fatalf("expected details to be preserved, got %#v", remote.Details)
+	}
+	if !errors.IsRetryable(err) || !errors.HasCode(err, errors.CodeServiceUnavailable) {
+		t.Fatalf("expected service down to be retryable")
+	}
+	if !stderrors.Is(err, &errors.ResponseError{InternalCode: errors.InternalServiceDown}) {
+		t.Fatalf("expected errors.Is to match internal code")
+	}
+	if remote.ErrorID == "" || !strings.Contains(err.Error(), "error_id "+remote.ErrorID) {
+		t.Fatalf("expected error ID to be decoded, got %q", err.Error())
+	}
+	if !strings.Contains(err.Error(), "connect-core: INTERNAL_SERVICE_DOWN") {
+		t.Fatalf("unexpected error text %q", err.Error())
+	}
+}
+
+func TestFromResponseDecodesProblemDetails(t *testing.T) {
+	resp := responseFrom(t, func(w http.ResponseWriter, r *http.Request) {
+		errors.RespondProblem(w, r, http.StatusConflict, errors.CodeConflict, "conflict", "busy", map[string]interface{}{"should_retry": true})
+	})
+
+	err := errors.FromResponse(resp)
+	if errors.CodeOf(err) != errors.CodeConflict || !errors.IsConflict(err) || !errors.IsRetryable(err) {
+		t.Fatalf("unexpected problem decoding: %v", err)
+	}
+}
+
+func TestFromResponseWithoutJSONBody(t *testing.T) {
+	resp := &http.Response{StatusCode: http.StatusBadGateway, Header: http.Header{}, Body: io.NopCloser(strings.NewReader("<html>bad gateway</html>"))}
+	err := errors.FromResponse(resp)
+	if errors.CodeOf(err) != errors.CodeServiceUnavailable || !errors.IsRetryable(err) {
+		t.Fatalf("unexpected fallback error: %v", err)
+	}
+
+	if errors.FromResponse(&http.Response{StatusCode: http.StatusOK}) != nil || errors.FromResponse(nil) != nil {
+		t.Fatalf("expected nil for successful or missing responses")
+	}
+}
+
+func TestStatusHelpersOnLocalErrors(t *testing.T) {
+	if !errors.IsUnauthorized(errors.TokenExpired()) || !errors.IsForbidden(errors.InsufficientPermissions("edit")) {
+		t.Fatalf("unexpected status helper results")
+	}
+	if !errors.IsRetryable(errors.Timeout("query", 5)) || errors.IsRetryable(stderrors.New("plain")) {
+		t.Fatalf("unexpected IsRetryable results")
+	}
+}
@@ -0,0 +1,133 @@
+// Command errorsgen genera types.ts (enums e interfaces TypeScript) y
+// openapi.json (schemas de componentes OpenAPI) a partir de las constantes
+// ErrorCode de codes.go e InternalErrorCode de internal.go, de los structs de
+// respuesta (ErrorResponse, InternalErrorResponse, ProblemDetails, FieldError)
+// y del catálogo de mensajes de messages.go, que son la fuente de verdad.
+//
+// Uso (desde la raíz del módulo errors):
+//
+//	go run ./cmd/errorsgen          # regenera types.ts y openapi.json
+//	go run ./cmd/errorsgen -check   # falla si algún archivo está desactualizado
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+func main() {
+	root := flag.String("root", ".", "raíz del módulo errors (donde están codes.go e internal.go)")
+	check := flag.Bool("check", false, "no escribe; falla si algún archivo generado está desactualizado")
+	flag.Parse()
+
+	if err := run(*root, *check); err != nil {
+		fmt.Fprintf(os.Stderr, "errorsgen: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(root string, check bool) error {
+	outputs, err := build(root)
+	if err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(outputs))
+	for name := range outputs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var stale []string
+	for _, name := range names {
+		target := filepath.Join(root, name)
+		current, err := os.ReadFile(filepath.Clean(target))
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("read %s: %w", target, err)
+		}
+		if bytes.Equal(current, outputs[name]) {
+			continue
+		}
+		if check {
+			stale = append(stale, target)
+			continue
+		}
+		// #nosec G306 -- los archivos generados se versionan y deben ser legibles por todos
+		if err := os.WriteFile(target, outputs[name], 0o644); err != nil {
+			return fmt.Errorf("write %s: %w", target, err)
+		}
+		fmt.Printf("errorsgen: wrote %s\n", target)
+	}
+
+	if len(stale) > 0 {
+		return fmt.Errorf("archivos generados desactualizados (ejecutar go run ./cmd/errorsgen): %v", stale)
+	}
+	return nil
+}
+
+// responseStruct es un struct de respuesta publicado en types.ts y openapi.json
+type responseStruct struct {
+	File   string // relativo a root
+	Type   string // nombre del struct y del schema OpenAPI
+	TSName string // nombre de la interface TypeScript
+
+	// Extensions indica que MarshalJSON aplana miembros adicionales
+	Extensions bool
+
+	// Formats es el format OpenAPI de los campos que lo necesitan
+	Formats map[string]string
+}
+
+var responseStructs = []responseStruct{
+	{File: "errors.go", Type: "ErrorResponse", TSName: "ApiErrorResponse"},
+	{File: "internal.go", Type: "InternalErrorResponse", TSName: "InternalErrorResponse"},
+	{File: "problem.go", Type: "ProblemDetails", TSName: "ProblemDetails", Extensions: true,
+		Formats: map[string]string{"type": "uri-reference", "instance": "uri-reference"}},
+	{File: "validate.go", Type: "FieldError", TSName: "FieldError"},
+}
+
+// responseDef es un responseStruct con los campos leídos del fuente
+type responseDef struct {
+	responseStruct
+	structDef
+}
+
+// build lee las constantes y los structs desde el código fuente y renderiza
+// cada salida, indexada por su ruta relativa a root
+func build(root string) (map[string][]byte, error) {
+	public, err := parseCodes(filepath.Join(root, "codes.go"), "ErrorCode")
+	if err != nil {
+		return nil, err
+	}
+	internal, err := parseCodes(filepath.Join(root, "internal.go"), "InternalErrorCode")
+	if err != nil {
+		return nil, err
+	}
+	structs := make([]responseDef, 0, len(responseStructs))
+	for _, rs := range responseStructs {
+		def, err := parseStruct(filepath.Join(root, rs.File), rs.Type)
+		if err != nil {
+			return nil, err
+		}
+		structs = append(structs, responseDef{responseStruct: rs, structDef: def})
+	}
+
+	ts, err := renderTypeScript(public, internal, structs)
+	if err != nil {
+		return nil, err
+	}
+	spec, err := renderOpenAPI(public, internal, structs)
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string][]byte{
+		"types.ts":     ts,
+		"openapi.json": spec,
+	}, nil
+}
@@ -0,0 +1,160 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testRootDir = "../.."
+
+func TestGeneratedFilesUpToDate(t *testing.T) {
+	outputs, err := build(testRootDir)
+	if err != nil {
+		t.Fatalf("unexpected build error: %v", err)
+	}
+
+	for name, want := range outputs {
+		target := filepath.Join(testRootDir, name)
+		got, err := os.ReadFile(target)
+		if err != nil {
+			t.Fatalf("read %s: %v", target, err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Errorf("%s is stale, run: go run ./cmd/errorsgen", target)
+		}
+	}
+}
+
+func TestParseCodesSections(t *testing.T) {
+	public, err := parseCodes(filepath.Join(testRootDir, "codes.go"), "ErrorCode")
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+	first := public[0]
+	if first.Name != "CodeUnauthorized" || first.Value != "UNAUTHORIZED" || first.Section != "Authentication & Authorization Errors" {
+		t.Fatalf("unexpected first code: %+v", first)
+	}
+
+	internal, err := parseCodes(filepath.Join(testRootDir, "internal.go"), "InternalErrorCode")
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+	last := internal[len(internal)-1]
+	if last.Value != "INTERNAL_SERVER_ERROR" || last.Section != "Generic" {
+		t.Fatalf("unexpected last internal code: %+v", last)
+	}
+}
+
+// parseTestStructs lee los structs de respuesta del módulo
+func parseTestStructs(t *testing.T) []responseDef {
+	t.Helper()
+	var structs []responseDef
+	for _, rs := range responseStructs {
+		def, err := parseStruct(filepath.Join(testRootDir, rs.File), rs.Type)
+		if err != nil {
+			t.Fatalf("unexpected parse error: %v", err)
+		}
+		structs = append(structs, responseDef{responseStruct: rs, structDef: def})
+	}
+	return structs
+}
+
+func TestRenderRequiresCatalogueMessage(t *testing.T) {
+	codes := []codeConst{{Name: "CodeNew", Value: "BRAND_NEW", Section: "New"}}
+	if _, err := renderTypeScript(codes, codes, nil); err == nil || !strings.Contains(err.Error(), "BRAND_NEW") {
+		t.Fatalf("expected missing catalogue message error, got %v", err)
+	}
+}
+
+func TestParseStructFollowsJSONTags(t *testing.T) {
+	def, err := parseStruct(filepath.Join(testRootDir, "problem.go"), "ProblemDetails")
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+	var names []string
+	for _, field := range def.Fields {
+		names = append(names, field.Name)
+		if field.Doc == "" {
+			t.Errorf("field %s has no doc comment", field.Name)
+		}
+	}
+	// Extensions (json:"-") se aplana con MarshalJSON y no es un campo
+	if strings.Join(names, ",") != "type,title,status,detail,instance,code" {
+		t.Fatalf("unexpected fields: %v", names)
+	}
+	if def.Fields[0].Optional || !def.Fields[3].Optional || def.Fields[5].GoType != "ErrorCode" {
+		t.Fatalf("unexpected field details: %+v", def.Fields)
+	}
+}
+
+func TestOpenAPIListsEveryCode(t *testing.T) {
+	public := []codeConst{{Value: "NOT_FOUND"}, {Value: "CONFLICT"}}
+	internal := []codeConst{{Value: "INTERNAL_NOT_FOUND"}}
+	spec, err := renderOpenAPI(public, internal, parseTestStructs(t))
+	if err != nil {
+		t.Fatalf("unexpected render error: %v", err)
+	}
+
+	var doc struct {
+		Components struct {
+			Schemas map[string]struct {
+				Enum                 []string                   `json:"enum"`
+				HTTPStatus           map[string]int             `json:"x-http-status"`
+				Required             []string                   `json:"required"`
+				Properties           map[string]json.RawMessage `json:"properties"`
+				AdditionalProperties bool                       `json:"additionalProperties"`
+			} `json:"schemas"`
+		} `json:"components"`
+	}
+	if err := json.Unmarshal(spec, &doc); err != nil {
+		t.Fatalf("invalid openapi json: %v", err)
+	}
+	codes := doc.Components.Schemas["ErrorCode"]
+	if strings.Join(codes.Enum, ",") != "NOT_FOUND,CONFLICT" || codes.HTTPStatus["NOT_FOUND"] != 404 {
+		t.Fatalf("unexpected ErrorCode schema: %+v", codes)
+	}
+	for _, name := range []string{"ErrorResponse", "InternalErrorResponse", "InternalErrorCode", "ProblemDetails", "FieldError", "ValidationError"} {
+		if _, ok := doc.Components.Schemas[name]; !ok {
+			t.Fatalf("missing schema %s", name)
+		}
+	}
+
+	// Los schemas de los structs salen de sus campos y tags json
+	response := doc.Components.Schemas["ErrorResponse"]
+	if strings.Join(response.Required, ",") != "error,status" || len(response.Properties) != 5 {
+		t.Fatalf("unexpected ErrorResponse schema: %+v", response)
+	}
+	problem := doc.Components.Schemas["ProblemDetails"]
+	if !problem.AdditionalProperties || problem.Properties["Extensions"] != nil {
+		t.Fatalf("expected ProblemDetails to allow flattened extensions: %+v", problem)
+	}
+}
+
+func TestTypeScriptInterfacesFollowStructs(t *testing.T) {
+	public, err := parseCodes(filepath.Join(testRootDir, "codes.go"), "ErrorCode")
+	if err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+	ts, err := renderTypeScript(public, nil, parseTestStructs(t))
+	if err != nil {
+		t.Fatalf("unexpected render error: %v", err)
+	}
+	for _, want := range []string{
+		"export interface ApiErrorResponse {",
+		"  code?: ErrorCode;",
+		"  error_id?: string;",
+		"export interface ProblemDetails {",
+		"  [extension: string]: unknown;",
+		"export interface FieldError {",
+		"export interface ValidationError extends ApiErrorResponse {",
+		"[ErrorCode.NOT_FOUND]: 'Not found',",
+	} {
+		if !strings.Contains(string(ts), want) {
+			t.Errorf("types.ts is missing %q", want)
+		}
+	}
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+
+	errors "github.com/AoC-Gamers/connect-libraries/errors"
+)
+
+type object = map[string]interface{}
+
+// schemaTypes traduce los tipos Go de los structs de respuesta
+var schemaTypes = map[string]object{
+	"string":                 {"type": "string"},
+	"int":                    {"type": "integer"},
+	"int64":                  {"type": "integer"},
+	"bool":                   {"type": "boolean"},
+	"ErrorCode":              {"$ref": "#/components/schemas/ErrorCode"},
+	"InternalErrorCode":      {"$ref": "#/components/schemas/InternalErrorCode"},
+	"interface{}":            {},
+	"any":                    {},
+	"map[string]interface{}": {"type": "object", "additionalProperties": true},
+	"map[string]any":         {"type": "object", "additionalProperties": true},
+	"map[string]string":      {"type": "object", "additionalProperties": object{"type": "string"}},
+}
+
+// structSchema genera el schema de un struct de respuesta
+func structSchema(def responseDef) (object, error) {
+	required := []string{}
+	properties := make(object, len(def.Fields))
+	for _, field := range def.Fields {
+		base, ok := schemaTypes[field.GoType]
+		if !ok {
+			return nil, fmt.Errorf("%s.%s: tipo %s sin equivalente OpenAPI", def.Type, field.Name, field.GoType)
+		}
+		property := make(object, len(base)+2)
+		for key, value := range base {
+			property[key] = value
+		}
+		// Los hermanos de $ref se ignoran en OpenAPI 3.0
+		if _, isRef := base["$ref"]; !isRef && field.Doc != "" {
+			property["description"] = field.Doc
+		}
+		if format, ok := def.Formats[field.Name]; ok {
+			property["format"] = format
+		}
+		properties[field.Name] = property
+		if !field.Optional {
+			required = append(required, field.Name)
+		}
+	}
+
+	schema := object{
+		"type":        "object",
+		"description": fmt.Sprintf("%s (%s %s)", def.Doc, def.File, def.Type),
+		"required":    required,
+		"properties":  properties,
+	}
+	if def.Extensions {
+		schema["additionalProperties"] = true
+	}
+	return schema, nil
+}
+
+// renderOpenAPI genera un documento OpenAPI 3 con solo components.schemas,
+// listo para combinarse con la especificación de cada servicio
+func renderOpenAPI(public, internal []codeConst, structs []responseDef) ([]byte, error) {
+	publicValues := make([]string, 0, len(public))
+	statuses := make(object, len(public))
+	for _, code := range public {
+		publicValues = append(publicValues, code.Value)
+		statuses[code.Value] = errors.ErrorCode(code.Value).HTTPStatus()
+	}
+	internalValues := make([]string, 0, len(internal))
+	for _, code := range internal {
+		internalValues = append(internalValues, code.Value)
+	}
+
+	schemas := object{
+		"ErrorCode": object{
+			"type":          "string",
+			"description":   "Programmatic error code (codes.go)",
+			"enum":          publicValues,
+			"x-http-status": statuses,
+		},
+		"InternalErrorCode": object{
+			"type":        "string",
+			"description": "Service-to-service error code (internal.go)",
+			"enum":        internalValues,
+		},
+		"ValidationError": object{
+			"description": "VALIDATION_ERROR response: meta.errors maps each field to its message, meta.fields lists every failure (errors.Validate) and single-field errors carry meta.field and meta.reason",
+			"allOf": []object{
+				{"$ref": "#/components/schemas/ErrorResponse"},
+				{
+					"type": "object",
+					"properties": object{
+						"code": object{"type": "string", "enum": []string{string(errors.CodeValidationError)}},
+						"meta": object{
+							"type": "object",
+							"properties": object{
+								"errors": object{"type": "object", "additionalProperties": object{"type": "string"}},
+								"fields": object{"type": "array", "items": object{"$ref": "#/components/schemas/FieldError"}},
+								"field":  object{"type": "string"},
+								"reason": object{"type": "string"},
+							},
+							"additionalProperties": true,
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, def := range structs {
+		schema, err := structSchema(def)
+		if err != nil {
+			return nil, err
+		}
+		schemas[def.Type] = schema
+	}
+
+	doc := object{
+		"openapi": "3.0.3",
+		"info": object{
+			"title":   "Connect error responses",
+			"version": "1.0.0",
+		},
+		"paths": object{},
+		"components": object{
+			"schemas": schemas,
+		},
+	}
+
+	encoded, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("render openapi.json: %w", err)
+	}
+	return append(encoded, '\n'), nil
+}
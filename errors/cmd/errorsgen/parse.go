@@ -0,0 +1,241 @@
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// codeConst es una constante de código de error en el orden del fuente
+type codeConst struct {
+	Name    string
+	Value   string
+	Section string
+}
+
+// parseCodes retorna las constantes del tipo typeName declaradas en path. La
+// sección de cada constante es el último encabezado previo dentro del bloque
+// const: un banner "// ====" o un comentario que no documenta la constante.
+func parseCodes(path, typeName string) ([]codeConst, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, path, nil, parser.ParseComments)
+	if err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+
+	var codes []codeConst
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.CONST {
+			continue
+		}
+
+		var specs []*ast.ValueSpec
+		for _, spec := range gen.Specs {
+			vs, ok := spec.(*ast.ValueSpec)
+			if !ok || !isType(vs.Type, typeName) {
+				continue
+			}
+			specs = append(specs, vs)
+		}
+		if len(specs) == 0 {
+			continue
+		}
+
+		headings := sectionHeadings(file, gen, specs)
+		for _, vs := range specs {
+			if len(vs.Names) != 1 || len(vs.Values) != 1 {
+				return nil, fmt.Errorf("%s: %s debe declararse como una constante con valor literal", path, typeName)
+			}
+			lit, ok := vs.Values[0].(*ast.BasicLit)
+			if !ok || lit.Kind != token.STRING {
+				return nil, fmt.Errorf("%s: %s no tiene un valor string literal", path, vs.Names[0].Name)
+			}
+			value, err := strconv.Unquote(lit.Value)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %s: %w", path, vs.Names[0].Name, err)
+			}
+
+			code := codeConst{Name: vs.Names[0].Name, Value: value}
+			for _, h := range headings {
+				if h.pos < vs.Pos() {
+					code.Section = h.text
+				}
+			}
+			codes = append(codes, code)
+		}
+	}
+
+	if len(codes) == 0 {
+		return nil, fmt.Errorf("%s: no se encontraron constantes %s", path, typeName)
+	}
+	return codes, nil
+}
+
+type heading struct {
+	pos  token.Pos
+	text string
+}
+
+func sectionHeadings(file *ast.File, gen *ast.GenDecl, specs []*ast.ValueSpec) []heading {
+	names := make(map[string]bool, len(specs))
+	for _, vs := range specs {
+		for _, name := range vs.Names {
+			names[name.Name] = true
+		}
+	}
+
+	var headings []heading
+	for _, group := range file.Comments {
+		if group.Pos() < gen.Pos() || group.End() > gen.End() {
+			continue
+		}
+		text := ""
+		for _, line := range strings.Split(group.Text(), "\n") {
+			line = strings.TrimSpace(line)
+			if line != "" && strings.Trim(line, "=") != "" {
+				text = line
+				break
+			}
+		}
+		if text == "" {
+			continue
+		}
+		first := strings.Fields(text)[0]
+		if names[first] {
+			continue
+		}
+		headings = append(headings, heading{pos: group.Pos(), text: text})
+	}
+	return headings
+}
+
+func isType(expr ast.Expr, name string) bool {
+	ident, ok := expr.(*ast.Ident)
+	return ok && ident.Name == name
+}
+
+// sections agrupa las constantes por sección conservando el orden
+func sections(codes []codeConst) [][]codeConst {
+	var groups [][]codeConst
+	for i, code := range codes {
+		if i == 0 || code.Section != codes[i-1].Section {
+			groups = append(groups, nil)
+		}
+		groups[len(groups)-1] = append(groups[len(groups)-1], code)
+	}
+	return groups
+}
+
+// structField es un campo serializado de un struct de respuesta
+type structField struct {
+	Name     string // nombre JSON
+	GoType   string
+	Optional bool // omitempty
+	Doc      string
+}
+
+// structDef es un struct de respuesta con sus campos en el orden del fuente
+type structDef struct {
+	Name   string
+	Doc    string
+	Fields []structField
+}
+
+// parseStruct retorna los campos JSON del struct typeName declarado en path.
+// Los campos con `json:"-"` se omiten; la documentación de cada campo es su
+// comentario, en una sola línea.
+func parseStruct(path, typeName string) (structDef, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, path, nil, parser.ParseComments)
+	if err != nil {
+		return structDef{}, fmt.Errorf("parse %s: %w", path, err)
+	}
+
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			ts, ok := spec.(*ast.TypeSpec)
+			if !ok || ts.Name.Name != typeName {
+				continue
+			}
+			st, ok := ts.Type.(*ast.StructType)
+			if !ok {
+				return structDef{}, fmt.Errorf("%s: %s no es un struct", path, typeName)
+			}
+			def := structDef{Name: typeName, Doc: firstLine(gen.Doc)}
+			for _, field := range st.Fields.List {
+				if len(field.Names) != 1 {
+					return structDef{}, fmt.Errorf("%s: %s debe declarar un campo con nombre por línea", path, typeName)
+				}
+				name, optional, skip, err := jsonName(field)
+				if err != nil {
+					return structDef{}, fmt.Errorf("%s: %s.%s: %w", path, typeName, field.Names[0].Name, err)
+				}
+				if skip || !field.Names[0].IsExported() {
+					continue
+				}
+				doc := joinLines(field.Doc)
+				if doc == "" {
+					doc = joinLines(field.Comment)
+				}
+				def.Fields = append(def.Fields, structField{
+					Name:     name,
+					GoType:   types.ExprString(field.Type),
+					Optional: optional,
+					Doc:      doc,
+				})
+			}
+			return def, nil
+		}
+	}
+	return structDef{}, fmt.Errorf("%s: no se encontró el struct %s", path, typeName)
+}
+
+// jsonName lee el tag json del campo
+func jsonName(field *ast.Field) (name string, optional, skip bool, err error) {
+	name = field.Names[0].Name
+	if field.Tag == nil {
+		return name, false, false, nil
+	}
+	tag, err := strconv.Unquote(field.Tag.Value)
+	if err != nil {
+		return "", false, false, err
+	}
+	value, ok := reflect.StructTag(tag).Lookup("json")
+	if !ok {
+		return name, false, false, nil
+	}
+	if value == "-" {
+		return "", false, true, nil
+	}
+	parts := strings.Split(value, ",")
+	if parts[0] != "" {
+		name = parts[0]
+	}
+	return name, slices.Contains(parts[1:], "omitempty"), false, nil
+}
+
+func firstLine(group *ast.CommentGroup) string {
+	if group == nil {
+		return ""
+	}
+	line, _, _ := strings.Cut(strings.TrimSpace(group.Text()), "\n")
+	return line
+}
+
+func joinLines(group *ast.CommentGroup) string {
+	if group == nil {
+		return ""
+	}
+	return strings.Join(strings.Fields(group.Text()), " ")
+}
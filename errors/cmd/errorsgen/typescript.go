@@ -0,0 +1,290 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"text/template"
+	"unicode"
+	"unicode/utf8"
+
+	errors "github.com/AoC-Gamers/connect-libraries/errors"
+)
+
+type tsMessage struct {
+	Value   string
+	Message string
+}
+
+type tsField struct {
+	Name     string
+	Type     string
+	Optional bool
+	Doc      string
+}
+
+type tsInterface struct {
+	Name       string
+	Source     string
+	Doc        string
+	Fields     []tsField
+	Extensions bool
+}
+
+type tsData struct {
+	Public     [][]codeConst
+	Internal   [][]codeConst
+	Interfaces []tsInterface
+	Messages   []tsMessage
+}
+
+// tsTypes traduce los tipos Go de los structs de respuesta
+var tsTypes = map[string]string{
+	"string":                 "string",
+	"int":                    "number",
+	"int64":                  "number",
+	"bool":                   "boolean",
+	"ErrorCode":              "ErrorCode",
+	"InternalErrorCode":      "InternalErrorCode",
+	"interface{}":            "unknown",
+	"any":                    "unknown",
+	"map[string]interface{}": "Record<string, unknown>",
+	"map[string]any":         "Record<string, unknown>",
+	"map[string]string":      "Record<string, string>",
+}
+
+func renderTypeScript(public, internal []codeConst, structs []responseDef) ([]byte, error) {
+	data := tsData{Public: sections(public), Internal: sections(internal)}
+	for _, def := range structs {
+		iface := tsInterface{Name: def.TSName, Source: def.File + " " + def.Type, Doc: def.Doc, Extensions: def.Extensions}
+		for _, field := range def.Fields {
+			tsType, ok := tsTypes[field.GoType]
+			if !ok {
+				return nil, fmt.Errorf("%s.%s: tipo %s sin equivalente TypeScript", def.Type, field.Name, field.GoType)
+			}
+			iface.Fields = append(iface.Fields, tsField{Name: field.Name, Type: tsType, Optional: field.Optional, Doc: field.Doc})
+		}
+		data.Interfaces = append(data.Interfaces, iface)
+	}
+	for _, code := range public {
+		message, ok := errors.LookupMessage(errors.LangEN, code.Value)
+		if !ok || message.Message == "" {
+			return nil, fmt.Errorf("falta el mensaje de %s en el catálogo de messages.go", code.Value)
+		}
+		data.Messages = append(data.Messages, tsMessage{Value: code.Value, Message: capitalize(message.Message)})
+	}
+
+	var buf bytes.Buffer
+	if err := typesTemplate.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("render types.ts: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func capitalize(text string) string {
+	r, size := utf8.DecodeRuneInString(text)
+	return string(unicode.ToUpper(r)) + text[size:]
+}
+
+var typesTemplate = template.Must(template.New("types").Parse(`/**
+ * Code generated by errorsgen from the errors package sources. DO NOT EDIT.
+ * Regenerate with: go run ./cmd/errorsgen
+ *
+ * TypeScript Types for Connect Backend Error Responses
+ *
+ * Copy this file to your frontend project:
+ * src/models/api-error.model.ts
+ *
+ * These types match the Go response structs (ErrorResponse,
+ * InternalErrorResponse, ProblemDetails, FieldError) from connect-errors library
+ */
+
+/**
+ * Standardized error codes from backend
+ * Generated from codes.go ErrorCode constants
+ */
+export enum ErrorCode {
+{{- range $i, $section := .Public}}
+{{- if $i}}
+{{end}}
+  // {{(index $section 0).Section}}
+{{- range $section}}
+  {{.Value}} = '{{.Value}}',
+{{- end}}
+{{- end}}
+}
+
+/**
+ * Error codes for service-to-service responses
+ * Generated from internal.go InternalErrorCode constants
+ */
+export enum InternalErrorCode {
+{{- range $i, $section := .Internal}}
+{{- if $i}}
+{{end}}
+  // {{(index $section 0).Section}}
+{{- range $section}}
+  {{.Value}} = '{{.Value}}',
+{{- end}}
+{{- end}}
+}
+
+{{range .Interfaces -}}
+/**
+ * {{.Doc}}
+ * Generated from {{.Source}}
+ */
+export interface {{.Name}} {
+{{- range $i, $f := .Fields}}
+{{- if $i}}
+{{end}}
+  /** {{$f.Doc}} */
+  {{$f.Name}}{{if $f.Optional}}?{{end}}: {{$f.Type}};
+{{- end}}
+{{- if .Extensions}}
+
+  /** Extension members (flattened meta) */
+  [extension: string]: unknown;
+{{- end}}
+}
+
+{{end -}}
+/**
+ * VALIDATION_ERROR response: meta.errors maps each field to its message,
+ * meta.fields lists every failure (errors.Validate) and single-field errors
+ * carry meta.field and meta.reason
+ */
+export interface ValidationError extends ApiErrorResponse {
+  code: ErrorCode.VALIDATION_ERROR;
+
+  meta?: {
+    errors?: Record<string, string>;
+    fields?: FieldError[];
+    field?: string;
+    reason?: string;
+    [key: string]: unknown;
+  };
+}
+
+/**
+ * Extended Error class with structured information
+ */
+export class ApiError extends Error {
+  public readonly code?: ErrorCode;
+  public readonly status: number;
+  public readonly detail?: string;
+  public readonly meta?: Record<string, unknown>;
+
+  constructor(response: ApiErrorResponse) {
+    super(response.error);
+    this.name = 'ApiError';
+    this.code = response.code;
+    this.status = response.status;
+    this.detail = response.detail;
+    this.meta = response.meta;
+  }
+
+  /**
+   * Check if error is of specific code
+   */
+  is(code: ErrorCode): boolean {
+    return this.code === code;
+  }
+
+  /**
+   * Check if error is permission-related
+   */
+  isPermissionError(): boolean {
+    return this.code === ErrorCode.PERMISSION_DENIED ||
+           this.code === ErrorCode.INSUFFICIENT_PERMISSIONS;
+  }
+
+  /**
+   * Check if error is membership not found
+   */
+  isMembershipNotFound(): boolean {
+    return this.code === ErrorCode.MEMBERSHIP_NOT_FOUND;
+  }
+
+  /**
+   * Check if error is not found
+   */
+  isNotFound(): boolean {
+    return this.code === ErrorCode.NOT_FOUND ||
+           this.code === ErrorCode.MEMBERSHIP_NOT_FOUND;
+  }
+
+  /**
+   * Check if error requires re-authentication
+   */
+  requiresReauth(): boolean {
+    return this.code === ErrorCode.TOKEN_EXPIRED ||
+           this.code === ErrorCode.POLICY_VERSION_MISMATCH ||
+           this.code === ErrorCode.TOKEN_INVALID;
+  }
+
+  /**
+   * Check if error is validation-related
+   */
+  isValidationError(): boolean {
+    return this.code === ErrorCode.VALIDATION_ERROR ||
+           this.code === ErrorCode.INVALID_FORMAT ||
+           this.code === ErrorCode.MISSING_REQUIRED_FIELD ||
+           this.code === ErrorCode.OUT_OF_RANGE;
+  }
+
+  /**
+   * Check if error is server-side
+   */
+  isServerError(): boolean {
+    return this.status >= 500 && this.status < 600;
+  }
+
+  /**
+   * Get user-friendly message
+   */
+  getUserMessage(): string {
+    const friendlyMessages: Record<ErrorCode, string> = {
+{{- range .Messages}}
+      [ErrorCode.{{.Value}}]: '{{js .Message}}',
+{{- end}}
+    };
+
+    return this.code && friendlyMessages[this.code]
+      ? friendlyMessages[this.code]
+      : this.message || 'An error occurred';
+  }
+
+  /**
+   * Convert to plain object for logging
+   */
+  toJSON() {
+    return {
+      name: this.name,
+      message: this.message,
+      code: this.code,
+      status: this.status,
+      detail: this.detail,
+      meta: this.meta,
+    };
+  }
+}
+
+/**
+ * Type guard to check if error is ApiError
+ */
+export function isApiError(error: unknown): error is ApiError {
+  return error instanceof ApiError;
+}
+
+/**
+ * Type guard to check if response has error structure
+ */
+export function isApiErrorResponse(data: unknown): data is ApiErrorResponse {
+  return (
+    typeof data === 'object' &&
+    data !== null &&
+    'error' in data &&
+    'status' in data
+  );
+}
+`))
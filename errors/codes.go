@@ -1,5 +1,7 @@
 package errors
 
+//go:generate go run ./cmd/errorsgen
+
 // ErrorCode representa códigos de error estandarizados
 // Siguiendo el patrón SCREAMING_SNAKE_CASE para consistencia con REST APIs
 type ErrorCode string
@@ -0,0 +1,142 @@
+package errors
+
+import (
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+// DefaultMaxBodyBytes es el tamaño máximo de body que acepta DecodeJSON (1 MiB)
+const DefaultMaxBodyBytes int64 = 1 << 20
+
+// Claves de mensajes de los errores de DecodeJSON (Code BAD_REQUEST)
+const (
+	KeyBodyEmpty        = "BAD_REQUEST.empty_body"
+	KeyBodyMalformed    = "BAD_REQUEST.malformed_json"
+	KeyBodyTooLarge     = "BAD_REQUEST.body_too_large"
+	KeyBodyUnknownField = "BAD_REQUEST.unknown_field"
+	KeyBodyTrailingData = "BAD_REQUEST.trailing_data"
+)
+
+// DecodeOptions configura DecodeJSONWith y BindWith
+type DecodeOptions struct {
+	// MaxBytes limita el tamaño del body; 0 usa DefaultMaxBodyBytes
+	MaxBytes int64
+
+	// AllowUnknownFields acepta campos que no existen en el struct destino
+	AllowUnknownFields bool
+}
+
+// DecodeJSON decodifica el body JSON en dst con las opciones por defecto
+func DecodeJSON(w http.ResponseWriter, r *http.Request, dst interface{}) error {
+	return DecodeJSONWith(w, r, dst, DecodeOptions{})
+}
+
+// DecodeJSONWith decodifica el body JSON en dst y retorna un *Error preciso:
+//   - body vacío, JSON malformado (con offset), campo desconocido, datos
+//     después del objeto: BAD_REQUEST
+//   - body mayor a MaxBytes: BAD_REQUEST con status 413
+//   - tipo incorrecto en un campo: INVALID_FORMAT con la ruta del campo
+func DecodeJSONWith(w http.ResponseWriter, r *http.Request, dst interface{}, opts DecodeOptions) error {
+	limit := opts.MaxBytes
+	if limit <= 0 {
+		limit = DefaultMaxBodyBytes
+	}
+	if r.Body == nil {
+		return newError(CodeBadRequest, KeyBodyEmpty, nil)
+	}
+
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, limit))
+	if !opts.AllowUnknownFields {
+		decoder.DisallowUnknownFields()
+	}
+	if err := decoder.Decode(dst); err != nil {
+		return decodeError(err, limit)
+	}
+	if err := decoder.Decode(&struct{}{}); !stderrors.Is(err, io.EOF) {
+		var tooLarge *http.MaxBytesError
+		if stderrors.As(err, &tooLarge) {
+			return decodeError(err, limit)
+		}
+		return newError(CodeBadRequest, KeyBodyTrailingData, nil)
+	}
+	return nil
+}
+
+// Bind decodifica el body con DecodeJSON y valida dst con Validate
+func Bind(w http.ResponseWriter, r *http.Request, dst interface{}) error {
+	return BindWith(w, r, dst, DecodeOptions{})
+}
+
+// BindWith es Bind con opciones de decodificación
+func BindWith(w http.ResponseWriter, r *http.Request, dst interface{}, opts DecodeOptions) error {
+	if err := DecodeJSONWith(w, r, dst, opts); err != nil {
+		return err
+	}
+	return Validate(dst)
+}
+
+func decodeError(err error, limit int64) *Error {
+	var (
+		syntaxErr *json.SyntaxError
+		typeErr   *json.UnmarshalTypeError
+		tooLarge  *http.MaxBytesError
+	)
+
+	switch {
+	case stderrors.Is(err, io.EOF):
+		return newError(CodeBadRequest, KeyBodyEmpty, nil)
+	case stderrors.As(err, &tooLarge):
+		e := withParamsAsMeta(newError(CodeBadRequest, KeyBodyTooLarge, map[string]interface{}{"limit": limit}))
+		e.Status = http.StatusRequestEntityTooLarge
+		return e
+	case stderrors.As(err, &syntaxErr):
+		return withParamsAsMeta(newError(CodeBadRequest, KeyBodyMalformed, map[string]interface{}{"offset": syntaxErr.Offset}))
+	case stderrors.Is(err, io.ErrUnexpectedEOF):
+		return withParamsAsMeta(newError(CodeBadRequest, KeyBodyMalformed, map[string]interface{}{"offset": "EOF"}))
+	case stderrors.As(err, &typeErr):
+		field := typeErr.Field
+		if field == "" {
+			field = "body"
+		}
+		e := InvalidFormat(field, jsonTypeName(typeErr.Type))
+		e.Meta["offset"] = typeErr.Offset
+		e.Err = err
+		return e
+	case strings.HasPrefix(err.Error(), "json: unknown field "):
+		field := strings.Trim(strings.TrimPrefix(err.Error(), "json: unknown field "), `"`)
+		return withParamsAsMeta(newError(CodeBadRequest, KeyBodyUnknownField, map[string]interface{}{"field": field}))
+	default:
+		e := BadRequest(err.Error())
+		e.Err = err
+		return e
+	}
+}
+
+// jsonTypeName describe el tipo esperado en términos de JSON
+func jsonTypeName(t reflect.Type) string {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "integer"
+	case reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Slice, reflect.Array:
+		return "array"
+	case reflect.Struct, reflect.Map:
+		return "object"
+	default:
+		return fmt.Sprint(t)
+	}
+}
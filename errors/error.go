@@ -0,0 +1,378 @@
+package errors
+
+import (
+	stderrors "errors"
+	"net/http"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Error es un error de Go que transporta un ErrorCode y se convierte en
+// respuesta HTTP con Render. Permite retornar el error hacia arriba en el
+// stack y renderizarlo una sola vez.
+type Error struct {
+	// Code es el código de error programático
+	Code ErrorCode
+
+	// Message es el mensaje corto enviado al cliente (campo "error")
+	Message string
+
+	// Detail es la explicación enviada al cliente
+	Detail string
+
+	// Meta contiene metadata adicional enviada al cliente
+	Meta map[string]interface{}
+
+	// Status sobrescribe Code.HTTPStatus() cuando es distinto de cero
+	Status int
+
+	// Err es la causa original; nunca se envía al cliente
+	Err error
+
+	// Key identifica la plantilla de mensaje (el Code o "CODE.variante") y
+	// Params sus parámetros; se usan para traducir con Localize y no se envían
+	Key    string
+	Params map[string]interface{}
+}
+
+// New crea un Error con código y mensaje
+func New(code ErrorCode, message string) *Error {
+	return &Error{Code: code, Message: message}
+}
+
+// Wrap crea un Error con código y mensaje que envuelve una causa
+func Wrap(err error, code ErrorCode, message string) *Error {
+	return &Error{Code: code, Message: message, Err: err}
+}
+
+// Error implementa la interfaz error
+func (e *Error) Error() string {
+	text := string(e.Code) + ": " + e.Message
+	if e.Detail != "" {
+		text += ": " + e.Detail
+	}
+	if e.Err != nil {
+		text += ": " + e.Err.Error()
+	}
+	return text
+}
+
+// Unwrap retorna la causa para errors.Is / errors.As
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Is considera iguales dos *Error con el mismo Code, de modo que
+// errors.Is(err, errors.New(errors.CodeNotFound, "")) funciona como sentinel
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Code == e.Code
+}
+
+// HTTPStatus retorna Status o, si no está definido, Code.HTTPStatus()
+func (e *Error) HTTPStatus() int {
+	if e.Status != 0 {
+		return e.Status
+	}
+	return e.Code.HTTPStatus()
+}
+
+// WithDetail retorna una copia con el detalle indicado. El detalle es texto
+// libre, por lo que la copia deja de traducirse con Localize.
+func (e *Error) WithDetail(detail string) *Error {
+	clone := *e
+	clone.Detail = detail
+	clone.Key = ""
+	return &clone
+}
+
+// WithMeta retorna una copia con un valor de metadata adicional
+func (e *Error) WithMeta(key string, value interface{}) *Error {
+	clone := *e
+	clone.Meta = make(map[string]interface{}, len(e.Meta)+1)
+	for k, v := range e.Meta {
+		clone.Meta[k] = v
+	}
+	clone.Meta[key] = value
+	return &clone
+}
+
+// WithCause retorna una copia que envuelve err
+func (e *Error) WithCause(err error) *Error {
+	clone := *e
+	clone.Err = err
+	return &clone
+}
+
+// CodeOf retorna el ErrorCode del primer *Error o *ResponseError en la cadena
+// de err (CodeInternalError si no hay ninguno)
+func CodeOf(err error) ErrorCode {
+	var e *Error
+	if stderrors.As(err, &e) {
+		return e.Code
+	}
+	var remote *ResponseError
+	if stderrors.As(err, &remote) {
+		return remote.Code
+	}
+	return CodeInternalError
+}
+
+// HasCode indica si la cadena de err contiene un *Error con el código indicado
+func HasCode(err error, code ErrorCode) bool {
+	return stderrors.Is(err, &Error{Code: code})
+}
+
+// Render escribe err como respuesta de error. Un *Error (directo o envuelto)
+// usa su código, mensaje, detalle y metadata; cualquier otro error se responde
+// como 500 genérico. Las respuestas 5xx registran la causa en el log y nunca
+// la envían al cliente. Dentro de Responder.Middleware respeta su modo. El
+// mensaje se traduce según Accept-Language (ver NegotiateLanguage).
+func Render(w http.ResponseWriter, r *http.Request, err error) {
+	if err == nil {
+		return
+	}
+
+	var e *Error
+	if !stderrors.As(err, &e) {
+		e = InternalError(err)
+	}
+
+	status := e.HTTPStatus()
+	if status >= http.StatusInternalServerError {
+		event := log.Error().Err(err).Str("code", string(e.Code)).Int("status", status)
+		if r != nil {
+			event = event.Str("method", r.Method).Str("path", r.URL.Path)
+		}
+		event.Msg("Request failed")
+	}
+
+	e = localizeFor(w, r, e)
+	respondErrorFor(w, r, status, e.Code, e.Message, e.Detail, e.Meta)
+}
+
+// HandlerFunc es un handler que retorna un error en lugar de responderlo;
+// el error se renderiza con Render
+type HandlerFunc func(w http.ResponseWriter, r *http.Request) error
+
+// ServeHTTP implementa http.Handler
+func (h HandlerFunc) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if err := h(w, r); err != nil {
+		Render(w, r, err)
+	}
+}
+
+// Handle adapta un handler func(w, r) error a http.HandlerFunc
+func Handle(h func(w http.ResponseWriter, r *http.Request) error) http.HandlerFunc {
+	return HandlerFunc(h).ServeHTTP
+}
+
+// ============================================
+// Constructores (mismos mensajes que los helpers Respond*)
+// ============================================
+
+// Unauthorized crea un error 401 genérico
+func Unauthorized(detail string) *Error {
+	return newError(CodeUnauthorized, "", map[string]interface{}{"detail": detail})
+}
+
+// TokenExpired crea el error de JWT expirado
+func TokenExpired() *Error {
+	e := newError(CodeTokenExpired, "", nil)
+	e.Meta = map[string]interface{}{"should_refresh": true}
+	return e
+}
+
+// TokenInvalid crea el error de JWT inválido
+func TokenInvalid(reason string) *Error {
+	e := newError(CodeTokenInvalid, "", map[string]interface{}{"reason": reason})
+	e.Meta = map[string]interface{}{"should_reauth": true}
+	return e
+}
+
+// PolicyVersionMismatch crea el error de mismatch de policy version
+func PolicyVersionMismatch(tokenVersion, currentVersion int) *Error {
+	e := newError(CodePolicyVersionMismatch, "", nil)
+	e.Meta = map[string]interface{}{
+		"token_version":   tokenVersion,
+		"current_version": currentVersion,
+		"should_reauth":   true,
+	}
+	return e
+}
+
+// PermissionDenied crea el error de permiso faltante en un scope
+func PermissionDenied(scopeID int64, scopeType, permission string, hasMembership bool) *Error {
+	key := ""
+	if !hasMembership {
+		key = KeyPermissionDeniedNoMembership
+	}
+	return withParamsAsMeta(newError(CodePermissionDenied, key, map[string]interface{}{
+		"scope_id":            scopeID,
+		"scope_type":          scopeType,
+		"permission":          permission,
+		"required_permission": permission,
+		"has_membership":      hasMembership,
+	}), "permission")
+}
+
+// InsufficientPermissions crea el error genérico de permisos insuficientes
+func InsufficientPermissions(action string) *Error {
+	return newError(CodeInsufficientPermissions, "", map[string]interface{}{"action": action})
+}
+
+// NotFound crea el error de recurso inexistente
+func NotFound(resourceType, identifier string) *Error {
+	return withParamsAsMeta(newError(CodeNotFound, "", map[string]interface{}{
+		"resource_type": resourceType,
+		"identifier":    identifier,
+	}))
+}
+
+// MembershipNotFound crea el error de membership inexistente
+func MembershipNotFound(scopeID int64, scopeType string, userID int64) *Error {
+	return withParamsAsMeta(newError(CodeMembershipNotFound, "", map[string]interface{}{
+		"scope_id":   scopeID,
+		"scope_type": scopeType,
+		"user_id":    userID,
+	}))
+}
+
+// AlreadyExists crea el error de recurso duplicado
+func AlreadyExists(resourceType, identifier string) *Error {
+	return withParamsAsMeta(newError(CodeAlreadyExists, "", map[string]interface{}{
+		"resource_type": resourceType,
+		"identifier":    identifier,
+	}))
+}
+
+// Conflict crea el error de conflicto de estado
+func Conflict(reason string) *Error {
+	return newError(CodeConflict, "", map[string]interface{}{"reason": reason})
+}
+
+// Validation crea el error de validación de un campo
+func Validation(field, reason string) *Error {
+	return withParamsAsMeta(newError(CodeValidationError, "", map[string]interface{}{
+		"field":  field,
+		"reason": reason,
+	}))
+}
+
+// ValidationErrors crea el error de múltiples validaciones
+func ValidationErrors(errors map[string]string) *Error {
+	e := newError(CodeValidationError, KeyValidationErrors, nil)
+	e.Meta = map[string]interface{}{"errors": errors}
+	return e
+}
+
+// BadRequest crea el error de petición inválida
+func BadRequest(detail string) *Error {
+	return newError(CodeBadRequest, "", map[string]interface{}{"detail": detail})
+}
+
+// MissingField crea el error de campo requerido
+func MissingField(fieldName string) *Error {
+	return withParamsAsMeta(newError(CodeMissingRequiredField, "", map[string]interface{}{
+		"field": fieldName,
+	}))
+}
+
+// InvalidFormat crea el error de formato inválido
+func InvalidFormat(field, expectedFormat string) *Error {
+	return withParamsAsMeta(newError(CodeInvalidFormat, "", map[string]interface{}{
+		"field":           field,
+		"expected_format": expectedFormat,
+	}))
+}
+
+// OutOfRange crea el error de valor fuera de rango
+func OutOfRange(field string, min, max, provided interface{}) *Error {
+	return withParamsAsMeta(newError(CodeOutOfRange, "", map[string]interface{}{
+		"field":    field,
+		"min":      min,
+		"max":      max,
+		"provided": provided,
+	}))
+}
+
+// InternalError crea un error 500 genérico que envuelve la causa
+func InternalError(cause error) *Error {
+	e := newError(CodeInternalError, "", nil)
+	e.Err = cause
+	return e
+}
+
+// DatabaseError crea el error de base de datos para una operación; la causa
+// queda solo en el log
+func DatabaseError(operation string, cause error) *Error {
+	e := withParamsAsMeta(newError(CodeDatabaseError, "", map[string]interface{}{
+		"operation": operation,
+	}))
+	e.Err = cause
+	return e
+}
+
+// ServiceUnavailable crea el error de servicio no disponible
+func ServiceUnavailable(service string) *Error {
+	e := newError(CodeServiceUnavailable, "", map[string]interface{}{"service": service})
+	e.Meta = map[string]interface{}{
+		"service":     service,
+		"retry_after": 60, // segundos
+	}
+	return e
+}
+
+// Timeout crea el error de operación que excede el timeout
+func Timeout(operation string, timeoutSeconds int) *Error {
+	return withParamsAsMeta(newError(CodeTimeout, "", map[string]interface{}{
+		"operation":       operation,
+		"timeout_seconds": timeoutSeconds,
+	}))
+}
+
+// OperationNotAllowed crea el error de operación no permitida
+func OperationNotAllowed(operation, reason string) *Error {
+	return withParamsAsMeta(newError(CodeOperationNotAllowed, "", map[string]interface{}{
+		"operation": operation,
+		"reason":    reason,
+	}))
+}
+
+// QuotaExceeded crea el error de cuota excedida (429)
+func QuotaExceeded(quotaType string, limit, current int) *Error {
+	e := withParamsAsMeta(newError(CodeQuotaExceeded, "", map[string]interface{}{
+		"quota_type": quotaType,
+		"limit":      limit,
+		"current":    current,
+	}))
+	e.Status = http.StatusTooManyRequests
+	return e
+}
+
+// RateLimitExceeded crea el error de rate limit excedido
+func RateLimitExceeded(limit int, window string, retryAfter int) *Error {
+	e := newError(CodeRateLimitExceeded, "", map[string]interface{}{
+		"limit":  limit,
+		"window": window,
+	})
+	e.Meta = map[string]interface{}{
+		"limit":       limit,
+		"window":      window,
+		"retry_after": retryAfter,
+	}
+	return e
+}
+
+// withParamsAsMeta usa los parámetros de la plantilla como metadata,
+// excluyendo los que solo existen para el mensaje
+func withParamsAsMeta(e *Error, messageOnly ...string) *Error {
+	e.Meta = make(map[string]interface{}, len(e.Params))
+	for key, value := range e.Params {
+		e.Meta[key] = value
+	}
+	for _, key := range messageOnly {
+		delete(e.Meta, key)
+	}
+	return e
+}
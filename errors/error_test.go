@@ -0,0 +1,116 @@
+package errors_test
+
+import (
+	"database/sql"
+	"encoding/json"
+	stderrors "errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	errors "github.com/AoC-Gamers/connect-libraries/errors"
+)
+
+func TestErrorWrapping(t *testing.T) {
+	err := fmt.Errorf("loading team: %w", errors.NotFound("team", "12").WithCause(sql.ErrNoRows))
+
+	if !errors.HasCode(err, errors.CodeNotFound) || errors.HasCode(err, errors.CodeConflict) {
+		t.Fatalf("unexpected HasCode results for %v", err)
+	}
+	if !stderrors.Is(err, sql.ErrNoRows) {
+		t.Fatalf("expected cause to be reachable with errors.Is")
+	}
+	if errors.CodeOf(err) != errors.CodeNotFound || errors.CodeOf(stderrors.New("plain")) != errors.CodeInternalError {
+		t.Fatalf("unexpected CodeOf results")
+	}
+
+	var target *errors.Error
+	if !stderrors.As(err, &target) || target.HTTPStatus() != http.StatusNotFound {
+		t.Fatalf("expected *Error with 404, got %+v", target)
+	}
+	if !strings.Contains(err.Error(), "NOT_FOUND") || !strings.Contains(err.Error(), sql.ErrNoRows.Error()) {
+		t.Fatalf("unexpected error text %q", err.Error())
+	}
+}
+
+func TestRenderMatchesHelpers(t *testing.T) {
+	viaHelper := httptest.NewRecorder()
+	errors.RespondQuotaExceeded(viaHelper, "lobbies", 3, 4)
+
+	viaRender := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/lobbies", nil)
+	errors.Render(viaRender, req, fmt.Errorf("create: %w", errors.QuotaExceeded("lobbies", 3, 4)))
+
+	if viaRender.Code != http.StatusTooManyRequests || viaRender.Code != viaHelper.Code {
+		t.Fatalf("expected 429 from both, got helper=%d render=%d", viaHelper.Code, viaRender.Code)
+	}
+	if viaRender.Body.String() != viaHelper.Body.String() {
+		t.Fatalf("expected identical bodies:\n%s\n%s", viaHelper.Body.String(), viaRender.Body.String())
+	}
+}
+
+func TestRenderHidesCauseFor5xx(t *testing.T) {
+	rr := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/teams", nil)
+
+	errors.Render(rr, req, errors.DatabaseError("list teams", stderrors.New("pq: password authentication failed")))
+	if rr.Code != http.StatusInternalServerError {
+		t.Fatalf("expected 500, got %d", rr.Code)
+	}
+	if strings.Contains(rr.Body.String(), "password") {
+		t.Fatalf("cause leaked to the client: %s", rr.Body.String())
+	}
+
+	rr = httptest.NewRecorder()
+	errors.Render(rr, req, stderrors.New("secret connection string"))
+	var response errors.ErrorResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+		t.Fatalf(errParseResponse, err)
+	}
+	if rr.Code != http.StatusInternalServerError || response.Code != errors.CodeInternalError || response.Detail != "" {
+		t.Fatalf("unexpected response for plain error: %d %+v", rr.Code, response)
+	}
+}
+
+func TestHandlerAdapter(t *testing.T) {
+	handler := errors.Handle(func(w http.ResponseWriter, r *http.Request) error {
+		if r.URL.Query().Get("id") == "" {
+			return errors.MissingField("id")
+		}
+		w.WriteHeader(http.StatusNoContent)
+		return nil
+	})
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/", nil))
+	if rr.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", rr.Code)
+	}
+
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/?id=1", nil))
+	if rr.Code != http.StatusNoContent {
+		t.Fatalf("expected 204, got %d", rr.Code)
+	}
+
+	// Render follows the responder mode
+	rr = httptest.NewRecorder()
+	errors.NewResponder(errors.ModeProblem).Middleware(handler).ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/", nil))
+	if rr.Header().Get(errors.HeaderContentType) != errors.MimeProblemJSON {
+		t.Fatalf("expected problem+json inside responder middleware")
+	}
+}
+
+func TestErrorWithMetaDoesNotMutate(t *testing.T) {
+	base := errors.Conflict("lobby is full")
+	withMeta := base.WithMeta("capacity", 8).WithDetail("lobby 3 is full")
+
+	if base.Meta != nil || base.Detail != "lobby is full" {
+		t.Fatalf("expected original error to be unchanged, got %+v", base)
+	}
+	if withMeta.Meta["capacity"] != 8 {
+		t.Fatalf("expected meta on copy")
+	}
+}
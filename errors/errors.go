@@ -12,7 +12,8 @@ const (
 )
 
 // ErrorResponse representa una respuesta de error estructurada
-// Basado en RFC 7807 (Problem Details for HTTP APIs) simplificado
+// Basado en RFC 7807 (Problem Details for HTTP APIs) simplificado.
+// Para el formato RFC 9457 completo ver ProblemDetails y Responder.
 type ErrorResponse struct {
 	// Error es el mensaje corto y legible (mantiene compatibilidad con APIs existentes)
 	Error string `json:"error"`
@@ -27,13 +28,29 @@ type ErrorResponse struct {
 	Detail string `json:"detail,omitempty"`
 
 	// Meta contiene metadata adicional específica del contexto
-	// Por ejemplo: scope_id, required_permission, field_name, etc.
+	// (ej: scope_id, required_permission, field_name)
 	Meta map[string]interface{} `json:"meta,omitempty"`
 }
 
 // RespondError escribe una respuesta de error estructurada
-// Es la función principal para enviar errores al cliente
+// Es la función principal para enviar errores al cliente.
+// Dentro de Responder.Middleware usa el modo de ese responder (legacy o problem+json).
 func RespondError(w http.ResponseWriter, status int, code ErrorCode, message, detail string, meta map[string]interface{}) {
+	respondErrorFor(w, nil, status, code, message, detail, meta)
+}
+
+// respondErrorFor es RespondError con el request conocido (Render, Recover);
+// el modo sale del Responder en el contexto del request
+func respondErrorFor(w http.ResponseWriter, r *http.Request, status int, code ErrorCode, message, detail string, meta map[string]interface{}) {
+	r = requestFor(w, r)
+	if rs := responderFrom(r); rs != nil {
+		rs.RespondError(w, r, status, code, message, detail, meta)
+		return
+	}
+	respondLegacyShape(w, status, code, message, detail, meta)
+}
+
+func respondLegacyShape(w http.ResponseWriter, status int, code ErrorCode, message, detail string, meta map[string]interface{}) {
 	resp := ErrorResponse{
 		Error:  message,
 		Code:   code,
@@ -137,6 +137,37 @@ func TestMetadata(t *testing.T) {
 	}
 }
 
+type fakeDecision map[string]interface{}
+
+func (d fakeDecision) SanitizedMeta() map[string]interface{} { return d }
+
+func TestPermissionDeniedWithDecision(t *testing.T) {
+	rr := httptest.NewRecorder()
+
+	decision := fakeDecision{"reason": "missing_permissions", "missing_permissions": []string{"TEAM__SUSPEND"}}
+	errors.RespondPermissionDeniedWithDecision(rr, 123, "TEAM", "TEAM__SUSPEND", true, decision)
+
+	if rr.Code != http.StatusForbidden {
+		t.Fatalf("expected status 403, got %d", rr.Code)
+	}
+
+	var response errors.ErrorResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+		t.Fatalf(errParseResponse, err)
+	}
+
+	explained, ok := response.Meta["decision"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("expected decision metadata, got %v", response.Meta["decision"])
+	}
+	if explained["reason"] != "missing_permissions" {
+		t.Errorf("expected reason missing_permissions, got %v", explained["reason"])
+	}
+	if response.Meta["scope_type"] != "TEAM" {
+		t.Errorf("expected legacy metadata to be kept, got %v", response.Meta)
+	}
+}
+
 func TestDetailedError(t *testing.T) {
 	rr := httptest.NewRecorder()
 
@@ -4,12 +4,16 @@ go 1.26.0
 toolchain go1.26.0
 require (
 	github.com/go-chi/render v1.0.3
+	github.com/jackc/pgx/v5 v5.8.0
 	github.com/rs/zerolog v1.34.0
 )
 
 require (
 	github.com/ajg/form v1.6.1 // indirect
+	github.com/jackc/pgpassfile v1.0.0 // indirect
+	github.com/jackc/pgservicefile v0.0.0-20240606120523-5a60cdf6a761 // indirect
 	github.com/mattn/go-colorable v0.1.14 // indirect
 	github.com/mattn/go-isatty v0.0.20 // indirect
 	golang.org/x/sys v0.40.0 // indirect
+	golang.org/x/text v0.29.0 // indirect
 )
@@ -1,8 +1,9 @@
 package errors
 
 import (
-	"fmt"
 	"net/http"
+
+	"github.com/rs/zerolog/log"
 )
 
 // Error message constants
@@ -16,8 +17,7 @@ const (
 
 // RespondUnauthorized responde con error 401 genérico
 func RespondUnauthorized(w http.ResponseWriter, detail string) {
-	RespondWithDetail(w, http.StatusUnauthorized, CodeUnauthorized,
-		"unauthorized", detail)
+	respond(w, Unauthorized(detail))
 }
 
 // RespondUnauthorizedSimple responde con 401 sin detalle adicional
@@ -28,59 +28,43 @@ func RespondUnauthorizedSimple(w http.ResponseWriter) {
 
 // RespondTokenExpired responde cuando el JWT ha expirado
 func RespondTokenExpired(w http.ResponseWriter) {
-	RespondError(w, http.StatusUnauthorized, CodeTokenExpired,
-		"unauthorized",
-		"JWT token has expired",
-		map[string]interface{}{
-			"should_refresh": true,
-		})
+	respond(w, TokenExpired())
 }
 
 // RespondTokenInvalid responde cuando el JWT es inválido
 func RespondTokenInvalid(w http.ResponseWriter, reason string) {
-	RespondError(w, http.StatusUnauthorized, CodeTokenInvalid,
-		"unauthorized",
-		fmt.Sprintf("JWT token is invalid: %s", reason),
-		map[string]interface{}{
-			"should_reauth": true,
-		})
+	respond(w, TokenInvalid(reason))
 }
 
 // RespondPolicyVersionMismatch responde cuando hay mismatch de policy version
 func RespondPolicyVersionMismatch(w http.ResponseWriter, tokenVersion, currentVersion int) {
-	RespondError(w, http.StatusUnauthorized, CodePolicyVersionMismatch,
-		"policy version mismatch",
-		"Token policy version does not match current version",
-		map[string]interface{}{
-			"token_version":   tokenVersion,
-			"current_version": currentVersion,
-			"should_reauth":   true,
-		})
+	respond(w, PolicyVersionMismatch(tokenVersion, currentVersion))
 }
 
 // RespondPermissionDenied responde cuando falta un permiso específico
 func RespondPermissionDenied(w http.ResponseWriter, scopeID int64, scopeType, permission string, hasMembership bool) {
-	detail := fmt.Sprintf("User lacks %s permission in %s scope %d", permission, scopeType, scopeID)
-	if !hasMembership {
-		detail = fmt.Sprintf("User does not have membership in %s scope %d", scopeType, scopeID)
-	}
+	respond(w, PermissionDenied(scopeID, scopeType, permission, hasMembership))
+}
 
-	RespondError(w, http.StatusForbidden, CodePermissionDenied,
-		"insufficient permissions",
-		detail,
-		map[string]interface{}{
-			"scope_id":            scopeID,
-			"scope_type":          scopeType,
-			"required_permission": permission,
-			"has_membership":      hasMembership,
-		})
+// AuthorizationDecision es la explicación de un chequeo de permisos (ej: authz.Decision).
+// SanitizedMeta solo debe devolver datos seguros para el cliente.
+type AuthorizationDecision interface {
+	SanitizedMeta() map[string]interface{}
+}
+
+// RespondPermissionDeniedWithDecision responde como RespondPermissionDenied e incluye
+// en meta["decision"] la explicación sanitizada de la decisión
+func RespondPermissionDeniedWithDecision(w http.ResponseWriter, scopeID int64, scopeType, permission string, hasMembership bool, decision AuthorizationDecision) {
+	err := PermissionDenied(scopeID, scopeType, permission, hasMembership)
+	if decision != nil {
+		err = err.WithMeta("decision", decision.SanitizedMeta())
+	}
+	respond(w, err)
 }
 
 // RespondInsufficientPermissions responde con permisos insuficientes genérico
 func RespondInsufficientPermissions(w http.ResponseWriter, action string) {
-	RespondWithDetail(w, http.StatusForbidden, CodeInsufficientPermissions,
-		"insufficient permissions",
-		fmt.Sprintf("You don't have permission to %s", action))
+	respond(w, InsufficientPermissions(action))
 }
 
 // ============================================
@@ -89,25 +73,12 @@ func RespondInsufficientPermissions(w http.ResponseWriter, action string) {
 
 // RespondNotFound responde cuando un recurso no existe
 func RespondNotFound(w http.ResponseWriter, resourceType, identifier string) {
-	RespondError(w, http.StatusNotFound, CodeNotFound,
-		"not found",
-		fmt.Sprintf("%s '%s' not found", resourceType, identifier),
-		map[string]interface{}{
-			"resource_type": resourceType,
-			"identifier":    identifier,
-		})
+	respond(w, NotFound(resourceType, identifier))
 }
 
 // RespondMembershipNotFound responde cuando no existe membership
 func RespondMembershipNotFound(w http.ResponseWriter, scopeID int64, scopeType string, userID int64) {
-	RespondError(w, http.StatusNotFound, CodeMembershipNotFound,
-		"membership not found",
-		fmt.Sprintf("User %d does not have membership in %s scope %d", userID, scopeType, scopeID),
-		map[string]interface{}{
-			"scope_id":   scopeID,
-			"scope_type": scopeType,
-			"user_id":    userID,
-		})
+	respond(w, MembershipNotFound(scopeID, scopeType, userID))
 }
 
 // RespondNotFoundWithDetail responde con not found con solo un mensaje de detalle
@@ -118,19 +89,12 @@ func RespondNotFoundWithDetail(w http.ResponseWriter, detail string) {
 
 // RespondAlreadyExists responde cuando un recurso ya existe
 func RespondAlreadyExists(w http.ResponseWriter, resourceType, identifier string) {
-	RespondError(w, http.StatusConflict, CodeAlreadyExists,
-		"resource already exists",
-		fmt.Sprintf("%s '%s' already exists", resourceType, identifier),
-		map[string]interface{}{
-			"resource_type": resourceType,
-			"identifier":    identifier,
-		})
+	respond(w, AlreadyExists(resourceType, identifier))
 }
 
 // RespondConflict responde con conflicto de estado
 func RespondConflict(w http.ResponseWriter, reason string) {
-	RespondWithDetail(w, http.StatusConflict, CodeConflict,
-		"conflict", reason)
+	respond(w, Conflict(reason))
 }
 
 // ============================================
@@ -139,23 +103,12 @@ func RespondConflict(w http.ResponseWriter, reason string) {
 
 // RespondValidationError responde con error de validación
 func RespondValidationError(w http.ResponseWriter, field, reason string) {
-	RespondError(w, http.StatusBadRequest, CodeValidationError,
-		MsgValidationFailed,
-		fmt.Sprintf("Field '%s': %s", field, reason),
-		map[string]interface{}{
-			"field":  field,
-			"reason": reason,
-		})
+	respond(w, Validation(field, reason))
 }
 
 // RespondValidationErrors responde con múltiples errores de validación
 func RespondValidationErrors(w http.ResponseWriter, errors map[string]string) {
-	RespondError(w, http.StatusBadRequest, CodeValidationError,
-		MsgValidationFailed,
-		"Multiple validation errors occurred",
-		map[string]interface{}{
-			"errors": errors,
-		})
+	respond(w, ValidationErrors(errors))
 }
 
 // RespondValidationErrorWithDetail responde con error de validación con solo un mensaje de detalle
@@ -166,42 +119,22 @@ func RespondValidationErrorWithDetail(w http.ResponseWriter, detail string) {
 
 // RespondBadRequest responde con bad request genérico
 func RespondBadRequest(w http.ResponseWriter, detail string) {
-	RespondWithDetail(w, http.StatusBadRequest, CodeBadRequest,
-		"bad request", detail)
+	respond(w, BadRequest(detail))
 }
 
 // RespondMissingField responde cuando falta un campo requerido
 func RespondMissingField(w http.ResponseWriter, fieldName string) {
-	RespondError(w, http.StatusBadRequest, CodeMissingRequiredField,
-		"missing required field",
-		fmt.Sprintf("Field '%s' is required", fieldName),
-		map[string]interface{}{
-			"field": fieldName,
-		})
+	respond(w, MissingField(fieldName))
 }
 
 // RespondInvalidFormat responde cuando el formato es inválido
 func RespondInvalidFormat(w http.ResponseWriter, field, expectedFormat string) {
-	RespondError(w, http.StatusBadRequest, CodeInvalidFormat,
-		"invalid format",
-		fmt.Sprintf("Field '%s' has invalid format. Expected: %s", field, expectedFormat),
-		map[string]interface{}{
-			"field":           field,
-			"expected_format": expectedFormat,
-		})
+	respond(w, InvalidFormat(field, expectedFormat))
 }
 
 // RespondOutOfRange responde cuando un valor está fuera de rango
 func RespondOutOfRange(w http.ResponseWriter, field string, min, max, provided interface{}) {
-	RespondError(w, http.StatusBadRequest, CodeOutOfRange,
-		"value out of range",
-		fmt.Sprintf("Field '%s' must be between %v and %v, got %v", field, min, max, provided),
-		map[string]interface{}{
-			"field":    field,
-			"min":      min,
-			"max":      max,
-			"provided": provided,
-		})
+	respond(w, OutOfRange(field, min, max, provided))
 }
 
 // ============================================
@@ -220,46 +153,32 @@ func RespondInternalErrorSimple(w http.ResponseWriter) {
 		"internal server error")
 }
 
-// RespondDatabaseError responde con error de base de datos desde un error
+// RespondDatabaseError responde con error de base de datos desde un error. La
+// causa queda solo en el log; para clasificar errores de PostgreSQL usar pgerr.
 func RespondDatabaseError(w http.ResponseWriter, err error) {
-	detail := "Database operation failed"
 	if err != nil {
-		detail = fmt.Sprintf("Database operation failed: %s", err.Error())
+		log.Error().
+			Err(err).
+			Str("code", string(CodeDatabaseError)).
+			Msg("Database operation failed")
 	}
 	RespondError(w, http.StatusInternalServerError, CodeDatabaseError,
-		"database error", detail, nil)
+		"database error", "Database operation failed", nil)
 }
 
 // RespondDatabaseErrorWithOperation responde con error de base de datos con operación específica
 func RespondDatabaseErrorWithOperation(w http.ResponseWriter, operation string) {
-	RespondError(w, http.StatusInternalServerError, CodeDatabaseError,
-		"database error",
-		fmt.Sprintf("Database operation failed: %s", operation),
-		map[string]interface{}{
-			"operation": operation,
-		})
+	respond(w, DatabaseError(operation, nil))
 }
 
 // RespondServiceUnavailable responde cuando el servicio no está disponible
 func RespondServiceUnavailable(w http.ResponseWriter, service string) {
-	RespondError(w, http.StatusServiceUnavailable, CodeServiceUnavailable,
-		"service unavailable",
-		fmt.Sprintf("Service '%s' is temporarily unavailable", service),
-		map[string]interface{}{
-			"service":     service,
-			"retry_after": 60, // segundos
-		})
+	respond(w, ServiceUnavailable(service))
 }
 
 // RespondTimeout responde cuando una operación excede el timeout
 func RespondTimeout(w http.ResponseWriter, operation string, timeoutSeconds int) {
-	RespondError(w, http.StatusGatewayTimeout, CodeTimeout,
-		"operation timeout",
-		fmt.Sprintf("Operation '%s' exceeded timeout of %d seconds", operation, timeoutSeconds),
-		map[string]interface{}{
-			"operation":       operation,
-			"timeout_seconds": timeoutSeconds,
-		})
+	respond(w, Timeout(operation, timeoutSeconds))
 }
 
 // ============================================
@@ -268,35 +187,23 @@ func RespondTimeout(w http.ResponseWriter, operation string, timeoutSeconds int)
 
 // RespondOperationNotAllowed responde cuando una operación no está permitida
 func RespondOperationNotAllowed(w http.ResponseWriter, operation, reason string) {
-	RespondError(w, http.StatusForbidden, CodeOperationNotAllowed,
-		"operation not allowed",
-		fmt.Sprintf("Operation '%s' is not allowed: %s", operation, reason),
-		map[string]interface{}{
-			"operation": operation,
-			"reason":    reason,
-		})
+	respond(w, OperationNotAllowed(operation, reason))
 }
 
 // RespondQuotaExceeded responde cuando se excede una cuota
 func RespondQuotaExceeded(w http.ResponseWriter, quotaType string, limit, current int) {
-	RespondError(w, http.StatusTooManyRequests, CodeQuotaExceeded,
-		"quota exceeded",
-		fmt.Sprintf("Quota for '%s' exceeded. Limit: %d, Current: %d", quotaType, limit, current),
-		map[string]interface{}{
-			"quota_type": quotaType,
-			"limit":      limit,
-			"current":    current,
-		})
+	respond(w, QuotaExceeded(quotaType, limit, current))
 }
 
 // RespondRateLimitExceeded responde cuando se excede el rate limit
 func RespondRateLimitExceeded(w http.ResponseWriter, limit int, window string, retryAfter int) {
-	RespondError(w, http.StatusTooManyRequests, CodeRateLimitExceeded,
-		"rate limit exceeded",
-		fmt.Sprintf("Rate limit of %d requests per %s exceeded", limit, window),
-		map[string]interface{}{
-			"limit":       limit,
-			"window":      window,
-			"retry_after": retryAfter,
-		})
+	respond(w, RateLimitExceeded(limit, window, retryAfter))
+}
+
+// respond escribe un *Error con su status (sin pasar por el log de Render).
+// El idioma y el modo salen del request transportado por la cadena de writers.
+func respond(w http.ResponseWriter, err *Error) {
+	r := requestFor(w, nil)
+	err = localizeFor(w, r, err)
+	respondErrorFor(w, r, err.HTTPStatus(), err.Code, err.Message, err.Detail, err.Meta)
 }
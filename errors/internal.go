@@ -5,7 +5,6 @@ import (
 	"strings"
 
 	"github.com/go-chi/render"
-	"github.com/rs/zerolog/log"
 )
 
 // InternalErrorCode códigos estándar para servicios internos
@@ -37,11 +36,23 @@ const (
 
 // InternalErrorResponse estructura estándar para respuestas entre servicios
 type InternalErrorResponse struct {
-	Code    InternalErrorCode `json:"code"`
-	Message string            `json:"message"`
-	Service string            `json:"service"`
-	Details interface{}       `json:"details,omitempty"`
-	Status  int               `json:"status"`
+	// Code es el código de error interno programático
+	Code InternalErrorCode `json:"code"`
+
+	// Message es el mensaje del error
+	Message string `json:"message"`
+
+	// Service es el servicio que produjo el error
+	Service string `json:"service"`
+
+	// Details contiene información adicional del error
+	Details interface{} `json:"details,omitempty"`
+
+	// Status es el código HTTP
+	Status int `json:"status"`
+
+	// ErrorID identifica el error también en la línea de log del productor
+	ErrorID string `json:"error_id,omitempty"`
 }
 
 // detectServiceName intenta detectar el nombre del servicio desde un request
@@ -78,22 +89,27 @@ func detectServiceName(r *http.Request) string {
 	return "connect-service"
 }
 
-// RespondInternalServiceError respuesta estándar para errores internos entre servicios
+// RespondInternalServiceError respuesta estándar para errores internos entre servicios.
+// Los details se redactan según InternalRedaction(); el log los registra completos
+// junto al error_id que también se envía en el body y en el header X-Error-ID.
 func RespondInternalServiceError(w http.ResponseWriter, r *http.Request, code InternalErrorCode, message string, details interface{}) {
 	service := detectServiceName(r)
 
 	statusCode := getInternalStatusCode(code)
+	errorID := newErrorID()
 
 	response := InternalErrorResponse{
 		Code:    code,
 		Message: message,
 		Service: service,
-		Details: details,
+		Details: redactDetails(InternalRedaction(), details),
 		Status:  statusCode,
+		ErrorID: errorID,
 	}
 
-	// Log estructurado para debugging interno
-	log.Error().
+	// Log estructurado para debugging interno (warn en 4xx, error en 5xx)
+	logEventForStatus(statusCode).
+		Str("error_id", errorID).
 		Str("internal_code", string(code)).
 		Str("service", service).
 		Str("path", r.URL.Path).
@@ -102,6 +118,9 @@ func RespondInternalServiceError(w http.ResponseWriter, r *http.Request, code In
 		Interface("details", details).
 		Msg("Internal service error")
 
+	if errorID != "" {
+		w.Header().Set(HeaderErrorID, errorID)
+	}
 	render.Status(r, statusCode)
 	render.JSON(w, r, response)
 }
@@ -153,7 +172,8 @@ func RespondInternalBadRequest(w http.ResponseWriter, r *http.Request, reason st
 	RespondInternalServiceError(w, r, InternalBadRequest, "bad request", details)
 }
 
-// RespondInternalDatabase (500) - Error de base de datos
+// RespondInternalDatabase (500) - Error de base de datos. El mensaje de err
+// solo llega al servicio que llamó con RedactNone; siempre queda en el log.
 func RespondInternalDatabase(w http.ResponseWriter, r *http.Request, operation string, err error) {
 	details := map[string]interface{}{
 		"operation": operation,
@@ -0,0 +1,254 @@
+package errors
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Language es un código de idioma ISO 639-1 ("es", "en")
+type Language string
+
+const (
+	// LangEN es el idioma por defecto (mensajes históricos en inglés)
+	LangEN Language = "en"
+
+	// LangES español
+	LangES Language = "es"
+)
+
+// DefaultLanguage se usa cuando el cliente no pide un idioma soportado
+const DefaultLanguage = LangEN
+
+// Message es la plantilla de mensaje y detalle de un error. Los parámetros se
+// escriben como {nombre} y se reemplazan con Error.Params.
+type Message struct {
+	Message string
+	Detail  string
+}
+
+// Claves de mensajes que comparten ErrorCode con otra variante
+const (
+	KeyPermissionDeniedNoMembership = "PERMISSION_DENIED.no_membership"
+	KeyValidationErrors             = "VALIDATION_ERROR.multiple"
+)
+
+var (
+	messagesMu sync.RWMutex
+	messages   = map[Language]map[string]Message{
+		LangEN: {
+			string(CodeUnauthorized):            {Message: "unauthorized", Detail: "{detail}"},
+			string(CodeTokenExpired):            {Message: "unauthorized", Detail: "JWT token has expired"},
+			string(CodeTokenInvalid):            {Message: "unauthorized", Detail: "JWT token is invalid: {reason}"},
+			string(CodePolicyVersionMismatch):   {Message: "policy version mismatch", Detail: "Token policy version does not match current version"},
+			string(CodePermissionDenied):        {Message: "insufficient permissions", Detail: "User lacks {permission} permission in {scope_type} scope {scope_id}"},
+			KeyPermissionDeniedNoMembership:     {Message: "insufficient permissions", Detail: "User does not have membership in {scope_type} scope {scope_id}"},
+			string(CodeInsufficientPermissions): {Message: "insufficient permissions", Detail: "You don't have permission to {action}"},
+			string(CodeNotFound):                {Message: "not found", Detail: "{resource_type} '{identifier}' not found"},
+			string(CodeMembershipNotFound):      {Message: "membership not found", Detail: "User {user_id} does not have membership in {scope_type} scope {scope_id}"},
+			string(CodeAlreadyExists):           {Message: "resource already exists", Detail: "{resource_type} '{identifier}' already exists"},
+			string(CodeConflict):                {Message: "conflict", Detail: "{reason}"},
+			string(CodeGone):                    {Message: "resource is no longer available"},
+			string(CodeResourceLocked):          {Message: "resource locked"},
+			string(CodeValidationError):         {Message: MsgValidationFailed, Detail: "Field '{field}': {reason}"},
+			KeyValidationErrors:                 {Message: MsgValidationFailed, Detail: "Multiple validation errors occurred"},
+			string(CodeInvalidRequest):          {Message: "invalid request"},
+			string(CodeBadRequest):              {Message: "bad request", Detail: "{detail}"},
+			KeyBodyEmpty:                        {Message: "bad request", Detail: "Request body is empty"},
+			KeyBodyMalformed:                    {Message: "bad request", Detail: "Malformed JSON at offset {offset}"},
+			KeyBodyTooLarge:                     {Message: "request body too large", Detail: "Request body exceeds {limit} bytes"},
+			KeyBodyUnknownField:                 {Message: "bad request", Detail: "Unknown field '{field}'"},
+			KeyBodyTrailingData:                 {Message: "bad request", Detail: "Request body must contain a single JSON value"},
+			string(CodeMissingRequiredField):    {Message: "missing required field", Detail: "Field '{field}' is required"},
+			string(CodeInvalidFormat):           {Message: "invalid format", Detail: "Field '{field}' has invalid format. Expected: {expected_format}"},
+			string(CodeOutOfRange):              {Message: "value out of range", Detail: "Field '{field}' must be between {min} and {max}, got {provided}"},
+			string(CodeInternalError):           {Message: "internal server error"},
+			string(CodeDatabaseError):           {Message: "database error", Detail: "Database operation failed: {operation}"},
+			string(CodeServiceUnavailable):      {Message: "service unavailable", Detail: "Service '{service}' is temporarily unavailable"},
+			string(CodeTimeout):                 {Message: "operation timeout", Detail: "Operation '{operation}' exceeded timeout of {timeout_seconds} seconds"},
+			string(CodeOperationNotAllowed):     {Message: "operation not allowed", Detail: "Operation '{operation}' is not allowed: {reason}"},
+			string(CodeQuotaExceeded):           {Message: "quota exceeded", Detail: "Quota for '{quota_type}' exceeded. Limit: {limit}, Current: {current}"},
+			string(CodeRateLimitExceeded):       {Message: "rate limit exceeded", Detail: "Rate limit of {limit} requests per {window} exceeded"},
+		},
+		LangES: {
+			string(CodeUnauthorized):            {Message: "no autorizado", Detail: "{detail}"},
+			string(CodeTokenExpired):            {Message: "no autorizado", Detail: "El token JWT ha expirado"},
+			string(CodeTokenInvalid):            {Message: "no autorizado", Detail: "El token JWT no es válido: {reason}"},
+			string(CodePolicyVersionMismatch):   {Message: "versión de política no coincide", Detail: "La versión de política del token no coincide con la versión actual"},
+			string(CodePermissionDenied):        {Message: "permisos insuficientes", Detail: "El usuario no tiene el permiso {permission} en el scope {scope_type} {scope_id}"},
+			KeyPermissionDeniedNoMembership:     {Message: "permisos insuficientes", Detail: "El usuario no es miembro del scope {scope_type} {scope_id}"},
+			string(CodeInsufficientPermissions): {Message: "permisos insuficientes", Detail: "No tienes permiso para {action}"},
+			string(CodeNotFound):                {Message: "no encontrado", Detail: "{resource_type} '{identifier}' no encontrado"},
+			string(CodeMembershipNotFound):      {Message: "membresía no encontrada", Detail: "El usuario {user_id} no es miembro del scope {scope_type} {scope_id}"},
+			string(CodeAlreadyExists):           {Message: "el recurso ya existe", Detail: "{resource_type} '{identifier}' ya existe"},
+			string(CodeConflict):                {Message: "conflicto", Detail: "{reason}"},
+			string(CodeGone):                    {Message: "el recurso ya no está disponible"},
+			string(CodeResourceLocked):          {Message: "recurso bloqueado"},
+			string(CodeValidationError):         {Message: "la validación falló", Detail: "Campo '{field}': {reason}"},
+			KeyValidationErrors:                 {Message: "la validación falló", Detail: "Se produjeron varios errores de validación"},
+			string(CodeInvalidRequest):          {Message: "petición inválida"},
+			string(CodeBadRequest):              {Message: "petición inválida", Detail: "{detail}"},
+			KeyBodyEmpty:                        {Message: "petición inválida", Detail: "El body de la petición está vacío"},
+			KeyBodyMalformed:                    {Message: "petición inválida", Detail: "JSON malformado en el offset {offset}"},
+			KeyBodyTooLarge:                     {Message: "body demasiado grande", Detail: "El body de la petición supera {limit} bytes"},
+			KeyBodyUnknownField:                 {Message: "petición inválida", Detail: "Campo desconocido '{field}'"},
+			KeyBodyTrailingData:                 {Message: "petición inválida", Detail: "El body debe contener un único valor JSON"},
+			string(CodeMissingRequiredField):    {Message: "falta un campo obligatorio", Detail: "El campo '{field}' es obligatorio"},
+			string(CodeInvalidFormat):           {Message: "formato inválido", Detail: "El campo '{field}' tiene un formato inválido. Esperado: {expected_format}"},
+			string(CodeOutOfRange):              {Message: "valor fuera de rango", Detail: "El campo '{field}' debe estar entre {min} y {max}, recibido {provided}"},
+			string(CodeInternalError):           {Message: "error interno del servidor"},
+			string(CodeDatabaseError):           {Message: "error de base de datos", Detail: "Falló la operación de base de datos: {operation}"},
+			string(CodeServiceUnavailable):      {Message: "servicio no disponible", Detail: "El servicio '{service}' no está disponible temporalmente"},
+			string(CodeTimeout):                 {Message: "tiempo de espera agotado", Detail: "La operación '{operation}' superó el tiempo límite de {timeout_seconds} segundos"},
+			string(CodeOperationNotAllowed):     {Message: "operación no permitida", Detail: "La operación '{operation}' no está permitida: {reason}"},
+			string(CodeQuotaExceeded):           {Message: "cuota excedida", Detail: "Cuota de '{quota_type}' excedida. Límite: {limit}, actual: {current}"},
+			string(CodeRateLimitExceeded):       {Message: "límite de peticiones excedido", Detail: "Se superó el límite de {limit} peticiones por {window}"},
+		},
+	}
+)
+
+// RegisterMessages agrega o reemplaza plantillas de un idioma. La clave es el
+// ErrorCode o una variante ("CODE.variante") usada como Error.Key.
+func RegisterMessages(lang Language, entries map[string]Message) {
+	messagesMu.Lock()
+	defer messagesMu.Unlock()
+	if messages[lang] == nil {
+		messages[lang] = make(map[string]Message, len(entries))
+	}
+	for key, message := range entries {
+		messages[lang][key] = message
+	}
+}
+
+// SupportedLanguages retorna los idiomas con plantillas registradas
+func SupportedLanguages() []Language {
+	messagesMu.RLock()
+	defer messagesMu.RUnlock()
+	result := make([]Language, 0, len(messages))
+	for lang := range messages {
+		result = append(result, lang)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i] < result[j] })
+	return result
+}
+
+func hasLanguage(lang Language) bool {
+	messagesMu.RLock()
+	defer messagesMu.RUnlock()
+	_, ok := messages[lang]
+	return ok
+}
+
+// LookupMessage retorna la plantilla de key (ErrorCode o variante) en lang.
+// cmd/errorsgen la usa para los mensajes de types.ts.
+func LookupMessage(lang Language, key string) (Message, bool) {
+	messagesMu.RLock()
+	defer messagesMu.RUnlock()
+	message, ok := messages[lang][key]
+	return message, ok
+}
+
+// Localize retorna una copia con Message y Detail en el idioma indicado. El
+// Code no se traduce. Los errores sin Key (creados con New o Wrap) o sin
+// plantilla para ese idioma se retornan sin cambios.
+func (e *Error) Localize(lang Language) *Error {
+	if e.Key == "" {
+		return e
+	}
+	template, ok := LookupMessage(lang, e.Key)
+	if !ok {
+		return e
+	}
+
+	clone := *e
+	clone.Message = expand(template.Message, e.Params)
+	clone.Detail = expand(template.Detail, e.Params)
+	return &clone
+}
+
+// NegotiateLanguage elige el idioma soportado con mayor q del header
+// Accept-Language ("es-AR,es;q=0.9,en;q=0.8" → es) o DefaultLanguage
+func NegotiateLanguage(r *http.Request) Language {
+	if r == nil {
+		return DefaultLanguage
+	}
+
+	best, bestQ := DefaultLanguage, 0.0
+	for _, header := range r.Header.Values("Accept-Language") {
+		for _, part := range strings.Split(header, ",") {
+			tag, q := parseLanguageRange(part)
+			if tag == "" || q <= bestQ {
+				continue
+			}
+			lang := Language(strings.ToLower(strings.SplitN(tag, "-", 2)[0]))
+			if hasLanguage(lang) {
+				best, bestQ = lang, q
+			}
+		}
+	}
+	return best
+}
+
+// HeaderContentLanguage es el header con el idioma de la respuesta
+const HeaderContentLanguage = "Content-Language"
+
+// localizeFor traduce e al idioma negociado con r y lo anuncia en
+// Content-Language. Los errores sin plantilla se dejan sin cambios.
+func localizeFor(w http.ResponseWriter, r *http.Request, e *Error) *Error {
+	if r == nil {
+		return e
+	}
+	lang := NegotiateLanguage(r)
+	localized := e.Localize(lang)
+	if localized != e {
+		w.Header().Set(HeaderContentLanguage, string(lang))
+	}
+	return localized
+}
+
+func parseLanguageRange(part string) (string, float64) {
+	fields := strings.Split(part, ";")
+	tag := strings.TrimSpace(fields[0])
+	if tag == "" || tag == "*" {
+		return "", 0
+	}
+	q := 1.0
+	for _, param := range fields[1:] {
+		name, value, ok := strings.Cut(strings.TrimSpace(param), "=")
+		if !ok || name != "q" {
+			continue
+		}
+		parsed, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return "", 0
+		}
+		q = parsed
+	}
+	return tag, q
+}
+
+// newError construye un Error con el mensaje en inglés de la plantilla key
+func newError(code ErrorCode, key string, params map[string]interface{}) *Error {
+	if key == "" {
+		key = string(code)
+	}
+	e := &Error{Code: code, Key: key, Params: params}
+	if localized := e.Localize(LangEN); localized != e {
+		return localized
+	}
+	return e
+}
+
+func expand(template string, params map[string]interface{}) string {
+	if template == "" || len(params) == 0 {
+		return template
+	}
+	pairs := make([]string, 0, len(params)*2)
+	for name, value := range params {
+		pairs = append(pairs, "{"+name+"}", fmt.Sprint(value))
+	}
+	return strings.NewReplacer(pairs...).Replace(template)
+}
@@ -0,0 +1,131 @@
+package errors_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	errors "github.com/AoC-Gamers/connect-libraries/errors"
+)
+
+func TestNegotiateLanguage(t *testing.T) {
+	cases := []struct {
+		header string
+		want   errors.Language
+	}{
+		{"", errors.LangEN},
+		{"es", errors.LangES},
+		{"es-AR,es;q=0.9,en;q=0.8", errors.LangES},
+		{"en;q=0.5, es;q=0.7", errors.LangES},
+		{"fr-FR, es;q=0.3", errors.LangES},
+		{"fr, de;q=0.9", errors.LangEN},
+		{"es;q=0, en", errors.LangEN},
+		{"*", errors.LangEN},
+	}
+
+	for _, tc := range cases {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		if tc.header != "" {
+			r.Header.Set("Accept-Language", tc.header)
+		}
+		if got := errors.NegotiateLanguage(r); got != tc.want {
+			t.Errorf("NegotiateLanguage(%q) = %q, want %q", tc.header, got, tc.want)
+		}
+	}
+	if errors.NegotiateLanguage(nil) != errors.DefaultLanguage {
+		t.Fatalf("expected default language for nil request")
+	}
+}
+
+func TestRenderLocalizesMessage(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/teams/12", nil)
+	r.Header.Set("Accept-Language", "es-AR,es;q=0.9")
+	rr := httptest.NewRecorder()
+
+	errors.Render(rr, r, errors.NotFound("team", "12"))
+
+	var resp errors.ErrorResponse
+	if err := json.NewDecoder(rr.Body).Decode(&resp); err != nil {
+		t.Fatalf(errParseResponse, err)
+	}
+	if resp.Code != errors.CodeNotFound {
+		t.Fatalf("expected untranslated code, got %q", resp.Code)
+	}
+	if resp.Error != "no encontrado" || resp.Detail != "team '12' no encontrado" {
+		t.Fatalf("unexpected localized body: %+v", resp)
+	}
+	if rr.Header().Get(errors.HeaderContentLanguage) != "es" {
+		t.Fatalf("expected Content-Language es, got %q", rr.Header().Get(errors.HeaderContentLanguage))
+	}
+}
+
+func TestHelpersLocalizeInsideMiddleware(t *testing.T) {
+	handler := errors.NewResponder(errors.ModeLegacy).Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errors.RespondPermissionDenied(w, 7, "team", "TEAM__EDIT", false)
+	}))
+
+	r := httptest.NewRequest(http.MethodPost, "/teams/7", nil)
+	r.Header.Set("Accept-Language", "es")
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, r)
+
+	var resp errors.ErrorResponse
+	if err := json.NewDecoder(rr.Body).Decode(&resp); err != nil {
+		t.Fatalf(errParseResponse, err)
+	}
+	if resp.Detail != "El usuario no es miembro del scope team 7" {
+		t.Fatalf("unexpected detail %q", resp.Detail)
+	}
+}
+
+func TestHelpersLocalizeBehindWrappedWriter(t *testing.T) {
+	inner := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errors.RespondPermissionDenied(w, 7, "team", "TEAM__EDIT", false)
+	})
+	handler := errors.NewResponder(errors.ModeLegacy).Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		inner.ServeHTTP(wrappedWriter{w}, r)
+	}))
+
+	r := httptest.NewRequest(http.MethodPost, "/teams/7", nil)
+	r.Header.Set("Accept-Language", "es")
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, r)
+
+	var resp errors.ErrorResponse
+	if err := json.NewDecoder(rr.Body).Decode(&resp); err != nil {
+		t.Fatalf(errParseResponse, err)
+	}
+	if resp.Detail != "El usuario no es miembro del scope team 7" || rr.Header().Get(errors.HeaderContentLanguage) != "es" {
+		t.Fatalf("unexpected localized response %q (%q)", resp.Detail, rr.Header().Get(errors.HeaderContentLanguage))
+	}
+}
+
+func TestLocalizeKeepsCustomText(t *testing.T) {
+	custom := errors.New(errors.CodeNotFound, "team missing")
+	if custom.Localize(errors.LangES) != custom {
+		t.Fatalf("expected errors without template to stay unchanged")
+	}
+
+	detailed := errors.Conflict("busy").WithDetail("lobby is full")
+	if got := detailed.Localize(errors.LangES); got.Detail != "lobby is full" {
+		t.Fatalf("expected custom detail to survive, got %q", got.Detail)
+	}
+}
+
+func TestRegisterMessages(t *testing.T) {
+	errors.RegisterMessages("pt", map[string]errors.Message{
+		string(errors.CodeConflict): {Message: "conflito", Detail: "{reason}"},
+	})
+
+	got := errors.Conflict("ocupado").Localize("pt")
+	if got.Message != "conflito" || got.Detail != "ocupado" || got.Code != errors.CodeConflict {
+		t.Fatalf("unexpected registered translation: %+v", got)
+	}
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("Accept-Language", "pt-BR")
+	if errors.NegotiateLanguage(r) != "pt" {
+		t.Fatalf("expected registered language to be negotiable")
+	}
+}
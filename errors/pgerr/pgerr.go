@@ -0,0 +1,200 @@
+// Package pgerr clasifica errores de PostgreSQL (pgx) en errores estándar de
+// connect-errors sin exponer SQL, nombres de tablas ni mensajes del servidor.
+//
+//	user, err := repo.FindUser(ctx, id)
+//	if err != nil {
+//		pgerr.Respond(w, r, "find user", err)
+//		return
+//	}
+package pgerr
+
+import (
+	"context"
+	"database/sql"
+	stderrors "errors"
+	"net/http"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/rs/zerolog/log"
+
+	errors "github.com/AoC-Gamers/connect-libraries/errors"
+)
+
+// Códigos SQLSTATE clasificados
+const (
+	UniqueViolation      = "23505"
+	ForeignKeyViolation  = "23503"
+	CheckViolation       = "23514"
+	NotNullViolation     = "23502"
+	SerializationFailure = "40001"
+	DeadlockDetected     = "40P01"
+	QueryCanceled        = "57014"
+)
+
+// Claves de plantilla registradas en el catálogo de mensajes de errors
+const (
+	KeyUniqueViolation     = "ALREADY_EXISTS.unique_violation"
+	KeyForeignKeyViolation = "CONFLICT.foreign_key_violation"
+	KeyCheckViolation      = "VALIDATION_ERROR.check_violation"
+	KeyNotNullViolation    = "MISSING_REQUIRED_FIELD.not_null_violation"
+	KeyRetryable           = "CONFLICT.serialization_failure"
+	KeyQueryCanceled       = "TIMEOUT.query_canceled"
+	KeyNoRows              = "NOT_FOUND.no_rows"
+	KeyDatabase            = "DATABASE_ERROR.unclassified"
+)
+
+func init() {
+	errors.RegisterMessages(errors.LangEN, map[string]errors.Message{
+		KeyUniqueViolation:     {Message: "resource already exists", Detail: "A resource with the same {field} already exists"},
+		KeyForeignKeyViolation: {Message: "conflict", Detail: "The operation references a resource that does not exist or is still in use"},
+		KeyCheckViolation:      {Message: errors.MsgValidationFailed, Detail: "A value does not satisfy the constraint {constraint}"},
+		KeyNotNullViolation:    {Message: "missing required field", Detail: "Field '{field}' is required"},
+		KeyRetryable:           {Message: "conflict", Detail: "The operation conflicted with a concurrent update, retry the request"},
+		KeyQueryCanceled:       {Message: "operation timeout", Detail: "Operation '{operation}' was canceled"},
+		KeyNoRows:              {Message: "not found", Detail: "The requested resource was not found"},
+		KeyDatabase:            {Message: "database error", Detail: "Database operation failed: {operation}"},
+	})
+	errors.RegisterMessages(errors.LangES, map[string]errors.Message{
+		KeyUniqueViolation:     {Message: "el recurso ya existe", Detail: "Ya existe un recurso con el mismo {field}"},
+		KeyForeignKeyViolation: {Message: "conflicto", Detail: "La operación referencia un recurso inexistente o que sigue en uso"},
+		KeyCheckViolation:      {Message: "la validación falló", Detail: "Un valor no cumple la restricción {constraint}"},
+		KeyNotNullViolation:    {Message: "falta un campo obligatorio", Detail: "El campo '{field}' es obligatorio"},
+		KeyRetryable:           {Message: "conflicto", Detail: "La operación chocó con una actualización concurrente, reintenta la petición"},
+		KeyQueryCanceled:       {Message: "tiempo de espera agotado", Detail: "La operación '{operation}' fue cancelada"},
+		KeyNoRows:              {Message: "no encontrado", Detail: "El recurso solicitado no existe"},
+		KeyDatabase:            {Message: "error de base de datos", Detail: "Falló la operación de base de datos: {operation}"},
+	})
+}
+
+// Classify convierte un error de base de datos en un *errors.Error. La causa
+// original queda en Err (solo para logs). Retorna nil si err es nil.
+//
+//   - sql.ErrNoRows / pgx.ErrNoRows → CodeNotFound
+//   - unique_violation → CodeAlreadyExists (meta: constraint, field)
+//   - foreign_key_violation → CodeConflict (meta: constraint)
+//   - check_violation → CodeValidationError (meta: constraint)
+//   - not_null_violation → CodeMissingRequiredField (meta: field)
+//   - serialization_failure / deadlock_detected → CodeConflict con meta.should_retry
+//   - query_canceled / context.DeadlineExceeded → CodeTimeout
+//   - cualquier otro → CodeDatabaseError
+func Classify(operation string, err error) *errors.Error {
+	if err == nil {
+		return nil
+	}
+
+	var e *errors.Error
+	if stderrors.As(err, &e) {
+		return e
+	}
+
+	if stderrors.Is(err, sql.ErrNoRows) {
+		return build(errors.CodeNotFound, KeyNoRows, nil, nil, err)
+	}
+
+	var pgErr *pgconn.PgError
+	if stderrors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case UniqueViolation:
+			field := pgErr.ColumnName
+			if field == "" {
+				field = pgErr.ConstraintName
+			}
+			return build(errors.CodeAlreadyExists, KeyUniqueViolation,
+				map[string]interface{}{"field": field},
+				map[string]interface{}{"constraint": pgErr.ConstraintName, "field": field}, err)
+		case ForeignKeyViolation:
+			return build(errors.CodeConflict, KeyForeignKeyViolation, nil,
+				map[string]interface{}{"constraint": pgErr.ConstraintName}, err)
+		case CheckViolation:
+			return build(errors.CodeValidationError, KeyCheckViolation,
+				map[string]interface{}{"constraint": pgErr.ConstraintName},
+				map[string]interface{}{"constraint": pgErr.ConstraintName}, err)
+		case NotNullViolation:
+			return build(errors.CodeMissingRequiredField, KeyNotNullViolation,
+				map[string]interface{}{"field": pgErr.ColumnName},
+				map[string]interface{}{"field": pgErr.ColumnName}, err)
+		case SerializationFailure, DeadlockDetected:
+			return build(errors.CodeConflict, KeyRetryable, nil,
+				map[string]interface{}{"should_retry": true}, err)
+		case QueryCanceled:
+			return canceled(operation, err)
+		}
+	}
+
+	if stderrors.Is(err, context.DeadlineExceeded) {
+		return canceled(operation, err)
+	}
+
+	return build(errors.CodeDatabaseError, KeyDatabase,
+		map[string]interface{}{"operation": operation},
+		map[string]interface{}{"operation": operation}, err)
+}
+
+// IsRetryable indica si err es un fallo de serialización o deadlock que
+// puede resolverse reintentando la transacción completa
+func IsRetryable(err error) bool {
+	return SQLState(err) == SerializationFailure || SQLState(err) == DeadlockDetected
+}
+
+// SQLState retorna el código SQLSTATE de err o "" si no es un error de PostgreSQL
+func SQLState(err error) string {
+	var pgErr *pgconn.PgError
+	if stderrors.As(err, &pgErr) {
+		return pgErr.Code
+	}
+	return ""
+}
+
+// Respond clasifica err y lo responde con errors.Render
+func Respond(w http.ResponseWriter, r *http.Request, operation string, err error) {
+	errors.Render(w, r, Classify(operation, err))
+}
+
+// RespondInternal clasifica err y lo responde con InternalErrorResponse. A
+// diferencia de errors.RespondInternalDatabase, los detalles no incluyen el
+// mensaje del error; la causa solo se registra en el log.
+func RespondInternal(w http.ResponseWriter, r *http.Request, operation string, err error) {
+	classified := Classify(operation, err)
+	if classified == nil {
+		return
+	}
+
+	details := map[string]interface{}{"operation": operation}
+	for key, value := range classified.Meta {
+		details[key] = value
+	}
+
+	log.Error().Err(err).
+		Str("operation", operation).
+		Str("sqlstate", SQLState(err)).
+		Str("code", string(classified.Code)).
+		Msg("Database error")
+
+	errors.RespondInternalServiceError(w, r, internalCode(classified.Code), classified.Message, details)
+}
+
+func internalCode(code errors.ErrorCode) errors.InternalErrorCode {
+	switch code {
+	case errors.CodeNotFound:
+		return errors.InternalNotFound
+	case errors.CodeAlreadyExists, errors.CodeConflict:
+		return errors.InternalConflict
+	case errors.CodeValidationError, errors.CodeMissingRequiredField:
+		return errors.InternalValidation
+	case errors.CodeTimeout:
+		return errors.InternalTimeout
+	default:
+		return errors.InternalDatabase
+	}
+}
+
+func canceled(operation string, err error) *errors.Error {
+	return build(errors.CodeTimeout, KeyQueryCanceled,
+		map[string]interface{}{"operation": operation},
+		map[string]interface{}{"operation": operation}, err)
+}
+
+func build(code errors.ErrorCode, key string, params, meta map[string]interface{}, cause error) *errors.Error {
+	e := &errors.Error{Code: code, Key: key, Params: params, Meta: meta, Err: cause}
+	return e.Localize(errors.DefaultLanguage)
+}
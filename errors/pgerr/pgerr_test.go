@@ -0,0 +1,108 @@
+package pgerr_test
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+
+	errors "github.com/AoC-Gamers/connect-libraries/errors"
+	"github.com/AoC-Gamers/connect-libraries/errors/pgerr"
+)
+
+func TestClassify(t *testing.T) {
+	cases := []struct {
+		name string
+		err  error
+		code errors.ErrorCode
+		meta map[string]interface{}
+	}{
+		{"no rows", fmt.Errorf("find team: %w", sql.ErrNoRows), errors.CodeNotFound, nil},
+		{"unique", &pgconn.PgError{Code: pgerr.UniqueViolation, ConstraintName: "teams_name_key", ColumnName: "name"},
+			errors.CodeAlreadyExists, map[string]interface{}{"constraint": "teams_name_key", "field": "name"}},
+		{"foreign key", &pgconn.PgError{Code: pgerr.ForeignKeyViolation, ConstraintName: "members_team_fk"},
+			errors.CodeConflict, map[string]interface{}{"constraint": "members_team_fk"}},
+		{"check", &pgconn.PgError{Code: pgerr.CheckViolation, ConstraintName: "teams_size_check"},
+			errors.CodeValidationError, map[string]interface{}{"constraint": "teams_size_check"}},
+		{"serialization", &pgconn.PgError{Code: pgerr.SerializationFailure},
+			errors.CodeConflict, map[string]interface{}{"should_retry": true}},
+		{"canceled", &pgconn.PgError{Code: pgerr.QueryCanceled}, errors.CodeTimeout, nil},
+		{"deadline", context.DeadlineExceeded, errors.CodeTimeout, nil},
+		{"other", &pgconn.PgError{Code: "XX000", Message: "relation \"teams\" is broken"}, errors.CodeDatabaseError, nil},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := pgerr.Classify("save team", tc.err)
+			if got.Code != tc.code {
+				t.Fatalf("expected %s, got %s", tc.code, got.Code)
+			}
+			for key, want := range tc.meta {
+				if got.Meta[key] != want {
+					t.Fatalf("expected meta %s=%v, got %v", key, want, got.Meta[key])
+				}
+			}
+			if got.Err == nil {
+				t.Fatalf("expected cause to be kept for logs")
+			}
+		})
+	}
+
+	if pgerr.Classify("noop", nil) != nil {
+		t.Fatalf("expected nil for nil error")
+	}
+}
+
+func TestRespondHidesSQLDetails(t *testing.T) {
+	cause := &pgconn.PgError{Code: pgerr.UniqueViolation, Message: "duplicate key value violates unique constraint", Detail: "Key (name)=(aoc) already exists.", ConstraintName: "teams_name_key", ColumnName: "name"}
+	rr := httptest.NewRecorder()
+	pgerr.Respond(rr, httptest.NewRequest(http.MethodPost, "/teams", nil), "create team", cause)
+
+	if rr.Code != http.StatusConflict {
+		t.Fatalf("expected 409, got %d", rr.Code)
+	}
+	body := rr.Body.String()
+	for _, leak := range []string{"duplicate key", "Key (name)", "SQLSTATE"} {
+		if strings.Contains(body, leak) {
+			t.Fatalf("response leaks %q: %s", leak, body)
+		}
+	}
+
+	var resp errors.ErrorResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("could not parse response: %v", err)
+	}
+	if resp.Code != errors.CodeAlreadyExists || resp.Detail != "A resource with the same name already exists" {
+		t.Fatalf("unexpected response: %+v", resp)
+	}
+}
+
+func TestRespondInternalOmitsErrorText(t *testing.T) {
+	rr := httptest.NewRecorder()
+	cause := &pgconn.PgError{Code: "XX000", Message: "could not read block 7 in file base/1/2"}
+	pgerr.RespondInternal(rr, httptest.NewRequest(http.MethodGet, "/core/teams", nil), "list teams", cause)
+
+	var resp errors.InternalErrorResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("could not parse response: %v", err)
+	}
+	if resp.Code != errors.InternalDatabase || strings.Contains(rr.Body.String(), "block 7") {
+		t.Fatalf("unexpected internal response: %s", rr.Body.String())
+	}
+}
+
+func TestIsRetryable(t *testing.T) {
+	wrapped := fmt.Errorf("tx: %w", &pgconn.PgError{Code: pgerr.DeadlockDetected})
+	if !pgerr.IsRetryable(wrapped) || pgerr.IsRetryable(sql.ErrNoRows) {
+		t.Fatalf("unexpected IsRetryable results")
+	}
+	if pgerr.SQLState(wrapped) != pgerr.DeadlockDetected {
+		t.Fatalf("unexpected SQLState %q", pgerr.SQLState(wrapped))
+	}
+}
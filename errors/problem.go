@@ -0,0 +1,311 @@
+package errors
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// MimeProblemJSON es el media type de RFC 9457 (Problem Details for HTTP APIs)
+const MimeProblemJSON = "application/problem+json"
+
+// DefaultProblemTypeBaseURI es el prefijo de los type URI generados desde ErrorCode
+const DefaultProblemTypeBaseURI = "urn:aoc-connect:error:"
+
+// ResponseMode define el formato de las respuestas de error
+type ResponseMode int
+
+const (
+	// ModeLegacy usa ErrorResponse con application/json (comportamiento histórico)
+	ModeLegacy ResponseMode = iota
+
+	// ModeProblem usa ProblemDetails con application/problem+json
+	ModeProblem
+
+	// ModeNegotiate usa ModeProblem cuando el cliente acepta application/problem+json
+	// en el header Accept y ModeLegacy en caso contrario
+	ModeNegotiate
+)
+
+// ProblemDetails representa un error según RFC 9457 (antes RFC 7807).
+// Code y Extensions se serializan como miembros de extensión de primer nivel.
+type ProblemDetails struct {
+	// Type es un URI estable que identifica el tipo de problema (ver ProblemType)
+	Type string `json:"type"`
+
+	// Title es el resumen corto del tipo de problema
+	Title string `json:"title"`
+
+	// Status es el código HTTP
+	Status int `json:"status"`
+
+	// Detail es la explicación de esta ocurrencia
+	Detail string `json:"detail,omitempty"`
+
+	// Instance identifica la ocurrencia (por defecto el path del request)
+	Instance string `json:"instance,omitempty"`
+
+	// Code es el código de error programático (extensión)
+	Code ErrorCode `json:"code,omitempty"`
+
+	// Extensions contiene miembros adicionales (el meta del formato legacy)
+	Extensions map[string]interface{} `json:"-"`
+}
+
+// MarshalJSON aplana Extensions junto a los miembros estándar. Las extensiones
+// nunca sobrescriben los miembros estándar.
+func (p ProblemDetails) MarshalJSON() ([]byte, error) {
+	members := make(map[string]interface{}, len(p.Extensions)+6)
+	for key, value := range p.Extensions {
+		members[key] = value
+	}
+
+	members["type"] = p.Type
+	members["title"] = p.Title
+	members["status"] = p.Status
+	if p.Detail != "" {
+		members["detail"] = p.Detail
+	} else {
+		delete(members, "detail")
+	}
+	if p.Instance != "" {
+		members["instance"] = p.Instance
+	} else {
+		delete(members, "instance")
+	}
+	if p.Code != "" {
+		members["code"] = p.Code
+	} else {
+		delete(members, "code")
+	}
+
+	return json.Marshal(members)
+}
+
+// UnmarshalJSON lee los miembros estándar y guarda el resto en Extensions
+func (p *ProblemDetails) UnmarshalJSON(data []byte) error {
+	type standard ProblemDetails
+	var decoded standard
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+
+	var members map[string]interface{}
+	if err := json.Unmarshal(data, &members); err != nil {
+		return err
+	}
+	for _, key := range []string{"type", "title", "status", "detail", "instance", "code"} {
+		delete(members, key)
+	}
+	if len(members) > 0 {
+		decoded.Extensions = members
+	}
+
+	*p = ProblemDetails(decoded)
+	return nil
+}
+
+// ProblemType retorna el type URI estable de un ErrorCode usando
+// DefaultProblemTypeBaseURI (ej: PERMISSION_DENIED → urn:aoc-connect:error:permission-denied)
+func ProblemType(code ErrorCode) string {
+	return problemType(DefaultProblemTypeBaseURI, code)
+}
+
+func problemType(base string, code ErrorCode) string {
+	if code == "" {
+		return "about:blank"
+	}
+	return base + strings.ReplaceAll(strings.ToLower(string(code)), "_", "-")
+}
+
+// Responder escribe errores en el formato configurado. El valor cero responde
+// en ModeLegacy.
+type Responder struct {
+	Mode ResponseMode
+
+	// TypeBaseURI reemplaza DefaultProblemTypeBaseURI en los type URI
+	TypeBaseURI string
+}
+
+// NewResponder crea un Responder con el modo indicado
+func NewResponder(mode ResponseMode) *Responder {
+	return &Responder{Mode: mode}
+}
+
+// RespondError escribe el error en el formato del responder. r es opcional y
+// se usa para la negociación por Accept y como instance del problema.
+func (rs *Responder) RespondError(w http.ResponseWriter, r *http.Request, status int, code ErrorCode, message, detail string, meta map[string]interface{}) {
+	if !rs.useProblem(r) {
+		respondLegacyShape(w, status, code, message, detail, meta)
+		return
+	}
+
+	base := rs.TypeBaseURI
+	if base == "" {
+		base = DefaultProblemTypeBaseURI
+	}
+	problem := ProblemDetails{
+		Type:       problemType(base, code),
+		Title:      message,
+		Status:     status,
+		Detail:     detail,
+		Code:       code,
+		Extensions: meta,
+	}
+	if r != nil {
+		problem.Instance = r.URL.RequestURI()
+	}
+	writeProblem(w, problem)
+}
+
+// Middleware hace que los helpers del paquete (RespondError, RespondNotFound, ...)
+// usen el modo de este responder en los requests que atraviesan el middleware.
+// El responder viaja en el contexto del request, así que otros wrappers del
+// ResponseWriter (chi, gzip, Recover) entre el middleware y el handler no lo pierden.
+func (rs *Responder) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r = r.WithContext(context.WithValue(r.Context(), responderKey{}, rs))
+		next.ServeHTTP(&requestWriter{ResponseWriter: w, request: r}, r)
+	})
+}
+
+func (rs *Responder) useProblem(r *http.Request) bool {
+	switch rs.Mode {
+	case ModeProblem:
+		return true
+	case ModeNegotiate:
+		return r != nil && AcceptsProblemJSON(r)
+	default:
+		return false
+	}
+}
+
+// RespondProblem escribe siempre application/problem+json
+func RespondProblem(w http.ResponseWriter, r *http.Request, status int, code ErrorCode, message, detail string, meta map[string]interface{}) {
+	NewResponder(ModeProblem).RespondError(w, r, status, code, message, detail, meta)
+}
+
+// AcceptsProblemJSON indica si el header Accept incluye application/problem+json
+// con q > 0
+func AcceptsProblemJSON(r *http.Request) bool {
+	for _, header := range r.Header.Values("Accept") {
+		for _, part := range strings.Split(header, ",") {
+			mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+			if err != nil || mediaType != MimeProblemJSON {
+				continue
+			}
+			if q, ok := params["q"]; ok {
+				if value, err := strconv.ParseFloat(q, 64); err != nil || value <= 0 {
+					continue
+				}
+			}
+			return true
+		}
+	}
+	return false
+}
+
+func writeProblem(w http.ResponseWriter, problem ProblemDetails) {
+	w.Header().Set(HeaderContentType, MimeProblemJSON)
+	w.WriteHeader(problem.Status)
+	_ = json.NewEncoder(w).Encode(problem)
+}
+
+// responderKey es la clave del *Responder en el contexto del request
+type responderKey struct{}
+
+// responderFrom retorna el Responder instalado por Responder.Middleware
+func responderFrom(r *http.Request) *Responder {
+	if r == nil {
+		return nil
+	}
+	rs, _ := r.Context().Value(responderKey{}).(*Responder)
+	return rs
+}
+
+// requestFor retorna el request con el que se resuelve el modo de respuesta:
+// r si lleva un Responder en su contexto o, si no, el transportado por la
+// cadena de writers (para los helpers que solo reciben el ResponseWriter)
+func requestFor(w http.ResponseWriter, r *http.Request) *http.Request {
+	if responderFrom(r) != nil {
+		return r
+	}
+	if carried := carriedRequest(w); carried != nil && (r == nil || responderFrom(carried) != nil) {
+		return carried
+	}
+	return r
+}
+
+// requestCarrier lo implementan los writers del paquete que transportan el
+// request hasta los helpers que solo reciben el ResponseWriter
+type requestCarrier interface {
+	carriedRequest() *http.Request
+}
+
+// carriedRequest recorre la cadena Unwrap() de w hasta un requestCarrier, de
+// modo que los wrappers de otros middlewares no ocultan el request
+func carriedRequest(w http.ResponseWriter) *http.Request {
+	for w != nil {
+		if carrier, ok := w.(requestCarrier); ok {
+			return carrier.carriedRequest()
+		}
+		unwrapper, ok := w.(interface{ Unwrap() http.ResponseWriter })
+		if !ok {
+			return nil
+		}
+		w = unwrapper.Unwrap()
+	}
+	return nil
+}
+
+// requestWriter transporta el request hasta los helpers del paquete
+type requestWriter struct {
+	http.ResponseWriter
+	request *http.Request
+}
+
+func (rw *requestWriter) carriedRequest() *http.Request {
+	return rw.request
+}
+
+// Unwrap permite a http.ResponseController acceder al writer original
+func (rw *requestWriter) Unwrap() http.ResponseWriter {
+	return rw.ResponseWriter
+}
+
+// Flush implementa http.Flusher cuando el writer original lo soporta
+func (rw *requestWriter) Flush() {
+	if flusher, ok := rw.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Hijack implementa http.Hijacker (websockets) cuando el writer original lo
+// soporta; si no, retorna http.ErrNotSupported
+func (rw *requestWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	return http.NewResponseController(rw.ResponseWriter).Hijack()
+}
+
+// ReadFrom implementa io.ReaderFrom para conservar el sendfile del writer original
+func (rw *requestWriter) ReadFrom(src io.Reader) (int64, error) {
+	return copyTo(rw.ResponseWriter, src)
+}
+
+// copyTo copia src usando io.ReaderFrom si w lo implementa
+func copyTo(w http.ResponseWriter, src io.Reader) (int64, error) {
+	if readerFrom, ok := w.(io.ReaderFrom); ok {
+		return readerFrom.ReadFrom(src)
+	}
+	return io.Copy(writerOnly{w}, src)
+}
+
+// writerOnly oculta ReadFrom para que io.Copy no vuelva a llamarlo
+type writerOnly struct {
+	io.Writer
+}
@@ -0,0 +1,195 @@
+package errors_test
+
+import (
+	"bufio"
+	"encoding/json"
+	stderrors "errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	errors "github.com/AoC-Gamers/connect-libraries/errors"
+)
+
+func TestRespondProblem(t *testing.T) {
+	rr := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/teams/12?x=1", nil)
+
+	errors.RespondProblem(rr, req, http.StatusForbidden, errors.CodePermissionDenied,
+		"insufficient permissions", "User lacks TEAM__SUSPEND", map[string]interface{}{"scope_id": 12, "status": 999})
+
+	if ct := rr.Header().Get(errors.HeaderContentType); ct != errors.MimeProblemJSON {
+		t.Fatalf("expected %s, got %s", errors.MimeProblemJSON, ct)
+	}
+
+	var body map[string]interface{}
+	if err := json.Unmarshal(rr.Body.Bytes(), &body); err != nil {
+		t.Fatalf(errParseResponse, err)
+	}
+	if body["type"] != "urn:aoc-connect:error:permission-denied" {
+		t.Errorf("unexpected type %v", body["type"])
+	}
+	if body["title"] != "insufficient permissions" || body["instance"] != "/teams/12?x=1" {
+		t.Errorf("unexpected title/instance: %v", body)
+	}
+	if body["status"] != float64(http.StatusForbidden) {
+		t.Errorf("expected extensions not to override status, got %v", body["status"])
+	}
+	if body["scope_id"] != float64(12) || body["code"] != "PERMISSION_DENIED" {
+		t.Errorf("expected extension members, got %v", body)
+	}
+
+	var problem errors.ProblemDetails
+	if err := json.Unmarshal(rr.Body.Bytes(), &problem); err != nil {
+		t.Fatalf(errParseResponse, err)
+	}
+	if problem.Code != errors.CodePermissionDenied || problem.Extensions["scope_id"] != float64(12) {
+		t.Errorf("unexpected decoded problem: %+v", problem)
+	}
+}
+
+func TestResponderMiddlewareModes(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errors.RespondNotFound(w, "team", "12")
+	})
+
+	cases := []struct {
+		name   string
+		mode   errors.ResponseMode
+		accept string
+		want   string
+	}{
+		{"legacy", errors.ModeLegacy, errors.MimeProblemJSON, errors.MimeJSON},
+		{"problem", errors.ModeProblem, "", errors.MimeProblemJSON},
+		{"negotiated problem", errors.ModeNegotiate, "application/json, application/problem+json;q=0.9", errors.MimeProblemJSON},
+		{"negotiated refused", errors.ModeNegotiate, "application/problem+json;q=0", errors.MimeJSON},
+		{"negotiated default", errors.ModeNegotiate, "", errors.MimeJSON},
+	}
+
+	for _, tc := range cases {
+		rr := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/teams/12", nil)
+		if tc.accept != "" {
+			req.Header.Set("Accept", tc.accept)
+		}
+
+		errors.NewResponder(tc.mode).Middleware(handler).ServeHTTP(rr, req)
+
+		if rr.Code != http.StatusNotFound {
+			t.Errorf("%s: expected 404, got %d", tc.name, rr.Code)
+		}
+		if ct := rr.Header().Get(errors.HeaderContentType); ct != tc.want {
+			t.Errorf("%s: expected %s, got %s", tc.name, tc.want, ct)
+		}
+	}
+}
+
+func TestProblemTypeBaseURI(t *testing.T) {
+	rr := httptest.NewRecorder()
+	responder := &errors.Responder{Mode: errors.ModeProblem, TypeBaseURI: "https://example.com/problems/"}
+	responder.RespondError(rr, nil, http.StatusConflict, errors.CodeAlreadyExists, "already exists", "", nil)
+
+	var problem errors.ProblemDetails
+	if err := json.Unmarshal(rr.Body.Bytes(), &problem); err != nil {
+		t.Fatalf(errParseResponse, err)
+	}
+	if problem.Type != "https://example.com/problems/already-exists" || problem.Instance != "" {
+		t.Fatalf("unexpected problem: %+v", problem)
+	}
+	if errors.ProblemType("") != "about:blank" {
+		t.Fatalf("expected about:blank for empty code")
+	}
+}
+
+// wrappedWriter imita middlewares como chi.WrapResponseWriter (con Unwrap)
+type wrappedWriter struct{ http.ResponseWriter }
+
+func (w wrappedWriter) Unwrap() http.ResponseWriter { return w.ResponseWriter }
+
+// opaqueWriter imita wrappers sin Unwrap (ej: gzip)
+type opaqueWriter struct{ http.ResponseWriter }
+
+func TestResponderMiddlewareSurvivesWrappers(t *testing.T) {
+	wrap := func(wrapper func(http.ResponseWriter) http.ResponseWriter) func(http.Handler) http.Handler {
+		return func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				next.ServeHTTP(wrapper(w), r)
+			})
+		}
+	}
+
+	cases := []struct {
+		name    string
+		wrapper func(http.ResponseWriter) http.ResponseWriter
+		handler http.HandlerFunc
+	}{
+		{
+			"helper behind unwrappable writer",
+			func(w http.ResponseWriter) http.ResponseWriter { return wrappedWriter{w} },
+			func(w http.ResponseWriter, r *http.Request) { errors.RespondNotFound(w, "team", "12") },
+		},
+		{
+			"render behind opaque writer",
+			func(w http.ResponseWriter) http.ResponseWriter { return opaqueWriter{w} },
+			func(w http.ResponseWriter, r *http.Request) { errors.Render(w, r, errors.NotFound("team", "12")) },
+		},
+	}
+
+	for _, tc := range cases {
+		rr := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/teams/12", nil)
+
+		errors.NewResponder(errors.ModeProblem).Middleware(wrap(tc.wrapper)(tc.handler)).ServeHTTP(rr, req)
+
+		if ct := rr.Header().Get(errors.HeaderContentType); ct != errors.MimeProblemJSON {
+			t.Errorf("%s: expected %s, got %s", tc.name, errors.MimeProblemJSON, ct)
+		}
+	}
+}
+
+// hijackRecorder es un ResponseRecorder que soporta Hijack, como el writer de net/http
+type hijackRecorder struct {
+	*httptest.ResponseRecorder
+	hijacked bool
+}
+
+func (h *hijackRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	h.hijacked = true
+	server, client := net.Pipe()
+	_ = client.Close()
+	return server, bufio.NewReadWriter(bufio.NewReader(server), bufio.NewWriter(server)), nil
+}
+
+func TestResponderMiddlewareForwardsHijackAndReadFrom(t *testing.T) {
+	responder := &errors.Responder{Mode: errors.ModeProblem}
+	rec := &hijackRecorder{ResponseRecorder: httptest.NewRecorder()}
+	handler := responder.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, err := io.Copy(w, strings.NewReader("streamed")); err != nil {
+			t.Fatalf("unexpected copy error: %v", err)
+		}
+		hijacker, ok := w.(http.Hijacker)
+		if !ok {
+			t.Fatal("expected the wrapped writer to implement http.Hijacker")
+		}
+		conn, _, err := hijacker.Hijack()
+		if err != nil {
+			t.Fatalf("unexpected hijack error: %v", err)
+		}
+		_ = conn.Close()
+	}))
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/ws", nil))
+
+	if !rec.hijacked || rec.Body.String() != "streamed" {
+		t.Fatalf("expected hijack and body to reach the original writer, hijacked=%v body=%q", rec.hijacked, rec.Body.String())
+	}
+
+	// Sin soporte en el writer original, Hijack retorna ErrNotSupported
+	responder.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, _, err := w.(http.Hijacker).Hijack(); !stderrors.Is(err, http.ErrNotSupported) {
+			t.Fatalf("expected http.ErrNotSupported, got %v", err)
+		}
+	})).ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/ws", nil))
+}
@@ -0,0 +1,140 @@
+package errors
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"runtime/debug"
+
+	"github.com/rs/zerolog/log"
+)
+
+// PanicHook recibe cada panic recuperado (ej: para reportarlo a Sentry).
+// stack es el stack trace de la goroutine que hizo panic.
+type PanicHook func(r *http.Request, recovered interface{}, stack []byte)
+
+// RecoverOptions configura RecoverWith
+type RecoverOptions struct {
+	// Hook se llama después de loguear el panic; es opcional
+	Hook PanicHook
+
+	// IsInternal indica si el request viene de otro servicio ya autenticado y
+	// debe responderse con InternalErrorResponse (ej: apikey.IsServiceAuthenticated,
+	// con RecoverWith registrado después del middleware de apikey). Por defecto
+	// ningún request es interno: un header enviado por el cliente no alcanza.
+	IsInternal func(r *http.Request) bool
+}
+
+// Recover es RecoverWith con las opciones por defecto
+func Recover(next http.Handler) http.Handler {
+	return RecoverWith(RecoverOptions{})(next)
+}
+
+// RecoverWith crea un middleware que recupera panics de los handlers, loguea
+// el stack con el contexto del request y responde 500 con CodeInternalError
+// (o InternalServerError para los requests que IsInternal reconoce) en el modo y
+// el idioma de un Responder.Middleware externo. Si la respuesta ya empezó a
+// escribirse no escribe nada más. http.ErrAbortHandler se propaga para que
+// net/http aborte la conexión.
+func RecoverWith(opts RecoverOptions) func(http.Handler) http.Handler {
+	isInternal := opts.IsInternal
+	if isInternal == nil {
+		isInternal = func(*http.Request) bool { return false }
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			tw := &trackingWriter{ResponseWriter: w, request: r}
+			defer func() {
+				recovered := recover()
+				if recovered == nil {
+					return
+				}
+				if recovered == http.ErrAbortHandler {
+					panic(recovered)
+				}
+
+				stack := debug.Stack()
+				log.Error().
+					Str("panic", fmt.Sprint(recovered)).
+					Str("method", r.Method).
+					Str("path", r.URL.Path).
+					Str("remote_addr", r.RemoteAddr).
+					Bool("headers_sent", tw.wroteHeader).
+					Bytes("stack", stack).
+					Msg("Panic recovered")
+
+				if opts.Hook != nil {
+					opts.Hook(r, recovered, stack)
+				}
+
+				if tw.wroteHeader {
+					return
+				}
+				// El modo y el idioma salen de r, que lleva el Responder de un
+				// Responder.Middleware externo aunque w sea otro wrapper
+				if isInternal(r) {
+					RespondInternalServiceError(w, r, InternalServerError, "internal server error", nil)
+					return
+				}
+				e := localizeFor(w, r, InternalError(nil))
+				respondErrorFor(w, r, e.HTTPStatus(), e.Code, e.Message, e.Detail, e.Meta)
+			}()
+
+			next.ServeHTTP(tw, r)
+		})
+	}
+}
+
+// trackingWriter registra si ya se enviaron los headers de la respuesta y
+// transporta el request hasta los helpers del paquete
+type trackingWriter struct {
+	http.ResponseWriter
+	request     *http.Request
+	wroteHeader bool
+}
+
+func (tw *trackingWriter) carriedRequest() *http.Request {
+	return tw.request
+}
+
+func (tw *trackingWriter) WriteHeader(status int) {
+	tw.wroteHeader = true
+	tw.ResponseWriter.WriteHeader(status)
+}
+
+func (tw *trackingWriter) Write(b []byte) (int, error) {
+	tw.wroteHeader = true
+	return tw.ResponseWriter.Write(b)
+}
+
+// Unwrap permite a http.ResponseController acceder al writer original
+func (tw *trackingWriter) Unwrap() http.ResponseWriter {
+	return tw.ResponseWriter
+}
+
+// Flush implementa http.Flusher cuando el writer original lo soporta
+func (tw *trackingWriter) Flush() {
+	tw.wroteHeader = true
+	if flusher, ok := tw.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Hijack implementa http.Hijacker cuando el writer original lo soporta. Tras
+// tomar la conexión un panic ya no puede responder por el writer.
+func (tw *trackingWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	conn, rw, err := http.NewResponseController(tw.ResponseWriter).Hijack()
+	if err == nil {
+		tw.wroteHeader = true
+	}
+	return conn, rw, err
+}
+
+// ReadFrom implementa io.ReaderFrom para conservar el sendfile del writer original
+func (tw *trackingWriter) ReadFrom(src io.Reader) (int64, error) {
+	tw.wroteHeader = true
+	return copyTo(tw.ResponseWriter, src)
+}
@@ -0,0 +1,187 @@
+package errors_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	errors "github.com/AoC-Gamers/connect-libraries/errors"
+)
+
+func panicHandler(value interface{}) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic(value)
+	})
+}
+
+func TestRecoverRespondsWithInternalError(t *testing.T) {
+	rr := httptest.NewRecorder()
+	errors.Recover(panicHandler("boom")).ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/teams", nil))
+
+	if rr.Code != http.StatusInternalServerError {
+		t.Fatalf("expected 500, got %d", rr.Code)
+	}
+	var resp errors.ErrorResponse
+	if err := json.NewDecoder(rr.Body).Decode(&resp); err != nil {
+		t.Fatalf(errParseResponse, err)
+	}
+	if resp.Code != errors.CodeInternalError || resp.Detail != "" {
+		t.Fatalf("unexpected body: %+v", resp)
+	}
+}
+
+type serviceKey struct{}
+
+// authenticateService simula el middleware de apikey: marca el contexto
+func authenticateService(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), serviceKey{}, "connect-core")))
+	})
+}
+
+func isAuthenticatedService(r *http.Request) bool {
+	return r.Context().Value(serviceKey{}) != nil
+}
+
+func TestRecoverIgnoresUnauthenticatedAPIKeyHeader(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/auth/internal/check", nil)
+	req.Header.Set("X-Internal-API-Key", "x")
+	rr := httptest.NewRecorder()
+	errors.Recover(panicHandler("boom")).ServeHTTP(rr, req)
+
+	var resp errors.ErrorResponse
+	if err := json.NewDecoder(rr.Body).Decode(&resp); err != nil {
+		t.Fatalf(errParseResponse, err)
+	}
+	if resp.Code != errors.CodeInternalError {
+		t.Fatalf("expected the public format for a client-sent header, got %+v", resp)
+	}
+}
+
+func TestRecoverRespondsInternalFormatForAuthenticatedServices(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/auth/internal/check", nil)
+	rr := httptest.NewRecorder()
+	recoverer := errors.RecoverWith(errors.RecoverOptions{IsInternal: isAuthenticatedService})
+	authenticateService(recoverer(panicHandler("boom"))).ServeHTTP(rr, req)
+
+	var resp errors.InternalErrorResponse
+	if err := json.NewDecoder(rr.Body).Decode(&resp); err != nil {
+		t.Fatalf(errParseResponse, err)
+	}
+	if rr.Code != http.StatusInternalServerError || resp.Code != errors.InternalServerError || resp.Service != "connect-auth" {
+		t.Fatalf("unexpected internal response %d %+v", rr.Code, resp)
+	}
+}
+
+func TestRecoverCallsHookAndSkipsWrittenResponses(t *testing.T) {
+	var (
+		gotValue interface{}
+		gotStack []byte
+	)
+	mw := errors.RecoverWith(errors.RecoverOptions{
+		Hook: func(r *http.Request, recovered interface{}, stack []byte) {
+			gotValue, gotStack = recovered, stack
+		},
+	})
+
+	handler := mw(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = w.Write([]byte("partial"))
+		panic("late")
+	}))
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if rr.Code != http.StatusAccepted || rr.Body.String() != "partial" {
+		t.Fatalf("expected the partial response untouched, got %d %q", rr.Code, rr.Body.String())
+	}
+	if gotValue != "late" || len(gotStack) == 0 {
+		t.Fatalf("expected hook to receive panic value and stack, got %v", gotValue)
+	}
+}
+
+func TestRecoverRespectsResponderMode(t *testing.T) {
+	handler := errors.NewResponder(errors.ModeProblem).Middleware(errors.Recover(panicHandler("boom")))
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if rr.Header().Get(errors.HeaderContentType) != errors.MimeProblemJSON {
+		t.Fatalf("expected problem+json, got %q", rr.Header().Get(errors.HeaderContentType))
+	}
+}
+
+func TestRecoverBehindWrappersKeepsModeAndLanguage(t *testing.T) {
+	// Responder → wrapper sin Unwrap (ej: gzip) → Recover → handler que hace panic
+	opaque := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			next.ServeHTTP(opaqueWriter{w}, r)
+		})
+	}
+	handler := errors.NewResponder(errors.ModeProblem).Middleware(opaque(errors.Recover(panicHandler("boom"))))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Accept-Language", "es")
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusInternalServerError || rr.Header().Get(errors.HeaderContentType) != errors.MimeProblemJSON {
+		t.Fatalf("expected 500 problem+json, got %d %q", rr.Code, rr.Header().Get(errors.HeaderContentType))
+	}
+	if rr.Header().Get(errors.HeaderContentLanguage) != "es" {
+		t.Fatalf("expected localized response, got Content-Language %q", rr.Header().Get(errors.HeaderContentLanguage))
+	}
+}
+
+func TestRecoverCarriesRequestToHelpers(t *testing.T) {
+	handler := errors.Recover(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		errors.RespondNotFound(wrappedWriter{w}, "team", "12")
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/teams/12", nil)
+	req.Header.Set("Accept-Language", "es")
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	if rr.Header().Get(errors.HeaderContentLanguage) != "es" {
+		t.Fatalf("expected helpers inside Recover to be localized, got %q", rr.Header().Get(errors.HeaderContentLanguage))
+	}
+}
+
+func TestRecoverPropagatesAbortHandler(t *testing.T) {
+	defer func() {
+		if recovered := recover(); recovered != http.ErrAbortHandler {
+			t.Fatalf("expected http.ErrAbortHandler to propagate, got %v", recovered)
+		}
+	}()
+	errors.Recover(panicHandler(http.ErrAbortHandler)).ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+}
+
+func TestRecoverForwardsHijackAndReadFrom(t *testing.T) {
+	rec := &hijackRecorder{ResponseRecorder: httptest.NewRecorder()}
+	errors.Recover(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, _, err := w.(http.Hijacker).Hijack()
+		if err != nil {
+			t.Fatalf("unexpected hijack error: %v", err)
+		}
+		_ = conn.Close()
+		panic("after hijack")
+	})).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/ws", nil))
+	if !rec.hijacked || rec.Body.Len() != 0 {
+		t.Fatalf("expected no error response after hijack, hijacked=%v body=%q", rec.hijacked, rec.Body.String())
+	}
+
+	rr := httptest.NewRecorder()
+	errors.Recover(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(w, strings.NewReader("partial"))
+		panic("after copy")
+	})).ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/files", nil))
+	if rr.Body.String() != "partial" {
+		t.Fatalf("expected only the streamed body, got %q", rr.Body.String())
+	}
+}
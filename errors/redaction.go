@@ -0,0 +1,182 @@
+package errors
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync/atomic"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+// HeaderErrorID es el header con el ID de la respuesta de error
+const HeaderErrorID = "X-Error-ID"
+
+// EnvRedactionPolicy es la variable de entorno leída por LoadRedactionPolicy
+const EnvRedactionPolicy = "ERRORS_REDACTION"
+
+// RedactionPolicy define qué parte de los details de InternalErrorResponse
+// se envía al servicio que llamó. El log siempre registra los details completos.
+type RedactionPolicy int
+
+const (
+	// RedactSensitive elimina de los details las claves de SensitiveDetailKeys
+	// (mensajes de error, SQL, stack), sin distinguir mayúsculas y en todos los
+	// niveles. Los details se normalizan a su forma JSON, así que aplica también
+	// a structs, maps de cualquier tipo y slices. Es el valor por defecto.
+	RedactSensitive RedactionPolicy = iota
+
+	// RedactNone envía los details completos (solo para desarrollo)
+	RedactNone
+
+	// RedactAll no envía details
+	RedactAll
+)
+
+// SensitiveDetailKeys son las claves eliminadas por RedactSensitive
+var SensitiveDetailKeys = []string{"error", "err", "cause", "query", "sql", "stack"}
+
+var internalRedaction atomic.Int32
+
+// String retorna el nombre de la política ("sensitive", "none", "all")
+func (p RedactionPolicy) String() string {
+	switch p {
+	case RedactNone:
+		return "none"
+	case RedactAll:
+		return "all"
+	default:
+		return "sensitive"
+	}
+}
+
+// ParseRedactionPolicy interpreta "none", "sensitive" o "all"
+func ParseRedactionPolicy(value string) (RedactionPolicy, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "", "sensitive":
+		return RedactSensitive, nil
+	case "none", "off":
+		return RedactNone, nil
+	case "all":
+		return RedactAll, nil
+	default:
+		return RedactSensitive, fmt.Errorf("invalid redaction policy %q", value)
+	}
+}
+
+// SetInternalRedaction configura la política de redacción de los errores internos
+func SetInternalRedaction(policy RedactionPolicy) {
+	internalRedaction.Store(int32(policy))
+}
+
+// InternalRedaction retorna la política de redacción vigente
+func InternalRedaction() RedactionPolicy {
+	return RedactionPolicy(internalRedaction.Load())
+}
+
+// LoadRedactionPolicy aplica la política de ERRORS_REDACTION (por entorno:
+// "none" en desarrollo, "sensitive" o "all" en producción). Un valor inválido
+// deja RedactSensitive y retorna el error.
+func LoadRedactionPolicy() error {
+	policy, err := ParseRedactionPolicy(os.Getenv(EnvRedactionPolicy))
+	SetInternalRedaction(policy)
+	return err
+}
+
+// redactDetails aplica la política a los details de un error interno
+func redactDetails(policy RedactionPolicy, details interface{}) interface{} {
+	switch policy {
+	case RedactNone:
+		return details
+	case RedactAll:
+		return nil
+	}
+
+	if _, isErr := details.(error); isErr {
+		return nil
+	}
+	normalized, ok := normalizeDetails(details)
+	if !ok {
+		return nil
+	}
+	redacted := redactValue(normalized)
+	if fields, isMap := redacted.(map[string]interface{}); isMap && len(fields) == 0 {
+		return nil
+	}
+	return redacted
+}
+
+// normalizeDetails convierte los details (structs, maps de cualquier tipo,
+// slices) a su forma JSON genérica, que es la que se envía en la respuesta.
+// Los números se conservan como json.Number para no perder precisión.
+func normalizeDetails(details interface{}) (interface{}, bool) {
+	if details == nil {
+		return nil, true
+	}
+	data, err := json.Marshal(details)
+	if err != nil {
+		return nil, false
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var normalized interface{}
+	if err := decoder.Decode(&normalized); err != nil {
+		return nil, false
+	}
+	return normalized, true
+}
+
+// redactValue elimina las claves sensibles en todos los niveles
+func redactValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		redacted := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			if isSensitiveKey(key) {
+				continue
+			}
+			redacted[key] = redactValue(item)
+		}
+		return redacted
+	case []interface{}:
+		redacted := make([]interface{}, len(v))
+		for i, item := range v {
+			redacted[i] = redactValue(item)
+		}
+		return redacted
+	default:
+		return value
+	}
+}
+
+func isSensitiveKey(key string) bool {
+	for _, sensitive := range SensitiveDetailKeys {
+		if strings.EqualFold(key, sensitive) {
+			return true
+		}
+	}
+	return false
+}
+
+// newErrorID genera un ID aleatorio para correlacionar respuesta y log
+func newErrorID() string {
+	var b [8]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(b[:])
+}
+
+// logEventForStatus retorna un evento warn para 4xx y error para 5xx
+func logEventForStatus(status int) *zerolog.Event {
+	if status >= http.StatusInternalServerError {
+		return log.Error()
+	}
+	return log.Warn()
+}
@@ -0,0 +1,122 @@
+package errors_test
+
+import (
+	"encoding/json"
+	stderrors "errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	errors "github.com/AoC-Gamers/connect-libraries/errors"
+)
+
+func respondInternalDatabase(t *testing.T) (*httptest.ResponseRecorder, errors.InternalErrorResponse) {
+	t.Helper()
+	rr := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/core/teams", nil)
+	errors.RespondInternalDatabase(rr, req, "list teams", stderrors.New("pq: relation \"teams\" does not exist"))
+
+	var resp errors.InternalErrorResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+		t.Fatalf(errParseResponse, err)
+	}
+	return rr, resp
+}
+
+func TestInternalErrorRedaction(t *testing.T) {
+	t.Cleanup(func() { errors.SetInternalRedaction(errors.RedactSensitive) })
+
+	cases := []struct {
+		policy    errors.RedactionPolicy
+		wantError bool
+		wantOp    bool
+	}{
+		{errors.RedactSensitive, false, true},
+		{errors.RedactNone, true, true},
+		{errors.RedactAll, false, false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.policy.String(), func(t *testing.T) {
+			errors.SetInternalRedaction(tc.policy)
+			_, resp := respondInternalDatabase(t)
+
+			details, _ := resp.Details.(map[string]interface{})
+			if _, ok := details["error"]; ok != tc.wantError {
+				t.Fatalf("error detail present=%v, want %v (%v)", ok, tc.wantError, resp.Details)
+			}
+			if _, ok := details["operation"]; ok != tc.wantOp {
+				t.Fatalf("operation detail present=%v, want %v", ok, tc.wantOp)
+			}
+		})
+	}
+}
+
+func TestRedactSensitiveNormalizesDetails(t *testing.T) {
+	type dbFailure struct {
+		Operation string `json:"operation"`
+		Query     string `json:"query"`
+		Error     string
+		TeamID    uint64 `json:"team_id"`
+	}
+
+	cases := []struct {
+		name    string
+		details interface{}
+		want    string
+	}{
+		{"map of strings", map[string]string{"operation": "list", "sql": "SELECT 1"}, `{"operation":"list"}`},
+		{"struct", dbFailure{Operation: "list", Query: "SELECT 1", Error: "pq: boom", TeamID: 76561198000000001}, `{"operation":"list","team_id":76561198000000001}`},
+		{"slice", []interface{}{map[string]interface{}{"field": "name", "stack": "main.go:10"}, "plain"}, `[{"field":"name"},"plain"]`},
+		{"nested", map[string]interface{}{"batch": map[string]interface{}{"id": 7, "cause": "timeout"}}, `{"batch":{"id":7}}`},
+		{"only sensitive", map[string]string{"error": "boom"}, `null`},
+		{"error value", stderrors.New("pq: boom"), `null`},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/core/teams", nil)
+			errors.RespondInternalServiceError(rr, req, errors.InternalDatabase, "failed", tc.details)
+
+			var resp struct {
+				Details json.RawMessage `json:"details"`
+			}
+			if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+				t.Fatalf(errParseResponse, err)
+			}
+			got := string(resp.Details)
+			if got == "" {
+				got = "null"
+			}
+			if got != tc.want {
+				t.Fatalf("details = %s, want %s", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestInternalErrorIncludesErrorID(t *testing.T) {
+	rr, resp := respondInternalDatabase(t)
+	if len(resp.ErrorID) != 16 || rr.Header().Get(errors.HeaderErrorID) != resp.ErrorID {
+		t.Fatalf("expected matching error IDs, got body %q header %q", resp.ErrorID, rr.Header().Get(errors.HeaderErrorID))
+	}
+
+	_, other := respondInternalDatabase(t)
+	if other.ErrorID == resp.ErrorID {
+		t.Fatalf("expected a new error ID per response")
+	}
+}
+
+func TestLoadRedactionPolicy(t *testing.T) {
+	t.Cleanup(func() { errors.SetInternalRedaction(errors.RedactSensitive) })
+
+	t.Setenv(errors.EnvRedactionPolicy, "none")
+	if err := errors.LoadRedactionPolicy(); err != nil || errors.InternalRedaction() != errors.RedactNone {
+		t.Fatalf("expected none policy, got %v (%v)", errors.InternalRedaction(), err)
+	}
+
+	t.Setenv(errors.EnvRedactionPolicy, "verbose")
+	if err := errors.LoadRedactionPolicy(); err == nil || errors.InternalRedaction() != errors.RedactSensitive {
+		t.Fatalf("expected invalid value to fall back to sensitive")
+	}
+}
@@ -1,32 +1,37 @@
 /**
+ * Code generated by errorsgen from the errors package sources. DO NOT EDIT.
+ * Regenerate with: go run ./cmd/errorsgen
+ *
  * TypeScript Types for Connect Backend Error Responses
- * 
+ *
  * Copy this file to your frontend project:
  * src/models/api-error.model.ts
- * 
- * These types match the Go ErrorResponse struct from connect-errors library
+ *
+ * These types match the Go response structs (ErrorResponse,
+ * InternalErrorResponse, ProblemDetails, FieldError) from connect-errors library
  */
 
 /**
  * Standardized error codes from backend
- * Must match codes.go ErrorCode constants
+ * Generated from codes.go ErrorCode constants
  */
 export enum ErrorCode {
-  // Authentication & Authorization
+  // Authentication & Authorization Errors
   UNAUTHORIZED = 'UNAUTHORIZED',
   TOKEN_EXPIRED = 'TOKEN_EXPIRED',
   TOKEN_INVALID = 'TOKEN_INVALID',
   POLICY_VERSION_MISMATCH = 'POLICY_VERSION_MISMATCH',
   PERMISSION_DENIED = 'PERMISSION_DENIED',
   INSUFFICIENT_PERMISSIONS = 'INSUFFICIENT_PERMISSIONS',
-  
+
   // Resource Errors
   NOT_FOUND = 'NOT_FOUND',
   MEMBERSHIP_NOT_FOUND = 'MEMBERSHIP_NOT_FOUND',
   ALREADY_EXISTS = 'ALREADY_EXISTS',
   CONFLICT = 'CONFLICT',
+  GONE = 'GONE',
   RESOURCE_LOCKED = 'RESOURCE_LOCKED',
-  
+
   // Validation Errors
   VALIDATION_ERROR = 'VALIDATION_ERROR',
   INVALID_REQUEST = 'INVALID_REQUEST',
@@ -34,13 +39,13 @@ export enum ErrorCode {
   MISSING_REQUIRED_FIELD = 'MISSING_REQUIRED_FIELD',
   INVALID_FORMAT = 'INVALID_FORMAT',
   OUT_OF_RANGE = 'OUT_OF_RANGE',
-  
+
   // Server Errors
   INTERNAL_ERROR = 'INTERNAL_ERROR',
   DATABASE_ERROR = 'DATABASE_ERROR',
   SERVICE_UNAVAILABLE = 'SERVICE_UNAVAILABLE',
   TIMEOUT = 'TIMEOUT',
-  
+
   // Business Logic Errors
   OPERATION_NOT_ALLOWED = 'OPERATION_NOT_ALLOWED',
   QUOTA_EXCEEDED = 'QUOTA_EXCEEDED',
@@ -48,26 +53,143 @@ export enum ErrorCode {
 }
 
 /**
- * Error response structure from backend
- * Matches ErrorResponse struct from errors.go
+ * Error codes for service-to-service responses
+ * Generated from internal.go InternalErrorCode constants
+ */
+export enum InternalErrorCode {
+  // Authentication & Authorization
+  INTERNAL_UNAUTHORIZED = 'INTERNAL_UNAUTHORIZED',
+  INTERNAL_FORBIDDEN = 'INTERNAL_FORBIDDEN',
+
+  // Resource Management
+  INTERNAL_NOT_FOUND = 'INTERNAL_NOT_FOUND',
+  INTERNAL_CONFLICT = 'INTERNAL_CONFLICT',
+
+  // Validation & Input
+  INTERNAL_VALIDATION = 'INTERNAL_VALIDATION',
+  INTERNAL_BAD_REQUEST = 'INTERNAL_BAD_REQUEST',
+
+  // System & Infrastructure
+  INTERNAL_DATABASE = 'INTERNAL_DATABASE',
+  INTERNAL_TIMEOUT = 'INTERNAL_TIMEOUT',
+  INTERNAL_SERVICE_DOWN = 'INTERNAL_SERVICE_DOWN',
+  INTERNAL_RATE_LIMIT = 'INTERNAL_RATE_LIMIT',
+  INTERNAL_AUTHZ_CHECK = 'INTERNAL_AUTHZ_CHECK',
+
+  // Generic
+  INTERNAL_SERVER_ERROR = 'INTERNAL_SERVER_ERROR',
+}
+
+/**
+ * ErrorResponse representa una respuesta de error estructurada
+ * Generated from errors.go ErrorResponse
  */
 export interface ApiErrorResponse {
-  /** Short error message (legacy compatibility) */
+  /** Error es el mensaje corto y legible (mantiene compatibilidad con APIs existentes) */
   error: string;
-  
-  /** Programmatic error code */
+
+  /** Code es el código de error programático para el frontend */
   code?: ErrorCode;
-  
-  /** HTTP status code */
+
+  /** Status es el código HTTP (redundante pero útil para debugging) */
   status: number;
-  
-  /** Detailed explanation of the error */
+
+  /** Detail es una explicación detallada del error */
   detail?: string;
-  
-  /** Additional metadata specific to the error context */
+
+  /** Meta contiene metadata adicional específica del contexto (ej: scope_id, required_permission, field_name) */
   meta?: Record<string, unknown>;
 }
 
+/**
+ * InternalErrorResponse estructura estándar para respuestas entre servicios
+ * Generated from internal.go InternalErrorResponse
+ */
+export interface InternalErrorResponse {
+  /** Code es el código de error interno programático */
+  code: InternalErrorCode;
+
+  /** Message es el mensaje del error */
+  message: string;
+
+  /** Service es el servicio que produjo el error */
+  service: string;
+
+  /** Details contiene información adicional del error */
+  details?: unknown;
+
+  /** Status es el código HTTP */
+  status: number;
+
+  /** ErrorID identifica el error también en la línea de log del productor */
+  error_id?: string;
+}
+
+/**
+ * ProblemDetails representa un error según RFC 9457 (antes RFC 7807).
+ * Generated from problem.go ProblemDetails
+ */
+export interface ProblemDetails {
+  /** Type es un URI estable que identifica el tipo de problema (ver ProblemType) */
+  type: string;
+
+  /** Title es el resumen corto del tipo de problema */
+  title: string;
+
+  /** Status es el código HTTP */
+  status: number;
+
+  /** Detail es la explicación de esta ocurrencia */
+  detail?: string;
+
+  /** Instance identifica la ocurrencia (por defecto el path del request) */
+  instance?: string;
+
+  /** Code es el código de error programático (extensión) */
+  code?: ErrorCode;
+
+  /** Extension members (flattened meta) */
+  [extension: string]: unknown;
+}
+
+/**
+ * FieldError es un fallo de validación de un campo
+ * Generated from validate.go FieldError
+ */
+export interface FieldError {
+  /** Field es la ruta JSON del campo (ej: members[2].role) */
+  field: string;
+
+  /** Code es MISSING_REQUIRED_FIELD, OUT_OF_RANGE o INVALID_FORMAT */
+  code: ErrorCode;
+
+  /** Rule es la regla que falló (required, min, max, len, oneof, steamid64, regex) */
+  rule: string;
+
+  /** Param es el parámetro de la regla (ej: "3" en min=3) */
+  param?: string;
+
+  /** Message es la explicación legible */
+  message: string;
+}
+
+/**
+ * VALIDATION_ERROR response: meta.errors maps each field to its message,
+ * meta.fields lists every failure (errors.Validate) and single-field errors
+ * carry meta.field and meta.reason
+ */
+export interface ValidationError extends ApiErrorResponse {
+  code: ErrorCode.VALIDATION_ERROR;
+
+  meta?: {
+    errors?: Record<string, string>;
+    fields?: FieldError[];
+    field?: string;
+    reason?: string;
+    [key: string]: unknown;
+  };
+}
+
 /**
  * Extended Error class with structured information
  */
@@ -97,7 +219,7 @@ export class ApiError extends Error {
    * Check if error is permission-related
    */
   isPermissionError(): boolean {
-    return this.code === ErrorCode.PERMISSION_DENIED || 
+    return this.code === ErrorCode.PERMISSION_DENIED ||
            this.code === ErrorCode.INSUFFICIENT_PERMISSIONS;
   }
 
@@ -112,7 +234,7 @@ export class ApiError extends Error {
    * Check if error is not found
    */
   isNotFound(): boolean {
-    return this.code === ErrorCode.NOT_FOUND || 
+    return this.code === ErrorCode.NOT_FOUND ||
            this.code === ErrorCode.MEMBERSHIP_NOT_FOUND;
   }
 
@@ -120,7 +242,7 @@ export class ApiError extends Error {
    * Check if error requires re-authentication
    */
   requiresReauth(): boolean {
-    return this.code === ErrorCode.TOKEN_EXPIRED || 
+    return this.code === ErrorCode.TOKEN_EXPIRED ||
            this.code === ErrorCode.POLICY_VERSION_MISMATCH ||
            this.code === ErrorCode.TOKEN_INVALID;
   }
@@ -147,33 +269,34 @@ export class ApiError extends Error {
    */
   getUserMessage(): string {
     const friendlyMessages: Record<ErrorCode, string> = {
-      [ErrorCode.UNAUTHORIZED]: 'You need to be logged in',
-      [ErrorCode.TOKEN_EXPIRED]: 'Your session has expired',
-      [ErrorCode.TOKEN_INVALID]: 'Your session is invalid',
-      [ErrorCode.POLICY_VERSION_MISMATCH]: 'Please log in again',
-      [ErrorCode.PERMISSION_DENIED]: 'You don\'t have permission for this action',
+      [ErrorCode.UNAUTHORIZED]: 'Unauthorized',
+      [ErrorCode.TOKEN_EXPIRED]: 'Unauthorized',
+      [ErrorCode.TOKEN_INVALID]: 'Unauthorized',
+      [ErrorCode.POLICY_VERSION_MISMATCH]: 'Policy version mismatch',
+      [ErrorCode.PERMISSION_DENIED]: 'Insufficient permissions',
       [ErrorCode.INSUFFICIENT_PERMISSIONS]: 'Insufficient permissions',
-      [ErrorCode.NOT_FOUND]: 'Resource not found',
-      [ErrorCode.MEMBERSHIP_NOT_FOUND]: 'You don\'t have access to this resource',
+      [ErrorCode.NOT_FOUND]: 'Not found',
+      [ErrorCode.MEMBERSHIP_NOT_FOUND]: 'Membership not found',
       [ErrorCode.ALREADY_EXISTS]: 'Resource already exists',
-      [ErrorCode.CONFLICT]: 'Conflict with current state',
-      [ErrorCode.RESOURCE_LOCKED]: 'Resource is locked',
-      [ErrorCode.VALIDATION_ERROR]: 'Invalid data provided',
+      [ErrorCode.CONFLICT]: 'Conflict',
+      [ErrorCode.GONE]: 'Resource is no longer available',
+      [ErrorCode.RESOURCE_LOCKED]: 'Resource locked',
+      [ErrorCode.VALIDATION_ERROR]: 'Validation failed',
       [ErrorCode.INVALID_REQUEST]: 'Invalid request',
       [ErrorCode.BAD_REQUEST]: 'Bad request',
-      [ErrorCode.MISSING_REQUIRED_FIELD]: 'Required field is missing',
+      [ErrorCode.MISSING_REQUIRED_FIELD]: 'Missing required field',
       [ErrorCode.INVALID_FORMAT]: 'Invalid format',
       [ErrorCode.OUT_OF_RANGE]: 'Value out of range',
-      [ErrorCode.INTERNAL_ERROR]: 'An internal error occurred',
-      [ErrorCode.DATABASE_ERROR]: 'Database error occurred',
-      [ErrorCode.SERVICE_UNAVAILABLE]: 'Service temporarily unavailable',
-      [ErrorCode.TIMEOUT]: 'Operation timed out',
+      [ErrorCode.INTERNAL_ERROR]: 'Internal server error',
+      [ErrorCode.DATABASE_ERROR]: 'Database error',
+      [ErrorCode.SERVICE_UNAVAILABLE]: 'Service unavailable',
+      [ErrorCode.TIMEOUT]: 'Operation timeout',
       [ErrorCode.OPERATION_NOT_ALLOWED]: 'Operation not allowed',
       [ErrorCode.QUOTA_EXCEEDED]: 'Quota exceeded',
-      [ErrorCode.RATE_LIMIT_EXCEEDED]: 'Too many requests',
+      [ErrorCode.RATE_LIMIT_EXCEEDED]: 'Rate limit exceeded',
     };
 
-    return this.code && friendlyMessages[this.code] 
+    return this.code && friendlyMessages[this.code]
       ? friendlyMessages[this.code]
       : this.message || 'An error occurred';
   }
@@ -0,0 +1,318 @@
+package errors
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// TagValidate es el struct tag leído por Validate
+const TagValidate = "validate"
+
+// FieldError es un fallo de validación de un campo
+type FieldError struct {
+	// Field es la ruta JSON del campo (ej: members[2].role)
+	Field string `json:"field"`
+
+	// Code es MISSING_REQUIRED_FIELD, OUT_OF_RANGE o INVALID_FORMAT
+	Code ErrorCode `json:"code"`
+
+	// Rule es la regla que falló (required, min, max, len, oneof, steamid64, regex)
+	Rule string `json:"rule"`
+
+	// Param es el parámetro de la regla (ej: "3" en min=3)
+	Param string `json:"param,omitempty"`
+
+	// Message es la explicación legible
+	Message string `json:"message"`
+}
+
+// Validate valida v (struct o puntero a struct) según los tags `validate` y
+// retorna un *Error VALIDATION_ERROR con todos los fallos, o nil si es válido.
+//
+// Reglas (separadas por coma):
+//   - required: el valor no puede ser cero (nil, "", 0, slice vacío)
+//   - omitempty: omite el resto de las reglas si el valor es cero
+//   - min=N / max=N: valor numérico, o largo de strings, slices y maps
+//   - len=N: largo exacto
+//   - oneof=a b c: el valor debe ser uno de los listados
+//   - steamid64: SteamID64 válido (string o entero)
+//   - regex=EXPR: el string debe coincidir; debe ser la última regla del tag
+//
+// Los structs, slices, arrays y maps anidados se recorren y los campos se
+// reportan con su ruta JSON (members[2].role). La meta incluye "errors"
+// (ruta → mensaje, igual que ValidationErrors) y "fields" ([]FieldError).
+// Un tag mal formado (ej: min=abc o regex inválida) produce panic.
+func Validate(v interface{}) error {
+	fields := ValidateFields(v)
+	if len(fields) == 0 {
+		return nil
+	}
+	return validationError(fields)
+}
+
+// ValidateFields es Validate retornando la lista de fallos
+func ValidateFields(v interface{}) []FieldError {
+	var fields []FieldError
+	validateValue(reflect.ValueOf(v), "", &fields)
+	return fields
+}
+
+func validationError(fields []FieldError) *Error {
+	messages := make(map[string]string, len(fields))
+	for _, f := range fields {
+		if _, exists := messages[f.Field]; !exists {
+			messages[f.Field] = f.Message
+		}
+	}
+	return ValidationErrors(messages).WithMeta("fields", fields)
+}
+
+func validateValue(v reflect.Value, path string, fields *[]FieldError) {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return
+		}
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			sf := t.Field(i)
+			if !sf.IsExported() {
+				continue
+			}
+			name, skip := jsonFieldName(sf)
+			if skip {
+				continue
+			}
+			fv := v.Field(i)
+			fieldPath := joinPath(path, name)
+			if sf.Anonymous && sf.Tag.Get("json") == "" {
+				fieldPath = path
+			}
+			if tag := sf.Tag.Get(TagValidate); tag != "" && tag != "-" {
+				if !applyRules(fv, fieldPath, tag, fields) {
+					continue
+				}
+			}
+			validateValue(fv, fieldPath, fields)
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			validateValue(v.Index(i), path+"["+strconv.Itoa(i)+"]", fields)
+		}
+	case reflect.Map:
+		iter := v.MapRange()
+		for iter.Next() {
+			validateValue(iter.Value(), path+"["+fmt.Sprint(iter.Key().Interface())+"]", fields)
+		}
+	}
+}
+
+// applyRules valida un campo y retorna si hay que seguir con sus hijos
+func applyRules(v reflect.Value, path, tag string, fields *[]FieldError) bool {
+	value := v
+	for value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			break
+		}
+		value = value.Elem()
+	}
+	zero := isZero(v)
+
+	for _, rule := range splitRules(tag) {
+		name, param, _ := strings.Cut(rule, "=")
+		switch name {
+		case "required":
+			if zero {
+				*fields = append(*fields, FieldError{Field: path, Code: CodeMissingRequiredField, Rule: name,
+					Message: "is required"})
+				return false
+			}
+		case "omitempty":
+			if zero {
+				return false
+			}
+		default:
+			if zero && (value.Kind() == reflect.Pointer || value.Kind() == reflect.Interface) {
+				return false
+			}
+			if fe, ok := checkRule(value, name, param); !ok {
+				fe.Field = path
+				*fields = append(*fields, fe)
+			}
+		}
+	}
+	return true
+}
+
+func checkRule(v reflect.Value, name, param string) (FieldError, bool) {
+	fail := func(code ErrorCode, message string) (FieldError, bool) {
+		return FieldError{Code: code, Rule: name, Param: param, Message: message}, false
+	}
+
+	switch name {
+	case "min", "max", "len":
+		limit, err := strconv.ParseFloat(param, 64)
+		if err != nil {
+			panic(fmt.Sprintf("errors: invalid %s=%q in validate tag", name, param))
+		}
+		measured, isLength := measure(v)
+		unit := ""
+		if isLength {
+			unit = " characters"
+			if v.Kind() != reflect.String {
+				unit = " items"
+			}
+		}
+		switch {
+		case name == "min" && measured < limit:
+			if isLength {
+				return fail(CodeOutOfRange, "must have at least "+param+unit)
+			}
+			return fail(CodeOutOfRange, "must be at least "+param)
+		case name == "max" && measured > limit:
+			if isLength {
+				return fail(CodeOutOfRange, "must have at most "+param+unit)
+			}
+			return fail(CodeOutOfRange, "must be at most "+param)
+		case name == "len" && measured != limit:
+			return fail(CodeOutOfRange, "must have exactly "+param+unit)
+		}
+	case "oneof":
+		options := strings.Fields(param)
+		current := fmt.Sprint(v.Interface())
+		for _, option := range options {
+			if current == option {
+				return FieldError{}, true
+			}
+		}
+		return fail(CodeInvalidFormat, "must be one of: "+strings.Join(options, ", "))
+	case "steamid64":
+		if !isSteamID64(v) {
+			return fail(CodeInvalidFormat, "must be a valid SteamID64")
+		}
+	case "regex":
+		if v.Kind() != reflect.String || !compileRegex(param).MatchString(v.String()) {
+			return fail(CodeInvalidFormat, "must match "+param)
+		}
+	default:
+		panic(fmt.Sprintf("errors: unknown validate rule %q", name))
+	}
+	return FieldError{}, true
+}
+
+// measure retorna el valor numérico o el largo, e indica si es un largo
+func measure(v reflect.Value) (float64, bool) {
+	switch v.Kind() {
+	case reflect.String:
+		return float64(len([]rune(v.String()))), true
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return float64(v.Len()), true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), false
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(v.Uint()), false
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), false
+	default:
+		panic(fmt.Sprintf("errors: min/max/len not supported for %s", v.Kind()))
+	}
+}
+
+const (
+	steamID64Min = 76561197960265728
+	steamID64Max = 76561202255233023
+)
+
+func isSteamID64(v reflect.Value) bool {
+	var id uint64
+	switch v.Kind() {
+	case reflect.String:
+		s := v.String()
+		if len(s) != 17 {
+			return false
+		}
+		parsed, err := strconv.ParseUint(s, 10, 64)
+		if err != nil {
+			return false
+		}
+		id = parsed
+	case reflect.Int, reflect.Int64:
+		if v.Int() < 0 {
+			return false
+		}
+		id = uint64(v.Int())
+	case reflect.Uint, reflect.Uint64:
+		id = v.Uint()
+	default:
+		return false
+	}
+	return id >= steamID64Min && id <= steamID64Max
+}
+
+var regexCache sync.Map
+
+func compileRegex(expr string) *regexp.Regexp {
+	if cached, ok := regexCache.Load(expr); ok {
+		return cached.(*regexp.Regexp)
+	}
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		panic(fmt.Sprintf("errors: invalid regex %q in validate tag: %v", expr, err))
+	}
+	regexCache.Store(expr, re)
+	return re
+}
+
+// splitRules separa las reglas por coma; regex= consume el resto del tag
+func splitRules(tag string) []string {
+	var rules []string
+	for tag != "" {
+		if strings.HasPrefix(tag, "regex=") {
+			return append(rules, tag)
+		}
+		rule, rest, _ := strings.Cut(tag, ",")
+		if rule = strings.TrimSpace(rule); rule != "" {
+			rules = append(rules, rule)
+		}
+		tag = strings.TrimLeft(rest, " ")
+	}
+	return rules
+}
+
+func isZero(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Slice, reflect.Map:
+		return v.Len() == 0
+	case reflect.Invalid:
+		return true
+	default:
+		return v.IsZero()
+	}
+}
+
+func jsonFieldName(sf reflect.StructField) (string, bool) {
+	tag := sf.Tag.Get("json")
+	if tag == "-" {
+		return "", true
+	}
+	name, _, _ := strings.Cut(tag, ",")
+	if name == "" {
+		name = sf.Name
+	}
+	return name, false
+}
+
+func joinPath(base, name string) string {
+	if base == "" {
+		return name
+	}
+	return base + "." + name
+}
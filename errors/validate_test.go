@@ -0,0 +1,192 @@
+package errors_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	errors "github.com/AoC-Gamers/connect-libraries/errors"
+)
+
+type member struct {
+	SteamID string `json:"steam_id" validate:"required,steamid64"`
+	Role    string `json:"role" validate:"required,oneof=owner admin member"`
+}
+
+type createTeamRequest struct {
+	Name    string   `json:"name" validate:"required,min=3,max=20"`
+	Tag     string   `json:"tag" validate:"omitempty,len=4,regex=^[A-Z0-9]{2,4}$"`
+	Slots   int      `json:"slots" validate:"min=2,max=10"`
+	Owner   *member  `json:"owner" validate:"required"`
+	Members []member `json:"members" validate:"max=3"`
+	Note    *string  `json:"note" validate:"max=5"`
+}
+
+func validTeam() createTeamRequest {
+	return createTeamRequest{
+		Name:  "Alpha",
+		Slots: 5,
+		Owner: &member{SteamID: "76561198000000001", Role: "owner"},
+		Members: []member{
+			{SteamID: "76561198000000002", Role: "member"},
+		},
+	}
+}
+
+func TestValidate_Valid(t *testing.T) {
+	team := validTeam()
+	if err := errors.Validate(&team); err != nil {
+		t.Fatalf("expected valid request, got %v", err)
+	}
+}
+
+func TestValidate_CollectsAllFailures(t *testing.T) {
+	note := "too long note"
+	team := validTeam()
+	team.Name = "Al"
+	team.Tag = "ab"
+	team.Slots = 11
+	team.Owner = nil
+	team.Note = &note
+	team.Members = append(team.Members,
+		member{SteamID: "123", Role: "member"},
+		member{SteamID: "76561198000000003", Role: "guest"},
+	)
+
+	fields := errors.ValidateFields(team)
+	got := make(map[string]errors.ErrorCode, len(fields))
+	for _, f := range fields {
+		got[f.Field+"/"+f.Rule] = f.Code
+	}
+	want := map[string]errors.ErrorCode{
+		"name/min":                      errors.CodeOutOfRange,
+		"tag/len":                       errors.CodeOutOfRange,
+		"tag/regex":                     errors.CodeInvalidFormat,
+		"slots/max":                     errors.CodeOutOfRange,
+		"owner/required":                errors.CodeMissingRequiredField,
+		"note/max":                      errors.CodeOutOfRange,
+		"members[1].steam_id/steamid64": errors.CodeInvalidFormat,
+		"members[2].role/oneof":         errors.CodeInvalidFormat,
+	}
+	if len(got) != len(want) {
+		t.Fatalf("expected %d failures, got %d: %+v", len(want), len(got), fields)
+	}
+	for key, code := range want {
+		if got[key] != code {
+			t.Errorf("%s: expected %s, got %q", key, code, got[key])
+		}
+	}
+
+	err := errors.Validate(team)
+	if !errors.HasCode(err, errors.CodeValidationError) {
+		t.Fatalf("expected VALIDATION_ERROR, got %v", err)
+	}
+}
+
+func TestValidate_SteamID64Integer(t *testing.T) {
+	type req struct {
+		ID uint64 `json:"id" validate:"steamid64"`
+	}
+	if err := errors.Validate(req{ID: 76561198000000001}); err != nil {
+		t.Fatalf("expected valid steamid64, got %v", err)
+	}
+	if err := errors.Validate(req{ID: 42}); err == nil {
+		t.Fatal("expected invalid steamid64")
+	}
+}
+
+func TestValidate_InvalidTagPanics(t *testing.T) {
+	type req struct {
+		Name string `validate:"min=abc"`
+	}
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected panic for malformed tag")
+		}
+	}()
+	_ = errors.Validate(req{Name: "x"})
+}
+
+func TestBind(t *testing.T) {
+	cases := []struct {
+		name    string
+		body    string
+		status  int
+		code    errors.ErrorCode
+		contain string
+	}{
+		{"valid", `{"name":"Alpha","slots":4,"owner":{"steam_id":"76561198000000001","role":"owner"}}`, http.StatusOK, "", ""},
+		{"empty", ``, http.StatusBadRequest, errors.CodeBadRequest, "empty"},
+		{"malformed", `{"name":`, http.StatusBadRequest, errors.CodeBadRequest, "Malformed JSON"},
+		{"syntax", `{"name" "x"}`, http.StatusBadRequest, errors.CodeBadRequest, "offset 9"},
+		{"unknown field", `{"name":"Alpha","color":"red"}`, http.StatusBadRequest, errors.CodeBadRequest, "Unknown field 'color'"},
+		{"wrong type", `{"name":"Alpha","slots":"four"}`, http.StatusBadRequest, errors.CodeInvalidFormat, "Field 'slots' has invalid format. Expected: integer"},
+		{"trailing", `{"name":"Alpha"} {}`, http.StatusBadRequest, errors.CodeBadRequest, "single JSON value"},
+		{"too large", `{"name":"` + strings.Repeat("a", 200) + `"}`, http.StatusRequestEntityTooLarge, errors.CodeBadRequest, "exceeds 128 bytes"},
+		{"invalid", `{"name":"Al","slots":4,"members":[{"steam_id":"1","role":"x"}]}`, http.StatusBadRequest, errors.CodeValidationError, ""},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/teams", strings.NewReader(tc.body))
+			w := httptest.NewRecorder()
+
+			var dst createTeamRequest
+			err := errors.BindWith(w, r, &dst, errors.DecodeOptions{MaxBytes: 128})
+			if tc.code == "" {
+				if err != nil {
+					t.Fatalf("expected no error, got %v", err)
+				}
+				return
+			}
+
+			errors.Render(w, r, err)
+			if w.Code != tc.status {
+				t.Fatalf("expected status %d, got %d", tc.status, w.Code)
+			}
+			var resp errors.ErrorResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+				t.Fatalf(errParseResponse, err)
+			}
+			if resp.Code != tc.code {
+				t.Fatalf("expected code %s, got %s", tc.code, resp.Code)
+			}
+			if !strings.Contains(resp.Detail, tc.contain) {
+				t.Fatalf("expected detail containing %q, got %q", tc.contain, resp.Detail)
+			}
+		})
+	}
+}
+
+func TestBind_RendersFieldPaths(t *testing.T) {
+	body := `{"name":"Alpha","slots":4,"owner":{"steam_id":"76561198000000001","role":"owner"},"members":[{"steam_id":"76561198000000002","role":"member"},{"steam_id":"76561198000000003","role":"boss"}]}`
+	r := httptest.NewRequest(http.MethodPost, "/teams", strings.NewReader(body))
+	r.Header.Set("Accept-Language", "es")
+	w := httptest.NewRecorder()
+
+	var dst createTeamRequest
+	errors.Render(w, r, errors.Bind(w, r, &dst))
+
+	var resp struct {
+		Code   errors.ErrorCode `json:"code"`
+		Detail string           `json:"detail"`
+		Meta   struct {
+			Errors map[string]string   `json:"errors"`
+			Fields []errors.FieldError `json:"fields"`
+		} `json:"meta"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf(errParseResponse, err)
+	}
+	if resp.Detail != "Se produjeron varios errores de validación" {
+		t.Fatalf("expected localized detail, got %q", resp.Detail)
+	}
+	if len(resp.Meta.Fields) != 1 || resp.Meta.Fields[0].Field != "members[1].role" || resp.Meta.Fields[0].Code != errors.CodeInvalidFormat {
+		t.Fatalf("unexpected fields: %+v", resp.Meta.Fields)
+	}
+	if resp.Meta.Errors["members[1].role"] == "" {
+		t.Fatalf("expected errors map entry, got %+v", resp.Meta.Errors)
+	}
+}
@@ -0,0 +1,109 @@
+// Package natssource keeps an authconfig.Cache up to date with the policy
+// version changes published by Connect-Auth on NATS.
+package natssource
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/AoC-Gamers/connect-libraries/middleware/v2/authconfig"
+	natsio "github.com/nats-io/nats.go"
+	"github.com/rs/zerolog/log"
+)
+
+// Subject and event types published by Connect-Auth
+const (
+	DefaultSubject = "connect.auth.policy_versions"
+	EventGlobal    = "policy_version_global"
+	EventUser      = "policy_version_user"
+)
+
+// Event is a policy version change. It is compatible with the Event envelope
+// of the nats module (PublishEventCore(subject, type, EventData{...})).
+type Event struct {
+	Type string    `json:"type"`
+	Data EventData `json:"data"`
+}
+
+// EventData holds the new version; SteamID is only used by EventUser and a
+// Version below 1 removes the user's override
+type EventData struct {
+	SteamID string `json:"steamid,omitempty"`
+	Version int    `json:"version"`
+}
+
+// Subscribe applies the events on subject (DefaultSubject when empty) to
+// cache using NATS Core, so every instance receives every event. Invalid
+// events are logged and dropped.
+func Subscribe(conn *natsio.Conn, subject string, cache *authconfig.Cache) (*natsio.Subscription, error) {
+	if conn == nil {
+		return nil, fmt.Errorf("NATS connection is nil")
+	}
+	if subject == "" {
+		subject = DefaultSubject
+	}
+
+	sub, err := conn.Subscribe(subject, func(msg *natsio.Msg) {
+		if err := handleMessage(cache, msg.Data); err != nil {
+			log.Error().
+				Err(err).
+				Str("subject", msg.Subject).
+				Msg("Failed to apply policy version event")
+		}
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to subscribe to %s: %w", subject, err)
+	}
+	return sub, nil
+}
+
+// Publish publishes a policy version change (used by Connect-Auth)
+func Publish(conn *natsio.Conn, subject string, event Event) error {
+	if conn == nil {
+		return fmt.Errorf("NATS connection is nil")
+	}
+	if subject == "" {
+		subject = DefaultSubject
+	}
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	return conn.Publish(subject, data)
+}
+
+// Apply updates the cache with the event
+func Apply(cache *authconfig.Cache, event Event) error {
+	switch event.Type {
+	case EventGlobal:
+		if event.Data.Version < 1 {
+			return fmt.Errorf("%s: invalid version %d", event.Type, event.Data.Version)
+		}
+		cache.SetGlobal(event.Data.Version)
+	case EventUser:
+		if event.Data.SteamID == "" {
+			return fmt.Errorf("%s without steamid", event.Type)
+		}
+		cache.SetUser(event.Data.SteamID, event.Data.Version)
+	default:
+		return fmt.Errorf("unknown policy version event type %q", event.Type)
+	}
+	return nil
+}
+
+func handleMessage(cache *authconfig.Cache, data []byte) error {
+	var event Event
+	if err := json.Unmarshal(data, &event); err != nil {
+		return fmt.Errorf("invalid policy version event: %w", err)
+	}
+	if err := Apply(cache, event); err != nil {
+		return err
+	}
+
+	log.Info().
+		Str("type", event.Type).
+		Str("steamid", event.Data.SteamID).
+		Int("version", event.Data.Version).
+		Msg("Policy version updated")
+	return nil
+}
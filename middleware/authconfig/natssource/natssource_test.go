@@ -0,0 +1,41 @@
+package natssource
+
+import (
+	"context"
+	"testing"
+
+	"github.com/AoC-Gamers/connect-libraries/middleware/v2/authconfig"
+)
+
+func TestHandleMessage(t *testing.T) {
+	cache := authconfig.NewCache(1)
+
+	// Envelope published with PublishEventCore from the nats module
+	envelope := `{"type":"policy_version_user","version":1,"data":{"steamid":"76561198000000001","version":4},"meta":{"ts":1,"by":"connect-auth"}}`
+	if err := handleMessage(cache, []byte(envelope)); err != nil {
+		t.Fatalf("handleMessage: %v", err)
+	}
+	if err := handleMessage(cache, []byte(`{"type":"policy_version_global","data":{"version":2}}`)); err != nil {
+		t.Fatalf("handleMessage: %v", err)
+	}
+
+	if got, _ := cache.PolicyVersion(context.Background(), "76561198000000001"); got != 4 {
+		t.Fatalf("expected override 4, got %d", got)
+	}
+	if got, _ := cache.PolicyVersion(context.Background(), "76561198000000002"); got != 2 {
+		t.Fatalf("expected global 2, got %d", got)
+	}
+
+	if err := handleMessage(cache, []byte("not json")); err == nil {
+		t.Fatal("expected error for invalid payload")
+	}
+	if err := handleMessage(cache, []byte(`{"type":"policy_version_user","data":{"version":3}}`)); err == nil {
+		t.Fatal("expected error for event without steamid")
+	}
+	if err := handleMessage(cache, []byte(`{"type":"policy_version_global","data":{"version":0}}`)); err == nil {
+		t.Fatal("expected error for invalid global version")
+	}
+	if _, err := Subscribe(nil, "", cache); err == nil {
+		t.Fatal("expected error for nil connection")
+	}
+}
@@ -0,0 +1,114 @@
+// Package redissource implements authconfig.PolicyVersionSource on Redis so
+// every instance reads the versions Connect-Auth writes.
+package redissource
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/AoC-Gamers/connect-libraries/middleware/v2/authconfig"
+	"github.com/redis/go-redis/v9"
+)
+
+// DefaultPrefix is the key prefix in Redis
+const DefaultPrefix = "connect:policy_version:"
+
+// Options configures a Source
+type Options struct {
+	// Prefix of the keys (default DefaultPrefix)
+	Prefix string
+
+	// Fallback is the global version used when the global key is missing
+	// (default authconfig.DefaultPolicyVersion)
+	Fallback int
+}
+
+// Source reads versions from Redis:
+//   - {prefix}global: global version
+//   - {prefix}users: hash steamID → per-user override
+//
+// PolicyVersion queries Redis on every call; use Loader with an
+// authconfig.Cache to poll instead.
+type Source struct {
+	client   redis.UniversalClient
+	prefix   string
+	fallback int
+}
+
+var _ authconfig.PolicyVersionSource = (*Source)(nil)
+
+// New creates a Source on a go-redis client
+func New(client redis.UniversalClient, opts Options) *Source {
+	if opts.Prefix == "" {
+		opts.Prefix = DefaultPrefix
+	}
+	if opts.Fallback < 1 {
+		opts.Fallback = authconfig.DefaultPolicyVersion
+	}
+	return &Source{client: client, prefix: opts.Prefix, fallback: opts.Fallback}
+}
+
+// PolicyVersion implements authconfig.PolicyVersionSource
+func (s *Source) PolicyVersion(ctx context.Context, steamID string) (int, error) {
+	pipe := s.client.Pipeline()
+	global := pipe.Get(ctx, s.globalKey())
+	user := pipe.HGet(ctx, s.usersKey(), steamID)
+	if _, err := pipe.Exec(ctx); err != nil && !errors.Is(err, redis.Nil) {
+		return 0, err
+	}
+
+	snapshot := authconfig.Snapshot{Global: s.fallback}
+	if value, err := global.Int(); err == nil {
+		snapshot.Global = value
+	}
+	if value, err := user.Int(); err == nil {
+		snapshot.Users = map[string]int{steamID: value}
+	}
+	return snapshot.Effective(steamID), nil
+}
+
+// Loader returns an authconfig.Loader that reads all versions (GET + HGETALL)
+func (s *Source) Loader() authconfig.Loader {
+	return func(ctx context.Context) (authconfig.Snapshot, error) {
+		snapshot := authconfig.Snapshot{Global: s.fallback}
+		global, err := s.client.Get(ctx, s.globalKey()).Int()
+		switch {
+		case err == nil:
+			snapshot.Global = global
+		case !errors.Is(err, redis.Nil):
+			return authconfig.Snapshot{}, err
+		}
+
+		users, err := s.client.HGetAll(ctx, s.usersKey()).Result()
+		if err != nil {
+			return authconfig.Snapshot{}, err
+		}
+		snapshot.Users = make(map[string]int, len(users))
+		for steamID, value := range users {
+			version, err := strconv.Atoi(value)
+			if err != nil {
+				return authconfig.Snapshot{}, fmt.Errorf("invalid policy version for %s: %w", steamID, err)
+			}
+			snapshot.Users[steamID] = version
+		}
+		return snapshot, nil
+	}
+}
+
+// SetGlobal writes the global version (used by Connect-Auth)
+func (s *Source) SetGlobal(ctx context.Context, version int) error {
+	return s.client.Set(ctx, s.globalKey(), version, 0).Err()
+}
+
+// SetUser writes the override for steamID; version < 1 removes it
+func (s *Source) SetUser(ctx context.Context, steamID string, version int) error {
+	if version < 1 {
+		return s.client.HDel(ctx, s.usersKey(), steamID).Err()
+	}
+	return s.client.HSet(ctx, s.usersKey(), steamID, version).Err()
+}
+
+func (s *Source) globalKey() string { return s.prefix + "global" }
+func (s *Source) usersKey() string  { return s.prefix + "users" }
@@ -0,0 +1,51 @@
+package redissource
+
+import (
+	"context"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+func TestSource(t *testing.T) {
+	ctx := context.Background()
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	defer func() { _ = client.Close() }()
+
+	source := New(client, Options{Fallback: 2})
+	if got, err := source.PolicyVersion(ctx, "76561198000000001"); err != nil || got != 2 {
+		t.Fatalf("expected fallback 2, got %d %v", got, err)
+	}
+
+	if err := source.SetGlobal(ctx, 3); err != nil {
+		t.Fatal(err)
+	}
+	if err := source.SetUser(ctx, "76561198000000001", 5); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := source.PolicyVersion(ctx, "76561198000000001"); got != 5 {
+		t.Fatalf("expected override 5, got %d", got)
+	}
+	if got, _ := source.PolicyVersion(ctx, "76561198000000002"); got != 3 {
+		t.Fatalf("expected global 3, got %d", got)
+	}
+
+	snapshot, err := source.Loader()(ctx)
+	if err != nil || snapshot.Global != 3 || snapshot.Users["76561198000000001"] != 5 {
+		t.Fatalf("unexpected snapshot %+v %v", snapshot, err)
+	}
+
+	if err := source.SetUser(ctx, "76561198000000001", 0); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := source.PolicyVersion(ctx, "76561198000000001"); got != 3 {
+		t.Fatalf("expected global after removing override, got %d", got)
+	}
+
+	server.Close()
+	if _, err := source.PolicyVersion(ctx, "76561198000000001"); err == nil {
+		t.Fatal("expected error when Redis is unavailable")
+	}
+}
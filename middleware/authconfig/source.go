@@ -0,0 +1,206 @@
+package authconfig
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// DefaultRefreshInterval is the Run interval used when a non-positive one is given
+const DefaultRefreshInterval = time.Minute
+
+// PolicyVersionSource supplies the policy version a user's token must carry.
+// The effective version of a user is max(global, per-user override), so a
+// global bump also invalidates users with an older override.
+type PolicyVersionSource interface {
+	PolicyVersion(ctx context.Context, steamID string) (int, error)
+}
+
+// Snapshot is the full set of policy versions
+type Snapshot struct {
+	Global int            `json:"global"`
+	Users  map[string]int `json:"users,omitempty"`
+}
+
+// Effective returns the effective version for steamID
+func (s Snapshot) Effective(steamID string) int {
+	version := s.Global
+	if override, ok := s.Users[steamID]; ok && override > version {
+		version = override
+	}
+	if version < 1 {
+		return DefaultPolicyVersion
+	}
+	return version
+}
+
+// Loader fetches a complete Snapshot (environment, Connect-Auth, ...)
+type Loader func(ctx context.Context) (Snapshot, error)
+
+// Cache is an in-memory PolicyVersionSource. It can be refreshed from a
+// Loader (Refresh, Run) and updated incrementally (SetGlobal, SetUser), e.g.
+// from NATS events, without restarting the service.
+type Cache struct {
+	mu       sync.RWMutex
+	snapshot Snapshot
+}
+
+var _ PolicyVersionSource = (*Cache)(nil)
+
+// NewCache creates a Cache with the given global version and no overrides
+func NewCache(global int) *Cache {
+	return &Cache{snapshot: Snapshot{Global: global, Users: map[string]int{}}}
+}
+
+// PolicyVersion implements PolicyVersionSource
+func (c *Cache) PolicyVersion(_ context.Context, steamID string) (int, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.snapshot.Effective(steamID), nil
+}
+
+// Snapshot returns a copy of the current versions
+func (c *Cache) Snapshot() Snapshot {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	users := make(map[string]int, len(c.snapshot.Users))
+	for steamID, version := range c.snapshot.Users {
+		users[steamID] = version
+	}
+	return Snapshot{Global: c.snapshot.Global, Users: users}
+}
+
+// Replace swaps all versions at once
+func (c *Cache) Replace(snapshot Snapshot) {
+	users := make(map[string]int, len(snapshot.Users))
+	for steamID, version := range snapshot.Users {
+		users[steamID] = version
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.snapshot = Snapshot{Global: snapshot.Global, Users: users}
+}
+
+// SetGlobal updates the global version
+func (c *Cache) SetGlobal(version int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.snapshot.Global = version
+}
+
+// SetUser sets the override for steamID; version < 1 removes it
+func (c *Cache) SetUser(steamID string, version int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if version < 1 {
+		delete(c.snapshot.Users, steamID)
+		return
+	}
+	c.snapshot.Users[steamID] = version
+}
+
+// Refresh merges the loader's result into the cache keeping the highest
+// version of the global and of each user, so a load that lags behind an
+// incremental update (SetGlobal, SetUser from NATS) cannot undo it. Versions
+// are never lowered by Refresh; use Replace (or SetUser with 0 to drop an
+// override) for that. On error the current versions are kept.
+func (c *Cache) Refresh(ctx context.Context, load Loader) error {
+	snapshot, err := load(ctx)
+	if err != nil {
+		return err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if snapshot.Global > c.snapshot.Global {
+		c.snapshot.Global = snapshot.Global
+	}
+	if c.snapshot.Users == nil {
+		c.snapshot.Users = make(map[string]int, len(snapshot.Users))
+	}
+	for steamID, version := range snapshot.Users {
+		if version > c.snapshot.Users[steamID] {
+			c.snapshot.Users[steamID] = version
+		}
+	}
+	return nil
+}
+
+// Run refreshes the cache once and then every interval until ctx is cancelled
+// (hot reload); a non-positive interval uses DefaultRefreshInterval. Failed
+// refreshes are logged and the previous versions are kept.
+func (c *Cache) Run(ctx context.Context, load Loader, interval time.Duration) {
+	if interval <= 0 {
+		interval = DefaultRefreshInterval
+	}
+	refresh := func() {
+		if err := c.Refresh(ctx, load); err != nil && ctx.Err() == nil {
+			log.Warn().Err(err).Msg("Failed to refresh policy versions, keeping previous values")
+		}
+	}
+
+	refresh()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			refresh()
+		}
+	}
+}
+
+// EnvLoader loads the global version from POLICY_VERSION (and fallbackVars)
+// on every refresh, without per-user overrides
+func EnvLoader(fallbackVars ...string) Loader {
+	return func(context.Context) (Snapshot, error) {
+		return Snapshot{Global: LoadPolicyVersionWithFallback(fallbackVars...)}, nil
+	}
+}
+
+// maxSnapshotBytes limits the HTTP response size
+const maxSnapshotBytes = 4 << 20
+
+// HTTPLoader loads a Snapshot from Connect-Auth with GET url, expecting
+// {"global": 3, "users": {"7656...": 5}}. apiKey is sent as X-Internal-API-Key.
+func HTTPLoader(url, apiKey string, client *http.Client) Loader {
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	return func(ctx context.Context) (Snapshot, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return Snapshot{}, err
+		}
+		req.Header.Set("Accept", "application/json")
+		if apiKey != "" {
+			req.Header.Set("X-Internal-API-Key", apiKey)
+		}
+
+		resp, err := client.Do(req) // #nosec G107 -- URL comes from service configuration
+		if err != nil {
+			return Snapshot{}, err
+		}
+		defer func() { _ = resp.Body.Close() }()
+
+		if resp.StatusCode != http.StatusOK {
+			return Snapshot{}, fmt.Errorf("policy versions: unexpected status %d", resp.StatusCode)
+		}
+
+		var snapshot Snapshot
+		if err := json.NewDecoder(io.LimitReader(resp.Body, maxSnapshotBytes)).Decode(&snapshot); err != nil {
+			return Snapshot{}, fmt.Errorf("policy versions: %w", err)
+		}
+		if snapshot.Global < 1 {
+			return Snapshot{}, fmt.Errorf("policy versions: invalid global version %d", snapshot.Global)
+		}
+		return snapshot, nil
+	}
+}
@@ -0,0 +1,154 @@
+package authconfig
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestCacheEffectiveVersion(t *testing.T) {
+	ctx := context.Background()
+	cache := NewCache(2)
+	cache.SetUser("76561198000000001", 5)
+
+	if got, _ := cache.PolicyVersion(ctx, "76561198000000001"); got != 5 {
+		t.Fatalf("expected user override 5, got %d", got)
+	}
+	if got, _ := cache.PolicyVersion(ctx, "76561198000000002"); got != 2 {
+		t.Fatalf("expected global 2, got %d", got)
+	}
+
+	// A global bump above the override wins
+	cache.SetGlobal(6)
+	if got, _ := cache.PolicyVersion(ctx, "76561198000000001"); got != 6 {
+		t.Fatalf("expected global 6 over override, got %d", got)
+	}
+
+	cache.SetUser("76561198000000001", 0)
+	if _, ok := cache.Snapshot().Users["76561198000000001"]; ok {
+		t.Fatal("expected override removed")
+	}
+}
+
+func TestCacheRefresh(t *testing.T) {
+	cache := NewCache(1)
+	load := func(context.Context) (Snapshot, error) {
+		return Snapshot{Global: 3, Users: map[string]int{"76561198000000001": 4}}, nil
+	}
+	if err := cache.Refresh(context.Background(), load); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := cache.PolicyVersion(context.Background(), "76561198000000001"); got != 4 {
+		t.Fatalf("expected 4 after refresh, got %d", got)
+	}
+
+	failing := func(context.Context) (Snapshot, error) { return Snapshot{}, errors.New("unavailable") }
+	if err := cache.Refresh(context.Background(), failing); err == nil {
+		t.Fatal("expected refresh error")
+	}
+	if cache.Snapshot().Global != 3 {
+		t.Fatal("expected previous versions kept after failed refresh")
+	}
+}
+
+func TestCacheRefreshKeepsNewerIncrementalUpdates(t *testing.T) {
+	cache := NewCache(1)
+	load := func(context.Context) (Snapshot, error) {
+		return Snapshot{Global: 2, Users: map[string]int{"76561198000000001": 3, "76561198000000002": 4}}, nil
+	}
+
+	// NATS events applied before the periodic load catches up
+	cache.SetGlobal(5)
+	cache.SetUser("76561198000000001", 7)
+
+	if err := cache.Refresh(context.Background(), load); err != nil {
+		t.Fatal(err)
+	}
+	snapshot := cache.Snapshot()
+	if snapshot.Global != 5 {
+		t.Fatalf("expected newer global 5 kept, got %d", snapshot.Global)
+	}
+	if snapshot.Users["76561198000000001"] != 7 {
+		t.Fatalf("expected newer override 7 kept, got %d", snapshot.Users["76561198000000001"])
+	}
+	if snapshot.Users["76561198000000002"] != 4 {
+		t.Fatalf("expected loaded override 4, got %d", snapshot.Users["76561198000000002"])
+	}
+
+	// Only Replace lowers versions
+	cache.Replace(Snapshot{Global: 2})
+	if got := cache.Snapshot().Global; got != 2 {
+		t.Fatalf("expected Replace to lower the global version, got %d", got)
+	}
+}
+
+func TestCacheRunLoadsBeforeFirstTick(t *testing.T) {
+	cache := NewCache(1)
+	loaded := make(chan struct{}, 1)
+	load := func(context.Context) (Snapshot, error) {
+		select {
+		case loaded <- struct{}{}:
+		default:
+		}
+		return Snapshot{Global: 4}, nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		// A non-positive interval falls back to DefaultRefreshInterval
+		cache.Run(ctx, load, 0)
+		close(done)
+	}()
+
+	select {
+	case <-loaded:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected an initial refresh before the first tick")
+	}
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected Run to stop after cancellation")
+	}
+	if got := cache.Snapshot().Global; got != 4 {
+		t.Fatalf("expected global 4 after the initial refresh, got %d", got)
+	}
+}
+
+func TestEnvLoader(t *testing.T) {
+	t.Setenv("POLICY_VERSION", "")
+	t.Setenv("AUTHZ_POLICY_VERSION", "8")
+
+	snapshot, err := EnvLoader("AUTHZ_POLICY_VERSION")(context.Background())
+	if err != nil || snapshot.Global != 8 {
+		t.Fatalf("expected global 8, got %+v %v", snapshot, err)
+	}
+}
+
+func TestHTTPLoader(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Internal-API-Key") != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`{"global":3,"users":{"76561198000000001":5}}`))
+	}))
+	defer server.Close()
+
+	snapshot, err := HTTPLoader(server.URL, "secret", nil)(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if snapshot.Global != 3 || snapshot.Effective("76561198000000001") != 5 {
+		t.Fatalf("unexpected snapshot %+v", snapshot)
+	}
+
+	if _, err := HTTPLoader(server.URL, "wrong", nil)(context.Background()); err == nil {
+		t.Fatal("expected error for non-200 response")
+	}
+}
@@ -20,6 +20,12 @@ func (c *Claims) HasPermission(permission uint64) bool {
 	return (c.AllowPermissions & permission) != 0
 }
 
+// HasPermissionBit verifica un permiso por índice de bit, incluidos los
+// superiores a 63 que no entran en las máscaras uint64
+func (c *Claims) HasPermissionBit(bitIndex uint) bool {
+	return c.AllowPermissionSet.ApplyDenyMask(c.DenyPermissionSet).Has(bitIndex)
+}
+
 // IsAdmin verifica si el usuario es administrador (web_admin o web_owner)
 func (c *Claims) IsAdmin() bool {
 	return c.Role == "web_admin" || c.Role == "web_owner"
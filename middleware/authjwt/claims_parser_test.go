@@ -1,9 +1,12 @@
 package authjwt
 
 import (
+	"context"
+	"errors"
 	"testing"
 	"time"
 
+	"github.com/AoC-Gamers/connect-libraries/middleware/v2/authconfig"
 	"github.com/golang-jwt/jwt/v5"
 )
 
@@ -63,9 +66,18 @@ func TestParseAndValidate(t *testing.T) {
 		"policy_version": 2,
 		"exp":            now + 3600,
 	})
+	// Compatibilidad con v2: el puntero ErrPolicyVersionMismatch
 	if _, err := ParseAndValidate(mismatchToken, secret, 1); err != ErrPolicyVersionMismatch {
 		t.Fatalf("expected ErrPolicyVersionMismatch, got %v", err)
 	}
+	_, err := ParseAndValidateDetailed(context.Background(), mismatchToken, AuthConfig{SignerMaterial: secret, PolicyVersionGlobal: 1})
+	var mismatch *PolicyVersionMismatchError
+	if !errors.Is(err, ErrPolicyVersionMismatch) || !errors.As(err, &mismatch) {
+		t.Fatalf("expected *PolicyVersionMismatchError, got %v", err)
+	}
+	if mismatch.TokenVersion != 2 || mismatch.CurrentVersion != 1 {
+		t.Fatalf("unexpected versions %+v", mismatch)
+	}
 
 	validToken := mustSignToken(t, secret, jwt.MapClaims{
 		"steamid":           "76561198000000001",
@@ -106,6 +118,113 @@ func TestParseAndValidate(t *testing.T) {
 	if legacyValidated.GetRole() != "web_user" {
 		t.Fatalf("expected default role web_user, got %s", legacyValidated.GetRole())
 	}
+	if legacyValidated.PolicyVersion != 9 {
+		t.Fatalf("expected the expected policy version for legacy tokens, got %d", legacyValidated.PolicyVersion)
+	}
+}
+
+func TestParseAndValidatePermissionSets(t *testing.T) {
+	secret := "secret-key"
+	exp := float64(time.Now().Add(time.Hour).Unix())
+	config := AuthConfig{SignerMaterial: secret}
+	wide := PermissionSetFromWords(1<<3|1<<5, 1<<6) // bits 3, 5 y 70
+
+	cases := []struct {
+		name  string
+		allow any
+		deny  any
+	}{
+		{"encoded", wide.Encode(), PermissionSetFromMask(1 << 5).Encode()},
+		{"words", []any{float64(1<<3 | 1<<5), float64(1 << 6)}, float64(1 << 5)},
+	}
+	for _, tc := range cases {
+		token := mustSignToken(t, secret, jwt.MapClaims{
+			"steamid":           "76561198000000001",
+			"allow_permissions": tc.allow,
+			"deny_permissions":  tc.deny,
+			"exp":               exp,
+		})
+		claims, err := ParseAndValidateDetailed(context.Background(), token, config)
+		if err != nil {
+			t.Fatalf("%s: expected valid token, got %v", tc.name, err)
+		}
+		if claims.AllowPermissions != 1<<3|1<<5 || claims.DenyPermissions != 1<<5 {
+			t.Fatalf("%s: unexpected legacy masks allow=%d deny=%d", tc.name, claims.AllowPermissions, claims.DenyPermissions)
+		}
+		if !claims.HasPermissionBit(70) || !claims.HasPermissionBit(3) || claims.HasPermissionBit(5) || claims.HasPermissionBit(71) {
+			t.Fatalf("%s: unexpected permission bits %v", tc.name, claims.AllowPermissionSet.Words())
+		}
+		if !claims.HasPermission(1<<3) || claims.HasPermission(1<<5) {
+			t.Fatalf("%s: expected legacy HasPermission to use the low word", tc.name)
+		}
+	}
+
+	malformed := mustSignToken(t, secret, jwt.MapClaims{
+		"steamid":          "76561198000000001",
+		"deny_permissions": "not base64!",
+		"exp":              exp,
+	})
+	if _, err := ParseAndValidateDetailed(context.Background(), malformed, config); !errors.Is(err, ErrInvalidPermissions) {
+		t.Fatalf("expected ErrInvalidPermissions, got %v", err)
+	}
+	if _, err := ParseAndValidateWithConfig(malformed, config); err != ErrInvalidToken {
+		t.Fatalf("expected ErrInvalidToken for v2 callers, got %v", err)
+	}
+}
+
+func TestParseAndValidateClassifiesErrors(t *testing.T) {
+	secret := "secret-key"
+	now := time.Now()
+
+	cases := []struct {
+		name   string
+		token  string
+		want   error
+		legacy error
+	}{
+		{"expired", mustSignToken(t, secret, jwt.MapClaims{
+			"steamid": "76561198000000001",
+			"exp":     now.Add(-time.Minute).Unix(),
+		}), ErrTokenExpired, ErrInvalidToken},
+		{"not yet valid", mustSignToken(t, secret, jwt.MapClaims{
+			"steamid": "76561198000000001",
+			"nbf":     now.Add(time.Hour).Unix(),
+		}), ErrTokenNotYetValid, ErrInvalidToken},
+		{"issued in the future", mustSignToken(t, secret, jwt.MapClaims{
+			"steamid": "76561198000000001",
+			"iat":     now.Add(time.Hour).Unix(),
+		}), ErrTokenNotYetValid, ErrInvalidToken},
+		{"bad signature", mustSignToken(t, "other-secret", jwt.MapClaims{
+			"steamid": "76561198000000001",
+		}), ErrBadSignature, ErrInvalidToken},
+		{"expired with bad signature", mustSignToken(t, "other-secret", jwt.MapClaims{
+			"steamid": "76561198000000001",
+			"exp":     now.Add(-time.Minute).Unix(),
+		}), ErrBadSignature, ErrInvalidToken},
+		{"malformed", "a.b.c", ErrInvalidToken, ErrInvalidToken},
+	}
+
+	config := AuthConfig{SignerMaterial: secret, PolicyVersionGlobal: 1, ValidateIssuedAt: true}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := ParseAndValidateDetailed(context.Background(), tc.token, config); err != tc.want {
+				t.Fatalf("expected %v, got %v", tc.want, err)
+			}
+			// Compatibilidad con v2: ErrInvalidToken
+			if _, err := ParseAndValidateWithConfig(tc.token, config); err != tc.legacy {
+				t.Fatalf("expected %v, got %v", tc.legacy, err)
+			}
+		})
+	}
+
+	// Sin ValidateIssuedAt un iat futuro se acepta, como en v2
+	futureIat := mustSignToken(t, secret, jwt.MapClaims{
+		"steamid": "76561198000000001",
+		"iat":     now.Add(time.Hour).Unix(),
+	})
+	if _, err := ParseAndValidate(futureIat, secret, 1); err != nil {
+		t.Fatalf("expected future iat to be accepted by default, got %v", err)
+	}
 }
 
 func TestNewAuthConfig(t *testing.T) {
@@ -136,3 +255,117 @@ func mustSignToken(t *testing.T, secret string, claims jwt.MapClaims) string {
 	}
 	return signed
 }
+
+func TestStandardClaimValidation(t *testing.T) {
+	secret := "secret-key"
+	now := time.Now()
+	base := func() jwt.MapClaims {
+		return jwt.MapClaims{
+			"steamid": "76561198000000001",
+			"iss":     "connect-auth",
+			"aud":     []string{"connect-core", "connect-lobby"},
+			"iat":     now.Add(-10 * time.Minute).Unix(),
+			"exp":     now.Add(time.Hour).Unix(),
+		}
+	}
+	config := AuthConfig{
+		SignerMaterial: secret,
+		Issuers:        []string{"connect-auth"},
+		Audience:       "connect-core",
+		MaxTokenAge:    time.Hour,
+		ClockSkew:      30 * time.Second,
+		RequiredClaims: []string{"iat"},
+	}
+
+	claims, err := ParseAndValidateWithConfig(mustSignToken(t, secret, base()), config)
+	if err != nil {
+		t.Fatalf("expected valid token, got %v", err)
+	}
+	if claims.Issuer != "connect-auth" || len(claims.Audience) != 2 {
+		t.Fatalf("unexpected standard claims %+v", claims)
+	}
+
+	cases := []struct {
+		name   string
+		mutate func(jwt.MapClaims)
+		want   error
+	}{
+		{"wrong issuer", func(c jwt.MapClaims) { c["iss"] = "someone-else" }, ErrInvalidIssuer},
+		{"missing issuer", func(c jwt.MapClaims) { delete(c, "iss") }, ErrInvalidIssuer},
+		{"wrong audience", func(c jwt.MapClaims) { c["aud"] = "connect-rt" }, ErrInvalidAudience},
+		{"too old", func(c jwt.MapClaims) { c["iat"] = now.Add(-2 * time.Hour).Unix() }, ErrTokenTooOld},
+		{"missing iat", func(c jwt.MapClaims) { delete(c, "iat") }, ErrMissingClaim},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := base()
+			tc.mutate(c)
+			_, err := ParseAndValidateWithConfig(mustSignToken(t, secret, c), config)
+			if !errors.Is(err, tc.want) || !IsClaimError(err) {
+				t.Fatalf("expected %v, got %v", tc.want, err)
+			}
+		})
+	}
+
+	// Audience como string y nbf dentro de la tolerancia
+	c := base()
+	c["aud"] = "connect-core"
+	c["nbf"] = now.Add(10 * time.Second).Unix()
+	if _, err := ParseAndValidateWithConfig(mustSignToken(t, secret, c), config); err != nil {
+		t.Fatalf("expected nbf within clock skew to pass, got %v", err)
+	}
+
+	// Sin opciones configuradas no se validan iss ni aud
+	if _, err := ParseAndValidate(mustSignToken(t, secret, jwt.MapClaims{"steamid": "76561198000000001", "aud": "x"}), secret, 1); err != nil {
+		t.Fatalf("expected token without standard claim options to pass, got %v", err)
+	}
+	if IsClaimError(ErrInvalidToken) {
+		t.Fatal("ErrInvalidToken is not a claim error")
+	}
+}
+
+type failingSource struct{}
+
+func (failingSource) PolicyVersion(context.Context, string) (int, error) {
+	return 0, errors.New("source unavailable")
+}
+
+func TestPolicyVersionSource(t *testing.T) {
+	const secret = "jwt-secret"
+	cache := authconfig.NewCache(1)
+	cache.SetUser("76561198000000001", 2)
+	config := AuthConfig{SignerMaterial: secret, PolicyVersionGlobal: 1, PolicyVersions: cache}
+
+	token := func(steamID string, version int) string {
+		return mustSignToken(t, secret, jwt.MapClaims{"steamid": steamID, "policy_version": version})
+	}
+
+	// El override por usuario invalida sus tokens anteriores sin afectar al resto
+	var mismatch *PolicyVersionMismatchError
+	_, err := ParseAndValidateDetailed(context.Background(), token("76561198000000001", 1), config)
+	if !errors.As(err, &mismatch) || mismatch.CurrentVersion != 2 {
+		t.Fatalf("expected mismatch against override 2, got %v", err)
+	}
+	if _, err := ParseAndValidateDetailed(context.Background(), token("76561198000000001", 2), config); err != nil {
+		t.Fatalf("expected token with override version to pass, got %v", err)
+	}
+	if _, err := ParseAndValidateDetailed(context.Background(), token("76561198000000002", 1), config); err != nil {
+		t.Fatalf("expected other user to pass with global version, got %v", err)
+	}
+
+	// Recarga en caliente: el cambio aplica sin reconstruir la configuración
+	cache.SetGlobal(3)
+	if _, err := ParseAndValidateDetailed(context.Background(), token("76561198000000002", 1), config); !errors.Is(err, ErrPolicyVersionMismatch) {
+		t.Fatalf("expected mismatch after global bump, got %v", err)
+	}
+
+	// Si la fuente falla se rechaza, salvo con PolicyVersionFailOpen
+	config.PolicyVersions = failingSource{}
+	if _, err := ParseAndValidateDetailed(context.Background(), token("76561198000000001", 1), config); !errors.Is(err, ErrPolicyVersionUnavailable) {
+		t.Fatalf("expected fail-closed, got %v", err)
+	}
+	config.PolicyVersionFailOpen = true
+	if _, err := ParseAndValidateDetailed(context.Background(), token("76561198000000001", 1), config); err != nil {
+		t.Fatalf("expected fallback to global version, got %v", err)
+	}
+}
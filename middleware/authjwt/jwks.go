@@ -0,0 +1,359 @@
+package authjwt
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// DefaultJWKSRefreshInterval es cada cuánto se recarga el documento JWKS
+	DefaultJWKSRefreshInterval = 15 * time.Minute
+
+	// DefaultJWKSMinRefreshInterval limita las recargas forzadas por un kid desconocido
+	DefaultJWKSMinRefreshInterval = 30 * time.Second
+
+	// DefaultJWKSRequestTimeout es cuánto espera un request una descarga del JWKS
+	DefaultJWKSRequestTimeout = 3 * time.Second
+
+	maxJWKSBytes = 1 << 20
+)
+
+// JWKSOptions configura un JWKSKeySet. Se usa URL o File, no ambos.
+type JWKSOptions struct {
+	// URL del documento JWKS (ej: https://auth.example.com/.well-known/jwks.json)
+	URL string
+
+	// File es la ruta de un documento JWKS local
+	File string
+
+	// RefreshInterval es la vigencia del caché (default DefaultJWKSRefreshInterval)
+	RefreshInterval time.Duration
+
+	// MinRefreshInterval es el tiempo mínimo entre recargas cuando llega un kid
+	// desconocido (default DefaultJWKSMinRefreshInterval)
+	MinRefreshInterval time.Duration
+
+	// RequestTimeout es cuánto espera un request la descarga antes de usar
+	// las claves en caché o fallar con ErrKeyUnavailable (default
+	// DefaultJWKSRequestTimeout). La descarga sigue para los demás requests,
+	// acotada por el timeout de HTTPClient.
+	RequestTimeout time.Duration
+
+	// HTTPClient para descargar el JWKS (default: timeout de 10s)
+	HTTPClient *http.Client
+}
+
+// JWKSKeySet es un KeySet respaldado por un documento JWKS con caché. Las
+// claves se cargan en el primer uso, se recargan al vencer RefreshInterval y
+// ante un kid desconocido (rotación). Si una recarga falla se siguen usando
+// las claves anteriores. La descarga se hace sin tomar el lock: los requests
+// concurrentes esperan una única descarga en curso y, tras un fallo (incluida
+// la primera carga), no se reintenta antes de MinRefreshInterval.
+//
+// En el request path usar VerificationKeyContext (lo hacen los parsers): el
+// request espera como máximo RequestTimeout y, si no hay claves que lo
+// verifiquen por un fallo de descarga, recibe ErrKeyUnavailable.
+type JWKSKeySet struct {
+	opts JWKSOptions
+	now  func() time.Time
+
+	mu          sync.Mutex
+	keys        map[string]crypto.PublicKey
+	fetchedAt   time.Time
+	attemptedAt time.Time
+	lastErr     error
+	inflight    *jwksFetch
+}
+
+// jwksFetch es una descarga en curso compartida por los requests que la esperan
+type jwksFetch struct {
+	done chan struct{}
+	err  error
+}
+
+// NewJWKSKeySet crea un JWKSKeySet. No descarga el documento; usar Refresh
+// para precargarlo al iniciar el servicio.
+func NewJWKSKeySet(opts JWKSOptions) (*JWKSKeySet, error) {
+	if (opts.URL == "") == (opts.File == "") {
+		return nil, errors.New("jwks: exactly one of URL or File is required")
+	}
+	if opts.RefreshInterval <= 0 {
+		opts.RefreshInterval = DefaultJWKSRefreshInterval
+	}
+	if opts.MinRefreshInterval <= 0 {
+		opts.MinRefreshInterval = DefaultJWKSMinRefreshInterval
+	}
+	if opts.RequestTimeout <= 0 {
+		opts.RequestTimeout = DefaultJWKSRequestTimeout
+	}
+	if opts.HTTPClient == nil {
+		opts.HTTPClient = &http.Client{Timeout: 10 * time.Second}
+	}
+	return &JWKSKeySet{opts: opts, now: time.Now}, nil
+}
+
+// Refresh descarga y reemplaza las claves. Si ya hay una descarga en curso
+// espera su resultado en lugar de iniciar otra.
+func (s *JWKSKeySet) Refresh(ctx context.Context) error {
+	return s.refreshIf(ctx, func(time.Time) bool { return true })
+}
+
+// VerificationKey implementa KeySet, sin contexto de request
+func (s *JWKSKeySet) VerificationKey(kid, alg string) (crypto.PublicKey, error) {
+	return s.VerificationKeyContext(context.Background(), kid, alg)
+}
+
+// VerificationKeyContext implementa ContextKeySet. Espera las descargas como
+// máximo RequestTimeout (o hasta que ctx se cancele); si no hay claves por un
+// fallo de descarga retorna ErrKeyUnavailable envolviendo la causa.
+func (s *JWKSKeySet) VerificationKeyContext(ctx context.Context, kid, alg string) (crypto.PublicKey, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.opts.RequestTimeout)
+	defer cancel()
+
+	// Recarga por vencimiento, con backoff si el último intento falló
+	_ = s.refreshIf(ctx, func(now time.Time) bool {
+		return (s.keys == nil || now.Sub(s.fetchedAt) >= s.opts.RefreshInterval) &&
+			now.Sub(s.attemptedAt) >= s.opts.MinRefreshInterval
+	})
+
+	keys, err := s.snapshot()
+	if keys == nil {
+		if errors.Is(err, ErrKeyNotFound) && ctx.Err() != nil {
+			err = ctx.Err()
+		}
+		return nil, fmt.Errorf("%w: %w", ErrKeyUnavailable, err)
+	}
+	key, err := selectKey(keys, kid, alg)
+	if !errors.Is(err, ErrKeyNotFound) {
+		return key, err
+	}
+
+	// Kid desconocido: posible rotación de claves
+	refreshErr := s.refreshIf(ctx, func(now time.Time) bool {
+		return now.Sub(s.attemptedAt) >= s.opts.MinRefreshInterval
+	})
+	if refreshErr != nil {
+		return nil, fmt.Errorf("%w: %w", ErrKeyUnavailable, refreshErr)
+	}
+	keys, _ = s.snapshot()
+	return selectKey(keys, kid, alg)
+}
+
+// snapshot retorna las claves vigentes o, si nunca se cargaron, el error del
+// último intento
+func (s *JWKSKeySet) snapshot() (map[string]crypto.PublicKey, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.keys == nil {
+		if s.lastErr != nil {
+			return nil, s.lastErr
+		}
+		return nil, ErrKeyNotFound
+	}
+	return s.keys, nil
+}
+
+// refreshIf inicia una descarga si due (evaluado con el lock tomado) lo indica,
+// o se une a la que esté en curso. El documento se descarga sin el lock y las
+// claves se reemplazan con el lock tomado. La descarga conserva los valores de
+// ctx pero no su cancelación, para que un request cancelado no haga fallar a
+// los demás que la esperan; ctx solo limita la espera.
+func (s *JWKSKeySet) refreshIf(ctx context.Context, due func(now time.Time) bool) error {
+	s.mu.Lock()
+	call := s.inflight
+	if call == nil {
+		if !due(s.now()) {
+			s.mu.Unlock()
+			return nil
+		}
+		call = &jwksFetch{done: make(chan struct{})}
+		s.inflight = call
+		s.mu.Unlock()
+		go s.load(context.WithoutCancel(ctx), call)
+	} else {
+		s.mu.Unlock()
+	}
+
+	select {
+	case <-call.done:
+		return call.err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s *JWKSKeySet) load(ctx context.Context, call *jwksFetch) {
+	data, err := s.fetch(ctx)
+	var keys map[string]crypto.PublicKey
+	if err == nil {
+		keys, err = ParseJWKS(data)
+	}
+
+	s.mu.Lock()
+	now := s.now()
+	s.attemptedAt = now
+	s.lastErr = err
+	if err == nil {
+		s.keys = keys
+		s.fetchedAt = now
+	}
+	cached := s.keys != nil
+	s.inflight = nil
+	s.mu.Unlock()
+
+	call.err = err
+	close(call.done)
+
+	if err != nil {
+		log.Warn().
+			Err(err).
+			Str("url", s.opts.URL).
+			Str("file", s.opts.File).
+			Bool("using_cached_keys", cached).
+			Msg("[JWT] Failed to refresh JWKS")
+	}
+}
+
+func (s *JWKSKeySet) fetch(ctx context.Context) ([]byte, error) {
+	if s.opts.File != "" {
+		return os.ReadFile(s.opts.File)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.opts.URL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := s.opts.HTTPClient.Do(req) // #nosec G107 -- URL provista por la configuración del servicio
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("jwks: unexpected status %d", resp.StatusCode)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, maxJWKSBytes))
+}
+
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Crv string `json:"crv"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// ParseJWKS parsea un documento JWKS ({"keys": [...]}) con claves RSA, EC
+// (P-256, P-384, P-521) y OKP (Ed25519). Las claves de cifrado (use "enc") y
+// los tipos no soportados se ignoran.
+func ParseJWKS(data []byte) (map[string]crypto.PublicKey, error) {
+	var doc struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("jwks: %w", err)
+	}
+
+	keys := make(map[string]crypto.PublicKey, len(doc.Keys))
+	for _, jwk := range doc.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		key, err := jwk.publicKey()
+		if err != nil {
+			log.Warn().Err(err).Str("kid", jwk.Kid).Msg("[JWT] Skipping JWKS key")
+			continue
+		}
+		keys[jwk.Kid] = key
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("jwks: no usable signing keys")
+	}
+	return keys, nil
+}
+
+func (k jsonWebKey) publicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeBase64URL(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeBase64URL(k.E)
+		if err != nil {
+			return nil, err
+		}
+		exponent := new(big.Int).SetBytes(e)
+		if len(n) == 0 || !exponent.IsInt64() || exponent.Int64() < 3 || exponent.Int64() > 1<<31-1 {
+			return nil, errors.New("invalid RSA key")
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(exponent.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported EC curve %q", k.Crv)
+		}
+		x, err := decodeBase64URL(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeBase64URL(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		size := (curve.Params().BitSize + 7) / 8
+		if len(x) > size || len(y) > size {
+			return nil, errors.New("invalid EC key")
+		}
+		point := make([]byte, 1+2*size)
+		point[0] = 4
+		copy(point[1+size-len(x):1+size], x)
+		copy(point[1+2*size-len(y):], y)
+		return ecdsa.ParseUncompressedPublicKey(curve, point)
+	case "OKP":
+		if k.Crv != "Ed25519" {
+			return nil, fmt.Errorf("unsupported OKP curve %q", k.Crv)
+		}
+		x, err := decodeBase64URL(k.X)
+		if err != nil {
+			return nil, err
+		}
+		if len(x) != ed25519.PublicKeySize {
+			return nil, errors.New("invalid Ed25519 key")
+		}
+		return ed25519.PublicKey(x), nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", k.Kty)
+	}
+}
+
+func decodeBase64URL(value string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(value)
+}
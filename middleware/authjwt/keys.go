@@ -0,0 +1,145 @@
+package authjwt
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Algoritmos asimétricos aceptados por defecto cuando AuthConfig.Keys está configurado
+var DefaultAsymmetricAlgorithms = []string{"RS256", "ES256", "EdDSA"}
+
+// ErrKeyNotFound indica que ninguna clave del KeySet corresponde al kid/algoritmo del token
+var ErrKeyNotFound = errors.New("verification key not found")
+
+// KeySet resuelve la clave pública que verifica un token según su header
+// `kid` y su algoritmo. Implementado por StaticKeySet (PEM) y JWKSKeySet.
+type KeySet interface {
+	VerificationKey(kid, alg string) (crypto.PublicKey, error)
+}
+
+// ContextKeySet es un KeySet que resuelve claves con el contexto de la
+// request (ej: JWKSKeySet, que puede descargar el documento). Los parsers lo
+// usan cuando AuthConfig.Keys lo implementa.
+type ContextKeySet interface {
+	KeySet
+	VerificationKeyContext(ctx context.Context, kid, alg string) (crypto.PublicKey, error)
+}
+
+// StaticKeySet es un conjunto fijo de claves públicas indexadas por kid
+type StaticKeySet struct {
+	keys map[string]crypto.PublicKey
+}
+
+// NewStaticKeySet crea un KeySet con claves indexadas por kid. La clave con
+// kid "" se usa para tokens sin header kid.
+func NewStaticKeySet(keys map[string]crypto.PublicKey) *StaticKeySet {
+	copied := make(map[string]crypto.PublicKey, len(keys))
+	for kid, key := range keys {
+		copied[kid] = key
+	}
+	return &StaticKeySet{keys: copied}
+}
+
+// LoadPEMKeySet lee una clave pública PEM desde un archivo (ver ParsePEMKeySet)
+func LoadPEMKeySet(path, kid string) (*StaticKeySet, error) {
+	data, err := os.ReadFile(path) // #nosec G304 -- ruta provista por la configuración del servicio
+	if err != nil {
+		return nil, fmt.Errorf("read PEM key: %w", err)
+	}
+	return ParsePEMKeySet(data, kid)
+}
+
+// ParsePEMKeySet parsea una clave pública PEM (PUBLIC KEY, RSA PUBLIC KEY o
+// CERTIFICATE) de tipo RSA, ECDSA o Ed25519 y la registra con el kid indicado.
+// Con kid "" la clave verifica cualquier token de un algoritmo compatible.
+func ParsePEMKeySet(data []byte, kid string) (*StaticKeySet, error) {
+	key, err := parsePEMPublicKey(data)
+	if err != nil {
+		return nil, err
+	}
+	return NewStaticKeySet(map[string]crypto.PublicKey{kid: key}), nil
+}
+
+// VerificationKey implementa KeySet
+func (s *StaticKeySet) VerificationKey(kid, alg string) (crypto.PublicKey, error) {
+	return selectKey(s.keys, kid, alg)
+}
+
+// selectKey busca por kid y, si no coincide, usa la clave sin kid
+func selectKey(keys map[string]crypto.PublicKey, kid, alg string) (crypto.PublicKey, error) {
+	if key, ok := keys[kid]; ok && keyMatchesAlgorithm(key, alg) {
+		return key, nil
+	}
+	if key, ok := keys[""]; ok && keyMatchesAlgorithm(key, alg) {
+		return key, nil
+	}
+	return nil, fmt.Errorf("%w: kid=%q alg=%s", ErrKeyNotFound, kid, alg)
+}
+
+func parsePEMPublicKey(data []byte) (crypto.PublicKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("invalid PEM data")
+	}
+
+	var (
+		key crypto.PublicKey
+		err error
+	)
+	switch block.Type {
+	case "PUBLIC KEY":
+		key, err = x509.ParsePKIXPublicKey(block.Bytes)
+	case "RSA PUBLIC KEY":
+		key, err = x509.ParsePKCS1PublicKey(block.Bytes)
+	case "CERTIFICATE":
+		var cert *x509.Certificate
+		cert, err = x509.ParseCertificate(block.Bytes)
+		if err == nil {
+			key = cert.PublicKey
+		}
+	default:
+		return nil, fmt.Errorf("unsupported PEM block %q", block.Type)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parse PEM key: %w", err)
+	}
+
+	switch key.(type) {
+	case *rsa.PublicKey, *ecdsa.PublicKey, ed25519.PublicKey:
+		return key, nil
+	default:
+		return nil, fmt.Errorf("unsupported public key type %T", key)
+	}
+}
+
+// keyMatchesAlgorithm verifica que el tipo de clave corresponda al algoritmo
+// del token, evitando confusiones de algoritmo (ej: RS256 firmado con ES256)
+func keyMatchesAlgorithm(key crypto.PublicKey, alg string) bool {
+	switch k := key.(type) {
+	case *rsa.PublicKey:
+		return strings.HasPrefix(alg, "RS") || strings.HasPrefix(alg, "PS")
+	case *ecdsa.PublicKey:
+		switch alg {
+		case "ES256":
+			return k.Curve.Params().BitSize == 256
+		case "ES384":
+			return k.Curve.Params().BitSize == 384
+		case "ES512":
+			return k.Curve.Params().BitSize == 521
+		}
+		return false
+	case ed25519.PublicKey:
+		return alg == "EdDSA"
+	default:
+		return false
+	}
+}
@@ -0,0 +1,335 @@
+package authjwt
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func validClaims() jwt.MapClaims {
+	return jwt.MapClaims{
+		"steamid":        "76561198000000001",
+		"policy_version": 1,
+		"exp":            time.Now().Add(time.Hour).Unix(),
+	}
+}
+
+func signWith(t *testing.T, method jwt.SigningMethod, kid string, key crypto.PrivateKey) string {
+	t.Helper()
+	token := jwt.NewWithClaims(method, validClaims())
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+	signed, err := token.SignedString(key)
+	if err != nil {
+		t.Fatalf("could not sign token: %v", err)
+	}
+	return signed
+}
+
+func TestParseAndValidateWithPEMKey(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(&rsaKey.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "auth.pem")
+	if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	keys, err := LoadPEMKeySet(path, "")
+	if err != nil {
+		t.Fatalf("LoadPEMKeySet: %v", err)
+	}
+	config := AuthConfig{Keys: keys, PolicyVersionGlobal: 1}
+
+	claims, err := ParseAndValidateWithConfig(signWith(t, jwt.SigningMethodRS256, "any", rsaKey), config)
+	if err != nil {
+		t.Fatalf("expected RS256 token to validate, got %v", err)
+	}
+	if claims.SteamID != "76561198000000001" {
+		t.Fatalf("unexpected steam id %s", claims.SteamID)
+	}
+
+	// Sin SignerMaterial no se aceptan tokens HMAC
+	if _, err := ParseAndValidateWithConfig(mustSignToken(t, "secret", validClaims()), config); err != ErrInvalidToken {
+		t.Fatalf("expected HMAC token to be rejected, got %v", err)
+	}
+
+	// Con HMAC únicamente no se aceptan tokens RS256
+	if _, err := ParseAndValidate(signWith(t, jwt.SigningMethodRS256, "", rsaKey), "secret", 1); err != ErrInvalidToken {
+		t.Fatalf("expected RS256 token to be rejected by HMAC config, got %v", err)
+	}
+
+	// Algoritmo fuera de la lista permitida
+	restricted := AuthConfig{Keys: keys, PolicyVersionGlobal: 1, Algorithms: []string{"ES256"}}
+	if _, err := ParseAndValidateWithConfig(signWith(t, jwt.SigningMethodRS256, "", rsaKey), restricted); err != ErrInvalidToken {
+		t.Fatalf("expected RS256 to be rejected, got %v", err)
+	}
+}
+
+func TestParseAndValidateWithJWKS(t *testing.T) {
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	edPublic, edPrivate, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var rotated atomic.Bool
+	var fetches atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetches.Add(1)
+		keys := []map[string]string{ecJWK("ec-1", &ecKey.PublicKey)}
+		if rotated.Load() {
+			keys = append(keys, map[string]string{"kty": "OKP", "crv": "Ed25519", "kid": "ed-1", "use": "sig", "x": b64(edPublic)})
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"keys": keys})
+	}))
+	defer server.Close()
+
+	keys, err := NewJWKSKeySet(JWKSOptions{URL: server.URL, MinRefreshInterval: time.Nanosecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+	config := AuthConfig{Keys: keys, PolicyVersionGlobal: 1}
+
+	if _, err := ParseAndValidateWithConfig(signWith(t, jwt.SigningMethodES256, "ec-1", ecKey), config); err != nil {
+		t.Fatalf("expected ES256 token to validate, got %v", err)
+	}
+	if _, err := ParseAndValidateWithConfig(signWith(t, jwt.SigningMethodEdDSA, "ed-1", edPrivate), config); err != ErrInvalidToken {
+		t.Fatalf("expected unknown kid to be rejected, got %v", err)
+	}
+
+	// Rotación: el kid desconocido fuerza la recarga del JWKS
+	rotated.Store(true)
+	if _, err := ParseAndValidateWithConfig(signWith(t, jwt.SigningMethodEdDSA, "ed-1", edPrivate), config); err != nil {
+		t.Fatalf("expected EdDSA token after rotation, got %v", err)
+	}
+
+	before := fetches.Load()
+	if _, err := ParseAndValidateWithConfig(signWith(t, jwt.SigningMethodES256, "ec-1", ecKey), config); err != nil {
+		t.Fatalf("expected cached key, got %v", err)
+	}
+	if fetches.Load() != before {
+		t.Fatal("expected cached JWKS to be reused")
+	}
+
+	// La clave EC no verifica tokens que dicen ser EdDSA con su kid
+	if _, err := keys.VerificationKey("ec-1", "EdDSA"); err == nil {
+		t.Fatal("expected algorithm/key type mismatch to fail")
+	}
+}
+
+func TestJWKSKeySetFromFileKeepsKeysOnFailure(t *testing.T) {
+	ecKey, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	doc, _ := json.Marshal(map[string]interface{}{"keys": []map[string]string{ecJWK("ec-384", &ecKey.PublicKey)}})
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	if err := os.WriteFile(path, doc, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	keys, err := NewJWKSKeySet(JWKSOptions{File: path, RefreshInterval: time.Nanosecond, MinRefreshInterval: time.Nanosecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+	config := AuthConfig{Keys: keys, PolicyVersionGlobal: 1, Algorithms: []string{"ES384"}}
+	token := signWith(t, jwt.SigningMethodES384, "ec-384", ecKey)
+	if _, err := ParseAndValidateWithConfig(token, config); err != nil {
+		t.Fatalf("expected ES384 token to validate, got %v", err)
+	}
+
+	if err := os.WriteFile(path, []byte("not json"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ParseAndValidateWithConfig(token, config); err != nil {
+		t.Fatalf("expected cached keys after failed refresh, got %v", err)
+	}
+
+	if _, err := NewJWKSKeySet(JWKSOptions{}); err == nil {
+		t.Fatal("expected error without URL or File")
+	}
+}
+
+func TestJWKSKeySetSharesConcurrentFetch(t *testing.T) {
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	release := make(chan struct{})
+	var fetches atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetches.Add(1)
+		<-release
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"keys": []map[string]string{ecJWK("ec-1", &ecKey.PublicKey)}})
+	}))
+	defer server.Close()
+
+	keys, err := NewJWKSKeySet(JWKSOptions{URL: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const callers = 8
+	errs := make(chan error, callers)
+	for i := 0; i < callers; i++ {
+		go func() {
+			_, err := keys.VerificationKey("ec-1", "ES256")
+			errs <- err
+		}()
+	}
+
+	// Mientras la descarga está bloqueada el key set no queda tomado
+	deadline := time.Now().Add(5 * time.Second)
+	for fetches.Load() == 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	done := make(chan struct{})
+	go func() {
+		_, _ = keys.snapshot()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the key set lock to be free during the fetch")
+	}
+
+	close(release)
+	for i := 0; i < callers; i++ {
+		if err := <-errs; err != nil {
+			t.Fatalf("expected key after shared fetch, got %v", err)
+		}
+	}
+	if got := fetches.Load(); got != 1 {
+		t.Fatalf("expected a single fetch, got %d", got)
+	}
+}
+
+func TestJWKSKeySetBacksOffAfterFailedInitialLoad(t *testing.T) {
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var healthy atomic.Bool
+	var fetches atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetches.Add(1)
+		if !healthy.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"keys": []map[string]string{ecJWK("ec-1", &ecKey.PublicKey)}})
+	}))
+	defer server.Close()
+
+	keys, err := NewJWKSKeySet(JWKSOptions{URL: server.URL, MinRefreshInterval: time.Minute})
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	keys.now = func() time.Time { return now }
+
+	for i := 0; i < 3; i++ {
+		if _, err := keys.VerificationKey("ec-1", "ES256"); err == nil {
+			t.Fatal("expected error while the JWKS endpoint fails")
+		}
+	}
+	if got := fetches.Load(); got != 1 {
+		t.Fatalf("expected failed initial load to back off, got %d fetches", got)
+	}
+
+	healthy.Store(true)
+	now = now.Add(time.Minute)
+	if _, err := keys.VerificationKey("ec-1", "ES256"); err != nil {
+		t.Fatalf("expected retry after MinRefreshInterval, got %v", err)
+	}
+	if got := fetches.Load(); got != 2 {
+		t.Fatalf("expected one retry, got %d fetches", got)
+	}
+}
+
+func TestJWKSKeySetBoundsRequestWait(t *testing.T) {
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	keys, err := NewJWKSKeySet(JWKSOptions{URL: server.URL, RequestTimeout: 20 * time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+	config := AuthConfig{Keys: keys, PolicyVersionGlobal: 1}
+	token := signWith(t, jwt.SigningMethodES256, "ec-1", ecKey)
+
+	start := time.Now()
+	_, err = ParseAndValidateDetailed(context.Background(), token, config)
+	if !errors.Is(err, ErrKeyUnavailable) {
+		t.Fatalf("expected ErrKeyUnavailable while the JWKS endpoint hangs, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("expected the request to wait at most RequestTimeout, waited %s", elapsed)
+	}
+
+	// Un request cancelado deja de esperar de inmediato
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := keys.VerificationKeyContext(ctx, "ec-1", "ES256"); !errors.Is(err, ErrKeyUnavailable) || !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected ErrKeyUnavailable wrapping context.Canceled, got %v", err)
+	}
+}
+
+func ecJWK(kid string, key *ecdsa.PublicKey) map[string]string {
+	point, _ := key.Bytes()
+	size := (len(point) - 1) / 2
+	return map[string]string{
+		"kty": "EC",
+		"crv": key.Curve.Params().Name,
+		"kid": kid,
+		"x":   b64(new(big.Int).SetBytes(point[1 : 1+size]).Bytes()),
+		"y":   b64(point[1+size:]),
+	}
+}
+
+func b64(data []byte) string {
+	return base64.RawURLEncoding.EncodeToString(data)
+}
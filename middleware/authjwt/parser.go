@@ -1,12 +1,49 @@
 package authjwt
 
 import (
+	"context"
+	"crypto"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/rs/zerolog/log"
 )
 
-// ParseAndValidate extrae y valida un JWT token retornando claims tipados
+// hmacAlgorithms son los algoritmos aceptados con SignerMaterial
+var hmacAlgorithms = []string{"HS256", "HS384", "HS512"}
+
+// ParseAndValidate extrae y valida un JWT token firmado con HMAC retornando claims tipados
 func ParseAndValidate(tokenStr, secret string, expectedPolicyVersion int) (*Claims, error) {
+	return ParseAndValidateWithConfig(tokenStr, AuthConfig{
+		SignerMaterial:      secret,
+		PolicyVersionGlobal: expectedPolicyVersion,
+	})
+}
+
+// ParseAndValidateWithConfig extrae y valida un JWT token según la configuración:
+// HMAC con SignerMaterial y/o RS256/ES256/EdDSA con las claves de Keys
+func ParseAndValidateWithConfig(tokenStr string, config AuthConfig) (*Claims, error) {
+	return ParseAndValidateContext(context.Background(), tokenStr, config)
+}
+
+// ParseAndValidateContext es ParseAndValidateWithConfig con el contexto usado
+// para consultar config.PolicyVersions. Como el resto de las funciones de v2
+// retorna ErrInvalidToken para tokens expirados, con firma inválida o aún no
+// válidos, y el puntero ErrPolicyVersionMismatch; usar ParseAndValidateDetailed
+// para distinguirlos.
+func ParseAndValidateContext(ctx context.Context, tokenStr string, config AuthConfig) (*Claims, error) {
+	claims, err := ParseAndValidateDetailed(ctx, tokenStr, config)
+	return claims, compatibleError(err)
+}
+
+// ParseAndValidateDetailed es ParseAndValidateContext con errores tipados:
+// ErrTokenExpired, ErrBadSignature, ErrTokenNotYetValid y
+// *PolicyVersionMismatchError (errors.Is(err, ErrPolicyVersionMismatch)) con
+// las versiones del token y la esperada. Es la que usan RequireAuth y OptionalAuth.
+func ParseAndValidateDetailed(ctx context.Context, tokenStr string, config AuthConfig) (*Claims, error) {
 	if tokenStr == "" {
 		log.Error().Msg("[JWT] Missing token")
 		return nil, ErrMissingToken
@@ -14,22 +51,15 @@ func ParseAndValidate(tokenStr, secret string, expectedPolicyVersion int) (*Clai
 
 	claims := jwt.MapClaims{}
 	token, err := jwt.ParseWithClaims(tokenStr, claims, func(tok *jwt.Token) (any, error) {
-		// Validar el algoritmo de firma
-		if _, ok := tok.Method.(*jwt.SigningMethodHMAC); !ok {
-			log.Error().
-				Str("algorithm", tok.Method.Alg()).
-				Msg("[JWT] Unexpected signing method")
-			return nil, ErrInvalidToken
-		}
-		return []byte(secret), nil
-	})
+		return verificationKey(ctx, tok, config)
+	}, config.parserOptions()...)
 
 	if err != nil {
 		log.Error().
 			Err(err).
 			Bool("token_valid", token != nil && token.Valid).
 			Msg("[JWT] Failed to parse token")
-		return nil, ErrInvalidToken
+		return nil, classifyParseError(err)
 	}
 
 	if !token.Valid {
@@ -37,6 +67,15 @@ func ParseAndValidate(tokenStr, secret string, expectedPolicyVersion int) (*Clai
 		return nil, ErrInvalidToken
 	}
 
+	if err := validateStandardClaims(claims, config, time.Now()); err != nil {
+		log.Error().
+			Err(err).
+			Strs("issuers", config.Issuers).
+			Str("audience", config.Audience).
+			Msg("[JWT] Standard claim validation failed")
+		return nil, err
+	}
+
 	// Extract steamID with compatibility fallback
 	steamID := extractSteamID(claims)
 	if steamID == "" {
@@ -47,35 +86,49 @@ func ParseAndValidate(tokenStr, secret string, expectedPolicyVersion int) (*Clai
 	}
 
 	// Validate policy version if present
+	expectedPolicyVersion, err := resolvePolicyVersion(ctx, config, steamID)
+	if err != nil {
+		return nil, err
+	}
 	if err := validatePolicyVersion(claims, expectedPolicyVersion); err != nil {
-		// Extraer la versión del token para el log
-		tokenPolicyVersion := -1
-		if pv, ok := claims["policy_version"].(float64); ok {
-			tokenPolicyVersion = int(pv)
-		}
-		log.Error().
-			Err(err).
-			Int("expected", expectedPolicyVersion).
-			Int("token_version", tokenPolicyVersion).
-			Msg("[JWT] Policy version mismatch")
 		return nil, err
 	}
 
 	// Extract role and permissions (bitmask)
 	role := extractRole(claims)
-	allowPermissions := extractAllowPermissions(claims)
-	denyPermissions := extractDenyPermissions(claims)
+	allowPermissions, err := PermissionSetFromClaim(claims["allow_permissions"])
+	if err != nil {
+		log.Error().Err(err).Msg("[JWT] Invalid allow_permissions claim")
+		return nil, err
+	}
+	denyPermissions, err := PermissionSetFromClaim(claims["deny_permissions"])
+	if err != nil {
+		log.Error().Err(err).Msg("[JWT] Invalid deny_permissions claim")
+		return nil, err
+	}
+	allowMask, _ := allowPermissions.Mask()
+	denyMask, _ := denyPermissions.Mask()
 	iat := extractIat(claims)
 	exp := extractExp(claims)
+	iss, _ := claims.GetIssuer()
+	aud, _ := claims.GetAudience()
+	nbf := extractNumericClaim(claims, "nbf")
+	jti, _ := claims["jti"].(string)
 
 	return &Claims{
-		SteamID:          steamID,
-		PolicyVersion:    expectedPolicyVersion,
-		Role:             role,
-		AllowPermissions: allowPermissions,
-		DenyPermissions:  denyPermissions,
-		IssuedAt:         iat,
-		ExpiresAt:        exp,
+		SteamID:            steamID,
+		PolicyVersion:      expectedPolicyVersion,
+		Role:               role,
+		AllowPermissions:   allowMask,
+		DenyPermissions:    denyMask,
+		AllowPermissionSet: allowPermissions,
+		DenyPermissionSet:  denyPermissions,
+		ID:                 jti,
+		Issuer:             iss,
+		Audience:           aud,
+		IssuedAt:           iat,
+		ExpiresAt:          exp,
+		NotBefore:          nbf,
 	}, nil
 }
 
@@ -95,22 +148,6 @@ func extractRole(claims jwt.MapClaims) string {
 	return "web_user" // Default role
 }
 
-// extractAllowPermissions extrae la máscara de permisos permitidos
-func extractAllowPermissions(claims jwt.MapClaims) uint64 {
-	if allowPermissions, ok := claims["allow_permissions"].(float64); ok {
-		return uint64(allowPermissions)
-	}
-	return 0
-}
-
-// extractDenyPermissions extrae la máscara de permisos denegados
-func extractDenyPermissions(claims jwt.MapClaims) uint64 {
-	if denyPermissions, ok := claims["deny_permissions"].(float64); ok {
-		return uint64(denyPermissions)
-	}
-	return 0
-}
-
 // extractIat extrae el timestamp de emisión
 func extractIat(claims jwt.MapClaims) int64 {
 	if iat, ok := claims["iat"].(float64); ok {
@@ -119,6 +156,14 @@ func extractIat(claims jwt.MapClaims) int64 {
 	return 0
 }
 
+// extractNumericClaim extrae un claim numérico (Unix timestamp)
+func extractNumericClaim(claims jwt.MapClaims, name string) int64 {
+	if value, ok := claims[name].(float64); ok {
+		return int64(value)
+	}
+	return 0
+}
+
 // extractExp extrae el timestamp de expiración
 func extractExp(claims jwt.MapClaims) int64 {
 	if exp, ok := claims["exp"].(float64); ok {
@@ -144,7 +189,143 @@ func validatePolicyVersion(claims jwt.MapClaims, expectedVersion int) error {
 			Int("expected", expectedVersion).
 			Int("token_version", tokenVersion).
 			Msg("[JWT] Policy version mismatch")
+		return &PolicyVersionMismatchError{TokenVersion: tokenVersion, CurrentVersion: expectedVersion}
+	}
+
+	return nil
+}
+
+// resolvePolicyVersion retorna la versión esperada para el usuario: la de
+// config.PolicyVersions o, si no está configurada, PolicyVersionGlobal. Si la
+// fuente falla retorna ErrPolicyVersionUnavailable (envolviendo el error de la
+// fuente), salvo con PolicyVersionFailOpen.
+func resolvePolicyVersion(ctx context.Context, config AuthConfig, steamID string) (int, error) {
+	if config.PolicyVersions == nil {
+		return config.PolicyVersionGlobal, nil
+	}
+	version, err := config.PolicyVersions.PolicyVersion(ctx, steamID)
+	if err != nil {
+		log.Error().
+			Err(err).
+			Str("steamid", steamID).
+			Bool("fail_open", config.PolicyVersionFailOpen).
+			Int("fallback_version", config.PolicyVersionGlobal).
+			Msg("[JWT] Policy version source failed")
+		if config.PolicyVersionFailOpen {
+			return config.PolicyVersionGlobal, nil
+		}
+		return 0, fmt.Errorf("%w: %w", ErrPolicyVersionUnavailable, err)
+	}
+	return version, nil
+}
+
+// compatibleError traduce los errores tipados a los errores de v2, que los
+// servicios comparan con ==
+func compatibleError(err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, ErrPolicyVersionMismatch):
 		return ErrPolicyVersionMismatch
+	case errors.Is(err, ErrTokenExpired), errors.Is(err, ErrBadSignature), errors.Is(err, ErrTokenNotYetValid),
+		errors.Is(err, ErrInvalidPermissions):
+		return ErrInvalidToken
+	default:
+		return err
+	}
+}
+
+// classifyParseError traduce los errores de jwt a ValidationError. La firma
+// se verifica antes que exp/nbf, por lo que un token falsificado nunca se
+// reporta como expirado.
+func classifyParseError(err error) error {
+	switch {
+	case errors.Is(err, ErrKeyUnavailable):
+		return ErrKeyUnavailable
+	case errors.Is(err, jwt.ErrTokenSignatureInvalid):
+		return ErrBadSignature
+	case errors.Is(err, jwt.ErrTokenExpired):
+		return ErrTokenExpired
+	case errors.Is(err, jwt.ErrTokenNotValidYet), errors.Is(err, jwt.ErrTokenUsedBeforeIssued):
+		return ErrTokenNotYetValid
+	default:
+		return ErrInvalidToken
+	}
+}
+
+// verificationKey selecciona la clave según el tipo de algoritmo del token
+func verificationKey(ctx context.Context, tok *jwt.Token, config AuthConfig) (any, error) {
+	if _, ok := tok.Method.(*jwt.SigningMethodHMAC); ok {
+		if config.SignerMaterial == "" {
+			log.Error().
+				Str("algorithm", tok.Method.Alg()).
+				Msg("[JWT] HMAC token received but no signer material configured")
+			return nil, ErrInvalidToken
+		}
+		return []byte(config.SignerMaterial), nil
+	}
+
+	if config.Keys == nil {
+		log.Error().
+			Str("algorithm", tok.Method.Alg()).
+			Msg("[JWT] Unexpected signing method")
+		return nil, ErrInvalidToken
+	}
+
+	kid, _ := tok.Header["kid"].(string)
+	var key crypto.PublicKey
+	var err error
+	if keys, ok := config.Keys.(ContextKeySet); ok {
+		key, err = keys.VerificationKeyContext(ctx, kid, tok.Method.Alg())
+	} else {
+		key, err = config.Keys.VerificationKey(kid, tok.Method.Alg())
+	}
+	if err != nil {
+		log.Error().
+			Err(err).
+			Str("kid", kid).
+			Str("algorithm", tok.Method.Alg()).
+			Msg("[JWT] Verification key not available")
+		return nil, err
+	}
+	return key, nil
+}
+
+// validateStandardClaims valida claims requeridos, iss, aud y antigüedad del token.
+// exp, nbf e iat futuro (con ClockSkew y ValidateIssuedAt) los valida el parser de jwt.
+func validateStandardClaims(claims jwt.MapClaims, config AuthConfig, now time.Time) error {
+	required := config.RequiredClaims
+	if config.MaxTokenAge > 0 && !slices.Contains(required, "iat") {
+		required = append(slices.Clone(required), "iat")
+	}
+	for _, name := range required {
+		if _, ok := claims[name]; !ok {
+			return &ValidationError{Type: ErrMissingClaim.Type, Message: ErrMissingClaim.Message + ": " + name}
+		}
+	}
+
+	if len(config.Issuers) > 0 {
+		issuer, err := claims.GetIssuer()
+		if err != nil || !slices.Contains(config.Issuers, issuer) {
+			return ErrInvalidIssuer
+		}
+	}
+
+	if config.Audience != "" {
+		audience, err := claims.GetAudience()
+		if err != nil || !slices.Contains(audience, config.Audience) {
+			return ErrInvalidAudience
+		}
+	}
+
+	if config.MaxTokenAge > 0 {
+		issuedAt, err := claims.GetIssuedAt()
+		if err != nil || issuedAt == nil {
+			return &ValidationError{Type: ErrMissingClaim.Type, Message: ErrMissingClaim.Message + ": iat"}
+		}
+		if now.Sub(issuedAt.Time) > config.MaxTokenAge+config.ClockSkew {
+			return ErrTokenTooOld
+		}
 	}
 
 	return nil
@@ -0,0 +1,182 @@
+package authjwt
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+)
+
+const (
+	wordBits = 64
+	// maxWordFloat es 2^64, el primer float64 que no entra en un uint64
+	maxWordFloat float64 = 1 << 64
+)
+
+// PermissionSet es una máscara de permisos de ancho arbitrario, con el mismo
+// formato de claim que permissions.PermissionSet de authz (este módulo no
+// depende de authz). El bit n equivale a 1<<n en las constantes uint64.
+// Para usar las operaciones de authz: permissions.PermissionSetFromWords(set.Words()...).
+//
+// Es inmutable y el valor cero es un set vacío.
+type PermissionSet struct {
+	words []uint64
+}
+
+// PermissionSetFromMask convierte una máscara uint64 en un set
+func PermissionSetFromMask(mask uint64) PermissionSet {
+	return PermissionSetFromWords(mask)
+}
+
+// PermissionSetFromWords crea un set desde palabras de 64 bits, la menos significativa primero
+func PermissionSetFromWords(words ...uint64) PermissionSet {
+	return PermissionSet{words: trimWords(append([]uint64(nil), words...))}
+}
+
+// Words retorna las palabras de 64 bits del set, la menos significativa primero
+func (s PermissionSet) Words() []uint64 {
+	return append([]uint64(nil), s.words...)
+}
+
+// Mask retorna los bits 0–63 del set; ok es false si usa bits superiores
+func (s PermissionSet) Mask() (mask uint64, ok bool) {
+	if len(s.words) == 0 {
+		return 0, true
+	}
+	return s.words[0], len(s.words) == 1
+}
+
+// IsEmpty indica si el set no tiene permisos
+func (s PermissionSet) IsEmpty() bool {
+	return len(s.words) == 0
+}
+
+// Has verifica si el bit bitIndex está habilitado
+func (s PermissionSet) Has(bitIndex uint) bool {
+	word := int(bitIndex / wordBits)
+	if word >= len(s.words) {
+		return false
+	}
+	return s.words[word]&(1<<(bitIndex%wordBits)) != 0
+}
+
+// HasPermission verifica si el set contiene todos los bits de permission
+func (s PermissionSet) HasPermission(permission PermissionSet) bool {
+	if len(permission.words) > len(s.words) {
+		return false
+	}
+	for i, word := range permission.words {
+		if s.words[i]&word != word {
+			return false
+		}
+	}
+	return true
+}
+
+// ApplyDenyMask quita del set los permisos denegados
+func (s PermissionSet) ApplyDenyMask(deny PermissionSet) PermissionSet {
+	words := append([]uint64(nil), s.words...)
+	for i := 0; i < len(words) && i < len(deny.words); i++ {
+		words[i] &^= deny.words[i]
+	}
+	return PermissionSet{words: trimWords(words)}
+}
+
+// Encode retorna la codificación compacta del claim: base64url sin padding
+// de los bytes little-endian del set, sin ceros finales ("" si está vacío)
+func (s PermissionSet) Encode() string {
+	raw := make([]byte, len(s.words)*8)
+	for i, word := range s.words {
+		binary.LittleEndian.PutUint64(raw[i*8:], word)
+	}
+	return base64.RawURLEncoding.EncodeToString(bytes.TrimRight(raw, "\x00"))
+}
+
+// MarshalJSON codifica el set como su string compacto
+func (s PermissionSet) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.Encode())
+}
+
+// PermissionSetFromClaim decodifica el valor de allow_permissions o
+// deny_permissions tal como queda en jwt.MapClaims: máscara uint64 (número),
+// string compacto (ver Encode) o array de palabras. nil es un set vacío.
+func PermissionSetFromClaim(value any) (PermissionSet, error) {
+	switch v := value.(type) {
+	case nil:
+		return PermissionSet{}, nil
+	case float64:
+		word, err := parseWord(v)
+		if err != nil {
+			return PermissionSet{}, err
+		}
+		return PermissionSetFromMask(word), nil
+	case json.Number:
+		word, err := parseNumberWord(v.String())
+		if err != nil {
+			return PermissionSet{}, err
+		}
+		return PermissionSetFromMask(word), nil
+	case string:
+		raw, err := base64.RawURLEncoding.DecodeString(v)
+		if err != nil {
+			return PermissionSet{}, fmt.Errorf("%w: %v", ErrInvalidPermissions, err)
+		}
+		padded := make([]byte, (len(raw)+7)/8*8)
+		copy(padded, raw)
+		words := make([]uint64, len(padded)/8)
+		for i := range words {
+			words[i] = binary.LittleEndian.Uint64(padded[i*8:])
+		}
+		return PermissionSet{words: trimWords(words)}, nil
+	case []any:
+		words := make([]uint64, len(v))
+		for i, item := range v {
+			var err error
+			switch word := item.(type) {
+			case float64:
+				words[i], err = parseWord(word)
+			case json.Number:
+				words[i], err = parseNumberWord(word.String())
+			default:
+				err = fmt.Errorf("%w: invalid mask word %v", ErrInvalidPermissions, item)
+			}
+			if err != nil {
+				return PermissionSet{}, err
+			}
+		}
+		return PermissionSetFromWords(words...), nil
+	default:
+		return PermissionSet{}, fmt.Errorf("%w: unsupported claim type %T", ErrInvalidPermissions, value)
+	}
+}
+
+func parseWord(number float64) (uint64, error) {
+	if number < 0 || number >= maxWordFloat || number != math.Trunc(number) {
+		return 0, fmt.Errorf("%w: mask %v out of range", ErrInvalidPermissions, number)
+	}
+	return uint64(number), nil
+}
+
+func parseNumberWord(value string) (uint64, error) {
+	if word, err := strconv.ParseUint(value, 10, 64); err == nil {
+		return word, nil
+	}
+	number, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: invalid mask word %q", ErrInvalidPermissions, value)
+	}
+	return parseWord(number)
+}
+
+func trimWords(words []uint64) []uint64 {
+	for len(words) > 0 && words[len(words)-1] == 0 {
+		words = words[:len(words)-1]
+	}
+	if len(words) == 0 {
+		return nil
+	}
+	return words
+}
@@ -0,0 +1,57 @@
+package authjwt
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Revocation consulta si un token con firma y claims válidos fue revocado
+// antes de su exp (por jti, por SteamID emitido antes de una fecha o por
+// policy version del usuario). Implementado por revocation.Memory y
+// revocation/redisstore.
+type Revocation interface {
+	IsRevoked(ctx context.Context, claims *Claims) (bool, error)
+}
+
+var (
+	// ErrTokenRevoked indica que el token fue revocado
+	ErrTokenRevoked = &ValidationError{"token_revoked", "token has been revoked, please re-authenticate"}
+
+	// ErrRevocationUnavailable indica que no se pudo consultar la revocación;
+	// el token no se rechaza por inválido sino porque no se puede verificar
+	ErrRevocationUnavailable = &ValidationError{"revocation_unavailable", "token revocation status is unavailable"}
+)
+
+// CheckRevocation consulta config.Revocation. Retorna ErrTokenRevoked si el
+// token fue revocado y ErrRevocationUnavailable (envolviendo el error del
+// backend) si la consulta falla, salvo con RevocationFailOpen. Sin Revocation
+// configurada retorna nil.
+func CheckRevocation(ctx context.Context, config AuthConfig, claims *Claims) error {
+	if config.Revocation == nil || claims == nil {
+		return nil
+	}
+
+	revoked, err := config.Revocation.IsRevoked(ctx, claims)
+	if err != nil {
+		log.Error().
+			Err(err).
+			Str("steamid", claims.SteamID).
+			Bool("fail_open", config.RevocationFailOpen).
+			Msg("[JWT] Revocation check failed")
+		if config.RevocationFailOpen {
+			return nil
+		}
+		return fmt.Errorf("%w: %w", ErrRevocationUnavailable, err)
+	}
+
+	if revoked {
+		log.Warn().
+			Str("steamid", claims.SteamID).
+			Str("jti", claims.ID).
+			Msg("[JWT] Revoked token rejected")
+		return ErrTokenRevoked
+	}
+	return nil
+}
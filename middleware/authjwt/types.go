@@ -1,13 +1,59 @@
 package authjwt
 
 import (
+	"errors"
+	"time"
+
 	"github.com/AoC-Gamers/connect-libraries/middleware/v2/authconfig"
+	"github.com/golang-jwt/jwt/v5"
 )
 
 // AuthConfig configuración común para autenticación JWT
 type AuthConfig struct {
+	// SignerMaterial es el secreto HMAC compartido; vacío desactiva HS256/384/512
 	SignerMaterial      string
 	PolicyVersionGlobal int
+
+	// PolicyVersions provee la versión por usuario (global + overrides, con
+	// recarga en caliente); si es nil se usa PolicyVersionGlobal
+	PolicyVersions authconfig.PolicyVersionSource
+
+	// PolicyVersionFailOpen valida contra PolicyVersionGlobal si la consulta
+	// de PolicyVersions falla (por defecto se rechaza el token)
+	PolicyVersionFailOpen bool
+
+	// Keys verifica tokens asimétricos (RS256, ES256, EdDSA) con claves de un
+	// PEM (StaticKeySet) o un JWKS (JWKSKeySet); el servicio no puede emitir tokens
+	Keys KeySet
+
+	// Algorithms restringe los algoritmos aceptados. Vacío acepta HS256/384/512
+	// si hay SignerMaterial y DefaultAsymmetricAlgorithms si hay Keys
+	Algorithms []string
+
+	// Issuers son los valores aceptados de `iss`; vacío no valida el emisor
+	Issuers []string
+
+	// Audience debe estar contenido en `aud` (ej: "connect-core"); vacío no valida
+	Audience string
+
+	// ClockSkew es la tolerancia al validar exp, nbf, iat y MaxTokenAge
+	ClockSkew time.Duration
+
+	// ValidateIssuedAt rechaza tokens con iat en el futuro (fuera de ClockSkew)
+	ValidateIssuedAt bool
+
+	// MaxTokenAge rechaza tokens emitidos (iat) hace más de este tiempo; requiere iat
+	MaxTokenAge time.Duration
+
+	// RequiredClaims son claims que deben estar presentes (ej: "iat", "jti")
+	RequiredClaims []string
+
+	// Revocation se consulta en RequireAuth y OptionalAuth después de validar el token
+	Revocation Revocation
+
+	// RevocationFailOpen acepta el token si la consulta de Revocation falla
+	// (por defecto se rechaza)
+	RevocationFailOpen bool
 }
 
 // NewAuthConfig creates an AuthConfig with unified POLICY_VERSION from environment
@@ -28,6 +74,39 @@ func NewAuthConfigWithFallback(jwtSecret string, fallbackVars ...string) AuthCon
 	}
 }
 
+// NewAuthConfigWithKeys crea un AuthConfig que solo acepta tokens asimétricos
+// verificados con keys, con POLICY_VERSION desde el entorno
+func NewAuthConfigWithKeys(keys KeySet) AuthConfig {
+	return AuthConfig{
+		Keys:                keys,
+		PolicyVersionGlobal: authconfig.LoadPolicyVersion(),
+	}
+}
+
+// allowedAlgorithms retorna los algoritmos aceptados por la configuración
+// parserOptions retorna las opciones de validación del parser de jwt
+func (c AuthConfig) parserOptions() []jwt.ParserOption {
+	options := []jwt.ParserOption{jwt.WithValidMethods(c.allowedAlgorithms()), jwt.WithLeeway(c.ClockSkew)}
+	if c.ValidateIssuedAt {
+		options = append(options, jwt.WithIssuedAt())
+	}
+	return options
+}
+
+func (c AuthConfig) allowedAlgorithms() []string {
+	if len(c.Algorithms) > 0 {
+		return c.Algorithms
+	}
+	var algorithms []string
+	if c.SignerMaterial != "" {
+		algorithms = append(algorithms, hmacAlgorithms...)
+	}
+	if c.Keys != nil {
+		algorithms = append(algorithms, DefaultAsymmetricAlgorithms...)
+	}
+	return algorithms
+}
+
 // Claims representa los claims optimizados del JWT
 // Solo contiene información de autenticación y autorización WEB
 type Claims struct {
@@ -37,12 +116,21 @@ type Claims struct {
 
 	// Autorización WEB (único scope incluido en JWT)
 	Role             string `json:"role"`              // Rol para display/logging
-	AllowPermissions uint64 `json:"allow_permissions"` // Permisos permitidos (bitmask)
-	DenyPermissions  uint64 `json:"deny_permissions"`  // Permisos denegados (bitmask)
+	AllowPermissions uint64 `json:"allow_permissions"` // Permisos permitidos (bitmask, bits 0–63)
+	DenyPermissions  uint64 `json:"deny_permissions"`  // Permisos denegados (bitmask, bits 0–63)
+
+	// Máscaras completas, incluidos los bits superiores a 63 de los claims
+	// codificados como PermissionSet
+	AllowPermissionSet PermissionSet `json:"-"`
+	DenyPermissionSet  PermissionSet `json:"-"`
 
 	// JWT standard claims
-	IssuedAt  int64 `json:"iat"` // Unix timestamp
-	ExpiresAt int64 `json:"exp"` // Unix timestamp
+	ID        string   `json:"jti,omitempty"`
+	Issuer    string   `json:"iss,omitempty"`
+	Audience  []string `json:"aud,omitempty"`
+	IssuedAt  int64    `json:"iat"`           // Unix timestamp
+	ExpiresAt int64    `json:"exp"`           // Unix timestamp
+	NotBefore int64    `json:"nbf,omitempty"` // Unix timestamp
 }
 
 // ValidationError tipos de errores de validación
@@ -55,10 +143,60 @@ func (e *ValidationError) Error() string {
 	return e.Message
 }
 
+// Is compara por Type, para usar errors.Is con los errores que llevan detalle
+// (ej: errors.Is(err, ErrMissingClaim) con el claim faltante en el mensaje)
+func (e *ValidationError) Is(target error) bool {
+	t, ok := target.(*ValidationError)
+	return ok && t.Type == e.Type
+}
+
 // Error types
 var (
 	ErrMissingToken          = &ValidationError{"missing_token", "missing authorization token"}
 	ErrInvalidToken          = &ValidationError{"invalid_token", "invalid or expired token"}
+	ErrTokenExpired          = &ValidationError{"token_expired", "token has expired"}
+	ErrTokenNotYetValid      = &ValidationError{"token_not_yet_valid", "token is not valid yet"}
+	ErrBadSignature          = &ValidationError{"bad_signature", "token signature is invalid"}
 	ErrMissingSteamID        = &ValidationError{"missing_steamid", "invalid token claims: missing steamid"}
 	ErrPolicyVersionMismatch = &ValidationError{"policy_mismatch", "token policy version mismatch, please re-authenticate"}
+	ErrInvalidIssuer         = &ValidationError{"invalid_issuer", "token issuer is not trusted"}
+	ErrInvalidAudience       = &ValidationError{"invalid_audience", "token is not intended for this service"}
+	ErrTokenTooOld           = &ValidationError{"token_too_old", "token exceeds maximum age, please re-authenticate"}
+	ErrMissingClaim          = &ValidationError{"missing_claim", "invalid token claims: missing required claim"}
+	ErrInvalidPermissions    = &ValidationError{"invalid_permissions", "invalid token claims: malformed permissions"}
+
+	// ErrPolicyVersionUnavailable indica que la consulta de PolicyVersions
+	// falló; el token no se puede verificar, no es inválido
+	ErrPolicyVersionUnavailable = &ValidationError{"policy_version_unavailable", "policy version is unavailable"}
+
+	// ErrKeyUnavailable indica que las claves de verificación no se pudieron
+	// cargar (ej: JWKS caído o lento); el token no se puede verificar
+	ErrKeyUnavailable = &ValidationError{"key_unavailable", "verification keys are unavailable"}
 )
+
+// PolicyVersionMismatchError es el error de policy version con ambas versiones
+// que retorna ParseAndValidateDetailed. errors.Is(err, ErrPolicyVersionMismatch) es true.
+type PolicyVersionMismatchError struct {
+	TokenVersion   int
+	CurrentVersion int
+}
+
+func (e *PolicyVersionMismatchError) Error() string {
+	return ErrPolicyVersionMismatch.Message
+}
+
+// Is permite errors.Is(err, ErrPolicyVersionMismatch)
+func (e *PolicyVersionMismatchError) Is(target error) bool {
+	return ErrPolicyVersionMismatch.Is(target)
+}
+
+// IsClaimError indica si err es un rechazo por claims estándar (iss, aud,
+// antigüedad o claims requeridos), distinto de firma o expiración
+func IsClaimError(err error) bool {
+	for _, target := range []error{ErrInvalidIssuer, ErrInvalidAudience, ErrTokenTooOld, ErrMissingClaim} {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
@@ -2,6 +2,7 @@ package chi
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"strings"
 
@@ -22,24 +23,26 @@ func RequireAuthWithResponder(config authjwt.AuthConfig, responder ErrorResponde
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			tokenStr := extractToken(r)
 
-			claims, err := authjwt.ParseAndValidate(tokenStr, config.SignerMaterial, config.PolicyVersionGlobal)
+			claims, err := authjwt.ParseAndValidateDetailed(r.Context(), tokenStr, config)
+			if err == nil {
+				err = authjwt.CheckRevocation(r.Context(), config, claims)
+			}
 			if err != nil {
-				// Log del error de autenticación
-				log.Error().
+				// Log del error de autenticación; la versión esperada es la del
+				// usuario (PolicyVersions), no necesariamente la global
+				event := log.Error().
 					Err(err).
 					Str("path", r.URL.Path).
-					Str("method", r.Method).
-					Int("expected_policy_version", config.PolicyVersionGlobal).
-					Msg("JWT authentication failed")
-
-				// Determinar el código de error apropiado
-				if strings.Contains(err.Error(), "expired") {
-					responder.TokenExpired(w)
-				} else if strings.Contains(err.Error(), "policy version") {
-					responder.PolicyVersionMismatch(w, 0, config.PolicyVersionGlobal)
-				} else {
-					responder.Unauthorized(w, "Missing or invalid authentication token")
+					Str("method", r.Method)
+				var mismatch *authjwt.PolicyVersionMismatchError
+				if errors.As(err, &mismatch) {
+					event = event.
+						Int("token_policy_version", mismatch.TokenVersion).
+						Int("expected_policy_version", mismatch.CurrentVersion)
 				}
+				event.Msg("JWT authentication failed")
+
+				respondAuthError(w, responder, err)
 				return
 			}
 
@@ -57,6 +60,52 @@ func RequireAuthWithResponder(config authjwt.AuthConfig, responder ErrorResponde
 	}
 }
 
+// respondAuthError determina el código de error apropiado para un fallo de autenticación
+func respondAuthError(w http.ResponseWriter, responder ErrorResponder, err error) {
+	var (
+		mismatch      *authjwt.PolicyVersionMismatchError
+		validationErr *authjwt.ValidationError
+	)
+
+	switch {
+	case errors.Is(err, authjwt.ErrRevocationUnavailable):
+		respondServiceUnavailable(w, responder, "revocation")
+	case errors.Is(err, authjwt.ErrPolicyVersionUnavailable):
+		respondServiceUnavailable(w, responder, "policy_version")
+	case errors.Is(err, authjwt.ErrKeyUnavailable):
+		respondServiceUnavailable(w, responder, "jwks")
+	case errors.Is(err, authjwt.ErrTokenExpired):
+		responder.TokenExpired(w)
+	case errors.As(err, &mismatch):
+		responder.PolicyVersionMismatch(w, mismatch.TokenVersion, mismatch.CurrentVersion)
+	case isTokenClaimsError(err) && errors.As(err, &validationErr):
+		if claimsResponder, ok := responder.(ClaimsErrorResponder); ok {
+			claimsResponder.InvalidTokenClaims(w, validationErr.Type, validationErr.Message)
+		} else {
+			responder.Unauthorized(w, validationErr.Message)
+		}
+	default:
+		responder.Unauthorized(w, "Missing or invalid authentication token")
+	}
+}
+
+// respondServiceUnavailable responde 503 con el responder si implementa
+// ServiceUnavailableResponder o con DefaultErrorResponder
+func respondServiceUnavailable(w http.ResponseWriter, responder ErrorResponder, service string) {
+	if unavailable, ok := responder.(ServiceUnavailableResponder); ok {
+		unavailable.ServiceUnavailable(w, service)
+		return
+	}
+	DefaultErrorResponder{}.ServiceUnavailable(w, service)
+}
+
+// isTokenClaimsError indica si el token fue rechazado por sus claims o revocado
+func isTokenClaimsError(err error) bool {
+	return authjwt.IsClaimError(err) ||
+		errors.Is(err, authjwt.ErrTokenNotYetValid) ||
+		errors.Is(err, authjwt.ErrTokenRevoked)
+}
+
 // RequireRole middleware de autorización por roles para Chi
 func RequireRole(allowedRoles ...string) func(http.Handler) http.Handler {
 	return RequireRoleWithResponder(nil, allowedRoles...)
@@ -122,6 +171,39 @@ func RequirePermissionBitmaskWithResponder(permission uint64, responder ErrorRes
 	}
 }
 
+// PermissionExplainer construye la explicación sanitizada de un chequeo de permisos
+// fallido (ej: authz.Explainer("WEB")). Solo debe devolver datos seguros para el cliente.
+type PermissionExplainer func(role string, allowMask, denyMask, requiredPermission uint64) map[string]interface{}
+
+// RequirePermissionBitmaskExplained funciona como RequirePermissionBitmaskWithResponder pero,
+// cuando el chequeo falla y el responder implementa ExplainedErrorResponder, incluye la
+// explicación del explainer en la respuesta 403
+func RequirePermissionBitmaskExplained(permission uint64, responder ErrorResponder, explainer PermissionExplainer) func(http.Handler) http.Handler {
+	responder = ensureResponder(responder)
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			claims := GetClaimsFromContext(r)
+			if claims == nil {
+				responder.Unauthorized(w, "authentication required")
+				return
+			}
+
+			if !claims.HasPermission(permission) {
+				explained, ok := responder.(ExplainedErrorResponder)
+				if !ok || explainer == nil {
+					responder.InsufficientPermissions(w, "required permission bitmask")
+					return
+				}
+				meta := explainer(claims.Role, claims.AllowPermissions, claims.DenyPermissions, permission)
+				explained.InsufficientPermissionsWithMeta(w, "required permission bitmask", meta)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
 // RequireAdmin middleware que requiere rol de administrador
 func RequireAdmin() func(http.Handler) http.Handler {
 	return RequireRole("admin", "super_admin")
@@ -138,7 +220,10 @@ func OptionalAuth(config authjwt.AuthConfig) func(http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			tokenStr := extractToken(r)
 			if tokenStr != "" {
-				claims, err := authjwt.ParseAndValidate(tokenStr, config.SignerMaterial, config.PolicyVersionGlobal)
+				claims, err := authjwt.ParseAndValidateDetailed(r.Context(), tokenStr, config)
+				if err == nil {
+					err = authjwt.CheckRevocation(r.Context(), config, claims)
+				}
 				if err == nil {
 					ctx := context.WithValue(r.Context(), authcontext.SteamIDKey, claims.GetSteamID())
 					ctx = context.WithValue(ctx, authcontext.RoleKey, claims.GetRole())
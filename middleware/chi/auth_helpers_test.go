@@ -2,6 +2,13 @@ package chi
 
 import (
 	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -9,6 +16,7 @@ import (
 
 	"github.com/AoC-Gamers/connect-libraries/middleware/v2/authcontext"
 	"github.com/AoC-Gamers/connect-libraries/middleware/v2/authjwt"
+	"github.com/AoC-Gamers/connect-libraries/middleware/v2/revocation"
 	"github.com/golang-jwt/jwt/v5"
 )
 
@@ -19,6 +27,7 @@ type fakeResponder struct {
 	tokenExpiredCalled            bool
 	policyVersionMismatchCalled   bool
 	insufficientPermissionsCalled bool
+	tokenVersion, currentVersion  int
 }
 
 func (f *fakeResponder) Unauthorized(w http.ResponseWriter, detail string) {
@@ -33,6 +42,7 @@ func (f *fakeResponder) TokenExpired(w http.ResponseWriter) {
 
 func (f *fakeResponder) PolicyVersionMismatch(w http.ResponseWriter, tokenVersion, currentVersion int) {
 	f.policyVersionMismatchCalled = true
+	f.tokenVersion, f.currentVersion = tokenVersion, currentVersion
 	w.WriteHeader(http.StatusUnauthorized)
 }
 
@@ -161,6 +171,48 @@ func TestRequireRoleAndPermissionErrors(t *testing.T) {
 	}
 }
 
+func TestRequirePermissionBitmaskExplained(t *testing.T) {
+	claims := &authjwt.Claims{Role: "team_user", AllowPermissions: 1, DenyPermissions: 2}
+	ctx := context.WithValue(context.Background(), authcontext.ClaimsKey, claims)
+	req := httptest.NewRequest(http.MethodGet, "/teams/1", nil).WithContext(ctx)
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatalf("did not expect next handler to be called")
+	})
+
+	var gotRole string
+	var gotRequired uint64
+	explainer := func(role string, allowMask, denyMask, requiredPermission uint64) map[string]interface{} {
+		gotRole, gotRequired = role, requiredPermission
+		return map[string]interface{}{"reason": "missing_permissions"}
+	}
+
+	rr := httptest.NewRecorder()
+	RequirePermissionBitmaskExplained(4, nil, explainer)(next).ServeHTTP(rr, req)
+	if rr.Code != http.StatusForbidden {
+		t.Fatalf("expected 403, got %d", rr.Code)
+	}
+	if gotRole != "team_user" || gotRequired != 4 {
+		t.Fatalf("unexpected explainer input role=%s required=%d", gotRole, gotRequired)
+	}
+
+	var response ErrorResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+		t.Fatalf("could not parse response: %v", err)
+	}
+	decision, ok := response.Meta["decision"].(map[string]interface{})
+	if !ok || decision["reason"] != "missing_permissions" {
+		t.Fatalf("expected decision metadata, got %v", response.Meta)
+	}
+
+	// Responders without ExplainedErrorResponder fall back to InsufficientPermissions
+	responder := &fakeResponder{}
+	rr = httptest.NewRecorder()
+	RequirePermissionBitmaskExplained(4, responder, explainer)(next).ServeHTTP(rr, req)
+	if !responder.insufficientPermissionsCalled {
+		t.Fatalf("expected fallback to InsufficientPermissions")
+	}
+}
+
 func TestRequireAndOptionalAuth(t *testing.T) {
 	secret := "chi-jwt-secret"
 	policyVersion := 3
@@ -218,3 +270,226 @@ func mustSignChiToken(t *testing.T, secret string, claims jwt.MapClaims) string
 	}
 	return signed
 }
+
+func TestRequireAuthMapsClaimErrors(t *testing.T) {
+	secret := "chi-jwt-secret"
+	token := mustSignChiToken(t, secret, jwt.MapClaims{
+		"steamid": "76561198000000999",
+		"aud":     "connect-lobby",
+		"exp":     float64(time.Now().Add(time.Hour).Unix()),
+	})
+	config := authjwt.AuthConfig{SignerMaterial: secret, PolicyVersionGlobal: 1, Audience: "connect-core"}
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("handler should not be called")
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/secure", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	rr := httptest.NewRecorder()
+	RequireAuth(config)(next).ServeHTTP(rr, req)
+
+	var resp ErrorResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("could not parse response: %v", err)
+	}
+	if rr.Code != http.StatusUnauthorized || resp.Code != CodeTokenInvalid || resp.Meta["reason"] != "invalid_audience" {
+		t.Fatalf("unexpected response %d %+v", rr.Code, resp)
+	}
+
+	// Sin ClaimsErrorResponder se usa Unauthorized
+	responder := &fakeResponder{}
+	RequireAuthWithResponder(config, responder)(next).ServeHTTP(httptest.NewRecorder(), req)
+	if !responder.unauthorizedCalled {
+		t.Fatal("expected fallback to Unauthorized")
+	}
+}
+
+type failingRevocation struct{}
+
+func (failingRevocation) IsRevoked(context.Context, *authjwt.Claims) (bool, error) {
+	return false, errors.New("redis unavailable")
+}
+
+type failingPolicySource struct{}
+
+func (failingPolicySource) PolicyVersion(context.Context, string) (int, error) {
+	return 0, errors.New("redis unavailable")
+}
+
+func TestRequireAuthRejectsWhenPolicyVersionUnavailable(t *testing.T) {
+	secret := "chi-jwt-secret"
+	token := mustSignChiToken(t, secret, jwt.MapClaims{
+		"steamid":        "76561198000000001",
+		"policy_version": 1,
+		"exp":            time.Now().Add(time.Hour).Unix(),
+	})
+	called := false
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	})
+	serve := func(cfg authjwt.AuthConfig) *httptest.ResponseRecorder {
+		called = false
+		req := httptest.NewRequest(http.MethodGet, "/secure", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		rr := httptest.NewRecorder()
+		RequireAuth(cfg)(next).ServeHTTP(rr, req)
+		return rr
+	}
+
+	config := authjwt.AuthConfig{SignerMaterial: secret, PolicyVersionGlobal: 1, PolicyVersions: failingPolicySource{}}
+	rr := serve(config)
+	var resp ErrorResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("could not parse response: %v", err)
+	}
+	if called || rr.Code != http.StatusServiceUnavailable || resp.Code != "SERVICE_UNAVAILABLE" || resp.Meta["service"] != "policy_version" {
+		t.Fatalf("expected fail-closed with 503, status=%d resp=%+v", rr.Code, resp)
+	}
+
+	config.PolicyVersionFailOpen = true
+	if rr := serve(config); !called || rr.Code != http.StatusOK {
+		t.Fatalf("expected fail-open against the global version, status=%d", rr.Code)
+	}
+}
+
+type unavailableKeys struct{}
+
+func (unavailableKeys) VerificationKey(string, string) (crypto.PublicKey, error) {
+	return nil, fmt.Errorf("%w: jwks: unexpected status 502", authjwt.ErrKeyUnavailable)
+}
+
+func TestRequireAuthRejectsWhenKeysUnavailable(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	token, err := jwt.NewWithClaims(jwt.SigningMethodES256, jwt.MapClaims{
+		"steamid": "76561198000000001",
+		"exp":     time.Now().Add(time.Hour).Unix(),
+	}).SignedString(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/secure", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	rr := httptest.NewRecorder()
+	RequireAuth(authjwt.AuthConfig{Keys: unavailableKeys{}})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("handler should not run without verification keys")
+	})).ServeHTTP(rr, req)
+
+	var resp ErrorResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("could not parse response: %v", err)
+	}
+	if rr.Code != http.StatusServiceUnavailable || resp.Meta["service"] != "jwks" {
+		t.Fatalf("expected 503 for unavailable keys, status=%d resp=%+v", rr.Code, resp)
+	}
+}
+
+func TestRequireAuthConsultsRevocation(t *testing.T) {
+	secret := "chi-jwt-secret"
+	token := mustSignChiToken(t, secret, jwt.MapClaims{
+		"steamid": "76561198000000999",
+		"jti":     "jti-1",
+		"iat":     float64(time.Now().Unix()),
+		"exp":     float64(time.Now().Add(time.Hour).Unix()),
+	})
+	store := revocation.NewMemory(time.Hour)
+	config := authjwt.AuthConfig{SignerMaterial: secret, PolicyVersionGlobal: 1, Revocation: store}
+
+	called := false
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		w.WriteHeader(http.StatusOK)
+	})
+	serve := func(cfg authjwt.AuthConfig) *httptest.ResponseRecorder {
+		called = false
+		req := httptest.NewRequest(http.MethodGet, "/secure", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		rr := httptest.NewRecorder()
+		RequireAuth(cfg)(next).ServeHTTP(rr, req)
+		return rr
+	}
+
+	if rr := serve(config); !called || rr.Code != http.StatusOK {
+		t.Fatalf("expected token to pass before revocation, status=%d", rr.Code)
+	}
+
+	if err := store.RevokeToken(context.Background(), "jti-1", time.Time{}); err != nil {
+		t.Fatal(err)
+	}
+	rr := serve(config)
+	var resp ErrorResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("could not parse response: %v", err)
+	}
+	if called || rr.Code != http.StatusUnauthorized || resp.Meta["reason"] != "token_revoked" {
+		t.Fatalf("expected revoked token to be rejected, status=%d resp=%+v", rr.Code, resp)
+	}
+
+	// OptionalAuth continúa sin autenticar
+	optionalReq := httptest.NewRequest(http.MethodGet, "/optional", nil)
+	optionalReq.Header.Set("Authorization", "Bearer "+token)
+	OptionalAuth(config)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if GetClaimsFromContext(r) != nil {
+			t.Fatal("expected revoked token to be ignored by OptionalAuth")
+		}
+	})).ServeHTTP(httptest.NewRecorder(), optionalReq)
+
+	// Fallo del backend: rechaza con 503 por defecto, acepta con RevocationFailOpen
+	failing := authjwt.AuthConfig{SignerMaterial: secret, PolicyVersionGlobal: 1, Revocation: failingRevocation{}}
+	rr = serve(failing)
+	resp = ErrorResponse{}
+	if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("could not parse response: %v", err)
+	}
+	if called || rr.Code != http.StatusServiceUnavailable || resp.Code != "SERVICE_UNAVAILABLE" || resp.Meta["service"] != "revocation" {
+		t.Fatalf("expected fail-closed with 503, status=%d resp=%+v", rr.Code, resp)
+	}
+	failing.RevocationFailOpen = true
+	if rr := serve(failing); !called || rr.Code != http.StatusOK {
+		t.Fatalf("expected fail-open, status=%d", rr.Code)
+	}
+}
+
+func TestRequireAuthClassifiesErrors(t *testing.T) {
+	secret := "chi-jwt-secret"
+	now := time.Now()
+	config := authjwt.AuthConfig{SignerMaterial: secret, PolicyVersionGlobal: 4}
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("handler should not be called")
+	})
+	serve := func(token string) *fakeResponder {
+		responder := &fakeResponder{}
+		req := httptest.NewRequest(http.MethodGet, "/secure", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		RequireAuthWithResponder(config, responder)(next).ServeHTTP(httptest.NewRecorder(), req)
+		return responder
+	}
+
+	expired := serve(mustSignChiToken(t, secret, jwt.MapClaims{
+		"steamid": "76561198000000999",
+		"exp":     float64(now.Add(-time.Minute).Unix()),
+	}))
+	if !expired.tokenExpiredCalled {
+		t.Fatalf("expected TokenExpired, got %+v", expired)
+	}
+
+	mismatch := serve(mustSignChiToken(t, secret, jwt.MapClaims{
+		"steamid":        "76561198000000999",
+		"policy_version": float64(3),
+		"exp":            float64(now.Add(time.Hour).Unix()),
+	}))
+	if !mismatch.policyVersionMismatchCalled || mismatch.tokenVersion != 3 || mismatch.currentVersion != 4 {
+		t.Fatalf("expected PolicyVersionMismatch(3, 4), got %+v", mismatch)
+	}
+
+	forged := serve(mustSignChiToken(t, "other-secret", jwt.MapClaims{
+		"steamid": "76561198000000999",
+		"exp":     float64(now.Add(-time.Minute).Unix()),
+	}))
+	if !forged.unauthorizedCalled || forged.tokenExpiredCalled {
+		t.Fatalf("expected Unauthorized for bad signature, got %+v", forged)
+	}
+}
@@ -15,11 +15,18 @@ const (
 	// CodeTokenExpired indica que el JWT ha expirado
 	CodeTokenExpired ErrorCode = "TOKEN_EXPIRED"
 
+	// CodeTokenInvalid indica que el JWT fue rechazado por sus claims
+	CodeTokenInvalid ErrorCode = "TOKEN_INVALID"
+
 	// CodePolicyVersionMismatch indica mismatch entre token y política actual
 	CodePolicyVersionMismatch ErrorCode = "POLICY_VERSION_MISMATCH"
 
 	// CodeInsufficientPermissions indica permisos insuficientes en general
 	CodeInsufficientPermissions ErrorCode = "INSUFFICIENT_PERMISSIONS"
+
+	// CodeServiceUnavailable indica que no se pudo verificar el token porque
+	// una dependencia (revocación, policy versions) no está disponible
+	CodeServiceUnavailable ErrorCode = "SERVICE_UNAVAILABLE"
 )
 
 // ErrorResponse representa una respuesta de error estructurada
@@ -41,7 +48,9 @@ type ErrorResponse struct {
 	Meta map[string]interface{} `json:"meta,omitempty"`
 }
 
-// ErrorResponder permite desacoplar las respuestas de error
+// ErrorResponder permite desacoplar las respuestas de error. Es un subconjunto
+// de responder.ErrorResponder: responder.Default, errors.AuthResponder o
+// cualquier implementación compartida con apikey sirve sin adaptadores.
 type ErrorResponder interface {
 	Unauthorized(w http.ResponseWriter, detail string)
 	TokenExpired(w http.ResponseWriter)
@@ -49,6 +58,27 @@ type ErrorResponder interface {
 	InsufficientPermissions(w http.ResponseWriter, action string)
 }
 
+// ExplainedErrorResponder es implementado opcionalmente por un ErrorResponder
+// que puede incluir la explicación de una decisión de autorización en el 403
+type ExplainedErrorResponder interface {
+	InsufficientPermissionsWithMeta(w http.ResponseWriter, action string, meta map[string]interface{})
+}
+
+// ClaimsErrorResponder es implementado opcionalmente por un ErrorResponder que
+// distingue los rechazos por claims estándar (issuer, audience, antigüedad o
+// claims requeridos); reason es el Type del authjwt.ValidationError
+type ClaimsErrorResponder interface {
+	InvalidTokenClaims(w http.ResponseWriter, reason, detail string)
+}
+
+// ServiceUnavailableResponder es implementado opcionalmente por un
+// ErrorResponder que responde 503 cuando no se puede verificar el token (falla
+// el backend de revocación o la fuente de policy versions). Si no lo implementa
+// se usa DefaultErrorResponder.ServiceUnavailable.
+type ServiceUnavailableResponder interface {
+	ServiceUnavailable(w http.ResponseWriter, service string)
+}
+
 // DefaultErrorResponder implementa respuestas estándar JSON
 type DefaultErrorResponder struct{}
 
@@ -75,6 +105,17 @@ func (DefaultErrorResponder) TokenExpired(w http.ResponseWriter) {
 		})
 }
 
+// InvalidTokenClaims responde 401 cuando el token fue rechazado por sus claims
+func (DefaultErrorResponder) InvalidTokenClaims(w http.ResponseWriter, reason, detail string) {
+	respondError(w, http.StatusUnauthorized, CodeTokenInvalid,
+		"unauthorized",
+		"JWT token is invalid: "+detail,
+		map[string]interface{}{
+			"reason":        reason,
+			"should_reauth": true,
+		})
+}
+
 // PolicyVersionMismatch responde cuando hay mismatch de policy version
 func (DefaultErrorResponder) PolicyVersionMismatch(w http.ResponseWriter, tokenVersion, currentVersion int) {
 	respondError(w, http.StatusUnauthorized, CodePolicyVersionMismatch,
@@ -94,6 +135,31 @@ func (DefaultErrorResponder) InsufficientPermissions(w http.ResponseWriter, acti
 		"You don't have permission to "+action)
 }
 
+// InsufficientPermissionsWithMeta responde con permisos insuficientes incluyendo
+// meta["decision"] con la explicación sanitizada
+func (DefaultErrorResponder) InsufficientPermissionsWithMeta(w http.ResponseWriter, action string, meta map[string]interface{}) {
+	var responseMeta map[string]interface{}
+	if meta != nil {
+		responseMeta = map[string]interface{}{"decision": meta}
+	}
+	respondError(w, http.StatusForbidden, CodeInsufficientPermissions,
+		"insufficient permissions",
+		"You don't have permission to "+action,
+		responseMeta)
+}
+
+// ServiceUnavailable responde 503 cuando una dependencia de la autenticación
+// no está disponible
+func (DefaultErrorResponder) ServiceUnavailable(w http.ResponseWriter, service string) {
+	respondError(w, http.StatusServiceUnavailable, CodeServiceUnavailable,
+		"service unavailable",
+		"Service '"+service+"' is temporarily unavailable",
+		map[string]interface{}{
+			"service":     service,
+			"retry_after": 60,
+		})
+}
+
 const (
 	responderHeaderContentType = "Content-Type"
 	responderMimeJSON          = "application/json"
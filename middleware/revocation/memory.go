@@ -0,0 +1,146 @@
+package revocation
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/AoC-Gamers/connect-libraries/middleware/v2/authjwt"
+)
+
+// Memory es un Store en memoria del proceso. Cada instancia del servicio
+// tiene su propio estado y lo pierde al reiniciar: natssync (NATS Core) no
+// reenvía los eventos publicados antes de suscribirse, así que un servicio
+// con varias instancias o reinicios debe usar redisstore. Memory con natssync
+// solo sirve para desarrollo o cuando perder revocaciones en un reinicio es
+// aceptable. Las entradas vencidas se eliminan con Run (o Sweep).
+type Memory struct {
+	retention time.Duration
+	now       func() time.Time
+
+	mu       sync.RWMutex
+	tokens   map[string]time.Time // jti → expiración de la entrada
+	users    map[string]expiring  // steamID → issued-before
+	policies map[string]expiring  // steamID → policy version mínima
+}
+
+type expiring struct {
+	value   int64
+	expires time.Time
+}
+
+var _ Store = (*Memory)(nil)
+
+// DefaultSweepInterval es cada cuánto Run elimina las entradas vencidas
+const DefaultSweepInterval = time.Minute
+
+// NewMemory crea un Memory; retention <= 0 usa DefaultRetention
+func NewMemory(retention time.Duration) *Memory {
+	if retention <= 0 {
+		retention = DefaultRetention
+	}
+	return &Memory{
+		retention: retention,
+		now:       time.Now,
+		tokens:    make(map[string]time.Time),
+		users:     make(map[string]expiring),
+		policies:  make(map[string]expiring),
+	}
+}
+
+// IsRevoked implementa authjwt.Revocation
+func (m *Memory) IsRevoked(_ context.Context, claims *authjwt.Claims) (bool, error) {
+	now := m.now()
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var state State
+	if claims.ID != "" {
+		if expires, ok := m.tokens[claims.ID]; ok && now.Before(expires) {
+			state.TokenRevoked = true
+		}
+	}
+	if entry, ok := m.users[claims.SteamID]; ok && now.Before(entry.expires) {
+		state.RevokedBefore = entry.value
+	}
+	if entry, ok := m.policies[claims.SteamID]; ok && now.Before(entry.expires) {
+		state.MinPolicyVersion = int(entry.value)
+	}
+	return state.Revokes(claims), nil
+}
+
+// RevokeToken implementa Store; sin expiresAt la entrada dura retention
+func (m *Memory) RevokeToken(_ context.Context, jti string, expiresAt time.Time) error {
+	now := m.now()
+	if expiresAt.IsZero() {
+		expiresAt = now.Add(m.retention)
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.tokens[jti] = expiresAt
+	return nil
+}
+
+// RevokeUser implementa Store; conserva la fecha más reciente
+func (m *Memory) RevokeUser(_ context.Context, steamID string, issuedBefore time.Time) error {
+	m.setMax(m.users, steamID, issuedBefore.Unix())
+	return nil
+}
+
+// SetUserPolicyVersion implementa Store; conserva la versión más alta
+func (m *Memory) SetUserPolicyVersion(_ context.Context, steamID string, version int) error {
+	m.setMax(m.policies, steamID, int64(version))
+	return nil
+}
+
+func (m *Memory) setMax(entries map[string]expiring, steamID string, value int64) {
+	now := m.now()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if current, ok := entries[steamID]; ok && current.value > value {
+		value = current.value
+	}
+	entries[steamID] = expiring{value: value, expires: now.Add(m.retention)}
+}
+
+// Run elimina las entradas vencidas cada interval hasta que ctx se cancele;
+// un interval no positivo usa DefaultSweepInterval. Las revocaciones no
+// barren el mapa para no recorrerlo en cada escritura.
+func (m *Memory) Run(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = DefaultSweepInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			m.Sweep()
+		}
+	}
+}
+
+// Sweep elimina las entradas vencidas
+func (m *Memory) Sweep() {
+	now := m.now()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.sweepLocked(now)
+}
+
+func (m *Memory) sweepLocked(now time.Time) {
+	for jti, expires := range m.tokens {
+		if !now.Before(expires) {
+			delete(m.tokens, jti)
+		}
+	}
+	for _, entries := range []map[string]expiring{m.users, m.policies} {
+		for steamID, entry := range entries {
+			if !now.Before(entry.expires) {
+				delete(entries, steamID)
+			}
+		}
+	}
+}
@@ -0,0 +1,81 @@
+// Package natssync aplica en un revocation.Store las revocaciones publicadas
+// por Connect-Auth en NATS, para que un baneo tenga efecto inmediato.
+package natssync
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/AoC-Gamers/connect-libraries/middleware/v2/revocation"
+	natsio "github.com/nats-io/nats.go"
+	"github.com/rs/zerolog/log"
+)
+
+// applyTimeout limita cada escritura en el store
+const applyTimeout = 5 * time.Second
+
+// Subscribe suscribe el store a subject (revocation.DefaultSubject si está
+// vacío) con NATS Core, de modo que cada instancia recibe todos los eventos.
+// Los eventos inválidos se loguean y se descartan. NATS Core no reenvía los
+// eventos publicados mientras la instancia estaba caída: con revocation.Memory
+// el estado empieza vacío en cada reinicio, así que en producción usar un
+// store persistente como redisstore.
+func Subscribe(conn *natsio.Conn, subject string, store revocation.Store) (*natsio.Subscription, error) {
+	if conn == nil {
+		return nil, fmt.Errorf("NATS connection is nil")
+	}
+	if subject == "" {
+		subject = revocation.DefaultSubject
+	}
+
+	sub, err := conn.Subscribe(subject, func(msg *natsio.Msg) {
+		if err := handleMessage(store, msg.Data); err != nil {
+			log.Error().
+				Err(err).
+				Str("subject", msg.Subject).
+				Msg("[JWT] Failed to apply revocation event")
+		}
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to subscribe to %s: %w", subject, err)
+	}
+	return sub, nil
+}
+
+// Publish publica un evento de revocación (usado por Connect-Auth)
+func Publish(conn *natsio.Conn, subject string, event revocation.Event) error {
+	if conn == nil {
+		return fmt.Errorf("NATS connection is nil")
+	}
+	if subject == "" {
+		subject = revocation.DefaultSubject
+	}
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	return conn.Publish(subject, data)
+}
+
+func handleMessage(store revocation.Store, data []byte) error {
+	var event revocation.Event
+	if err := json.Unmarshal(data, &event); err != nil {
+		return fmt.Errorf("invalid revocation event: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), applyTimeout)
+	defer cancel()
+	if err := revocation.Apply(ctx, store, event); err != nil {
+		return err
+	}
+
+	log.Info().
+		Str("type", event.Type).
+		Str("steamid", event.Data.SteamID).
+		Str("jti", event.Data.JTI).
+		Str("reason", event.Data.Reason).
+		Msg("[JWT] Revocation applied")
+	return nil
+}
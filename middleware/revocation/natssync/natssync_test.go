@@ -0,0 +1,35 @@
+package natssync
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/AoC-Gamers/connect-libraries/middleware/v2/authjwt"
+	"github.com/AoC-Gamers/connect-libraries/middleware/v2/revocation"
+)
+
+func TestHandleMessage(t *testing.T) {
+	store := revocation.NewMemory(time.Hour)
+
+	// Sobre publicado con PublishEventCore del módulo nats
+	envelope := `{"type":"user_revoked","version":1,"data":{"steamid":"76561198000000001","reason":"sanction"},"meta":{"ts":1,"by":"connect-auth"}}`
+	if err := handleMessage(store, []byte(envelope)); err != nil {
+		t.Fatalf("handleMessage: %v", err)
+	}
+
+	claims := &authjwt.Claims{SteamID: "76561198000000001", IssuedAt: time.Now().Add(-time.Minute).Unix()}
+	if revoked, _ := store.IsRevoked(context.Background(), claims); !revoked {
+		t.Fatal("expected user revoked after event")
+	}
+
+	if err := handleMessage(store, []byte("not json")); err == nil {
+		t.Fatal("expected error for invalid payload")
+	}
+	if err := handleMessage(store, []byte(`{"type":"user_revoked","data":{}}`)); err == nil {
+		t.Fatal("expected error for event without steamid")
+	}
+	if _, err := Subscribe(nil, "", store); err == nil {
+		t.Fatal("expected error for nil connection")
+	}
+}
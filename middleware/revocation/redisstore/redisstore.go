@@ -0,0 +1,129 @@
+// Package redisstore implementa revocation.Store sobre Redis, compartiendo las
+// revocaciones entre todas las instancias de un servicio.
+package redisstore
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/AoC-Gamers/connect-libraries/middleware/v2/authjwt"
+	"github.com/AoC-Gamers/connect-libraries/middleware/v2/revocation"
+	"github.com/redis/go-redis/v9"
+)
+
+// DefaultPrefix es el prefijo de las claves en Redis
+const DefaultPrefix = "connect:revocation:"
+
+// Options configura el Store
+type Options struct {
+	// Prefix de las claves (default DefaultPrefix)
+	Prefix string
+
+	// Retention de las revocaciones por usuario y policy versions
+	// (default revocation.DefaultRetention)
+	Retention time.Duration
+}
+
+// Store es un revocation.Store respaldado por Redis. Claves:
+//   - {prefix}jti:{jti}: token revocado, con TTL hasta su exp
+//   - {prefix}user:{steamid}: Unix timestamp issued-before
+//   - {prefix}policy:{steamid}: policy version mínima
+type Store struct {
+	client    redis.UniversalClient
+	prefix    string
+	retention time.Duration
+}
+
+var _ revocation.Store = (*Store)(nil)
+
+// setMaxScript guarda ARGV[1] solo si es mayor que el valor actual
+var setMaxScript = redis.NewScript(`
+local current = tonumber(redis.call('GET', KEYS[1]))
+local value = tonumber(ARGV[1])
+if current == nil or current < value then
+	redis.call('SET', KEYS[1], ARGV[1], 'EX', ARGV[2])
+else
+	redis.call('EXPIRE', KEYS[1], ARGV[2])
+end
+return 1
+`)
+
+// New crea un Store sobre un cliente de go-redis
+func New(client redis.UniversalClient, opts Options) *Store {
+	if opts.Prefix == "" {
+		opts.Prefix = DefaultPrefix
+	}
+	if opts.Retention <= 0 {
+		opts.Retention = revocation.DefaultRetention
+	}
+	return &Store{client: client, prefix: opts.Prefix, retention: opts.Retention}
+}
+
+// IsRevoked implementa authjwt.Revocation con un único MGET
+func (s *Store) IsRevoked(ctx context.Context, claims *authjwt.Claims) (bool, error) {
+	keys := []string{s.userKey(claims.SteamID), s.policyKey(claims.SteamID)}
+	if claims.ID != "" {
+		keys = append(keys, s.tokenKey(claims.ID))
+	}
+
+	values, err := s.client.MGet(ctx, keys...).Result()
+	if err != nil {
+		return false, err
+	}
+
+	state := revocation.State{
+		RevokedBefore:    parseInt(values[0]),
+		MinPolicyVersion: int(parseInt(values[1])),
+	}
+	if len(values) > 2 && values[2] != nil {
+		state.TokenRevoked = true
+	}
+	return state.Revokes(claims), nil
+}
+
+// RevokeToken implementa revocation.Store
+func (s *Store) RevokeToken(ctx context.Context, jti string, expiresAt time.Time) error {
+	ttl := s.retention
+	if !expiresAt.IsZero() {
+		ttl = time.Until(expiresAt)
+		if ttl <= 0 {
+			return nil // el token ya expiró
+		}
+	}
+	return s.client.Set(ctx, s.tokenKey(jti), "1", ttl).Err()
+}
+
+// RevokeUser implementa revocation.Store; conserva la fecha más reciente
+func (s *Store) RevokeUser(ctx context.Context, steamID string, issuedBefore time.Time) error {
+	return s.setMax(ctx, s.userKey(steamID), issuedBefore.Unix())
+}
+
+// SetUserPolicyVersion implementa revocation.Store; conserva la versión más alta
+func (s *Store) SetUserPolicyVersion(ctx context.Context, steamID string, version int) error {
+	return s.setMax(ctx, s.policyKey(steamID), int64(version))
+}
+
+func (s *Store) setMax(ctx context.Context, key string, value int64) error {
+	seconds := int64(s.retention / time.Second)
+	if seconds < 1 {
+		seconds = 1
+	}
+	return setMaxScript.Run(ctx, s.client, []string{key}, value, seconds).Err()
+}
+
+func (s *Store) tokenKey(jti string) string      { return s.prefix + "jti:" + jti }
+func (s *Store) userKey(steamID string) string   { return s.prefix + "user:" + steamID }
+func (s *Store) policyKey(steamID string) string { return s.prefix + "policy:" + steamID }
+
+func parseInt(value interface{}) int64 {
+	str, ok := value.(string)
+	if !ok {
+		return 0
+	}
+	parsed, err := strconv.ParseInt(str, 10, 64)
+	if err != nil {
+		return 0
+	}
+	return parsed
+}
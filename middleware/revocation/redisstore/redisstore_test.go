@@ -0,0 +1,70 @@
+package redisstore
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/AoC-Gamers/connect-libraries/middleware/v2/authjwt"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+func TestStore(t *testing.T) {
+	ctx := context.Background()
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	defer func() { _ = client.Close() }()
+
+	store := New(client, Options{Retention: time.Hour})
+	now := time.Now()
+	claims := &authjwt.Claims{ID: "jti-1", SteamID: "76561198000000001", PolicyVersion: 2, IssuedAt: now.Add(-time.Minute).Unix()}
+
+	if revoked, err := store.IsRevoked(ctx, claims); err != nil || revoked {
+		t.Fatalf("expected not revoked, got %v %v", revoked, err)
+	}
+
+	if err := store.RevokeToken(ctx, "jti-1", now.Add(time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+	if revoked, _ := store.IsRevoked(ctx, claims); !revoked {
+		t.Fatal("expected token revoked by jti")
+	}
+	if ttl := server.TTL(DefaultPrefix + "jti:jti-1"); ttl <= 0 || ttl > time.Minute {
+		t.Fatalf("expected TTL until token exp, got %s", ttl)
+	}
+	server.FastForward(2 * time.Minute)
+	if revoked, _ := store.IsRevoked(ctx, claims); revoked {
+		t.Fatal("expected jti revocation to expire with the token")
+	}
+
+	if err := store.RevokeUser(ctx, claims.SteamID, now); err != nil {
+		t.Fatal(err)
+	}
+	_ = store.RevokeUser(ctx, claims.SteamID, now.Add(-time.Hour))
+	if revoked, _ := store.IsRevoked(ctx, claims); !revoked {
+		t.Fatal("expected user revocation (latest timestamp kept)")
+	}
+
+	fresh := &authjwt.Claims{SteamID: claims.SteamID, PolicyVersion: 2, IssuedAt: now.Add(time.Second).Unix()}
+	if revoked, _ := store.IsRevoked(ctx, fresh); revoked {
+		t.Fatal("expected token issued after revocation to be accepted")
+	}
+	if err := store.SetUserPolicyVersion(ctx, claims.SteamID, 3); err != nil {
+		t.Fatal(err)
+	}
+	_ = store.SetUserPolicyVersion(ctx, claims.SteamID, 1)
+	if revoked, _ := store.IsRevoked(ctx, fresh); !revoked {
+		t.Fatal("expected old policy version to be revoked (highest version kept)")
+	}
+
+	server.FastForward(2 * time.Hour)
+	if revoked, _ := store.IsRevoked(ctx, fresh); revoked {
+		t.Fatal("expected user entries to expire after retention")
+	}
+
+	server.SetError("unavailable")
+	if _, err := store.IsRevoked(ctx, fresh); err == nil {
+		t.Fatal("expected backend error")
+	}
+}
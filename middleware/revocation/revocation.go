@@ -0,0 +1,118 @@
+// Package revocation implementa authjwt.Revocation: revocación de tokens por
+// jti, de todos los tokens de un SteamID emitidos antes de una fecha y por
+// policy version mínima de un usuario.
+//
+// Memory guarda el estado en el proceso; redisstore lo comparte entre
+// instancias y natssync aplica las revocaciones publicadas por Connect-Auth.
+package revocation
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/AoC-Gamers/connect-libraries/middleware/v2/authjwt"
+)
+
+// DefaultRetention es cuánto se conservan las revocaciones por usuario y las
+// policy versions; debe ser mayor o igual a la vida máxima de un token
+const DefaultRetention = 24 * time.Hour
+
+// Store es un authjwt.Revocation que además registra revocaciones
+type Store interface {
+	authjwt.Revocation
+
+	// RevokeToken revoca un token por jti hasta su expiración
+	RevokeToken(ctx context.Context, jti string, expiresAt time.Time) error
+
+	// RevokeUser revoca los tokens del usuario emitidos en o antes de
+	// issuedBefore, truncado a segundos (incluye los de ese mismo segundo)
+	RevokeUser(ctx context.Context, steamID string, issuedBefore time.Time) error
+
+	// SetUserPolicyVersion rechaza los tokens del usuario con policy_version menor
+	SetUserPolicyVersion(ctx context.Context, steamID string, version int) error
+}
+
+// State es el estado de revocación que aplica a un token
+type State struct {
+	// TokenRevoked indica que el jti fue revocado
+	TokenRevoked bool
+
+	// RevokedBefore es el Unix timestamp hasta el que se revocan los tokens del usuario
+	RevokedBefore int64
+
+	// MinPolicyVersion es la policy version mínima aceptada para el usuario
+	MinPolicyVersion int
+}
+
+// Revokes indica si el estado revoca los claims. Un token sin iat se
+// considera emitido antes de cualquier revocación por usuario. iat y
+// RevokedBefore tienen precisión de segundos, así que un token emitido en el
+// mismo segundo que la revocación también se revoca: es preferible que el
+// usuario vuelva a autenticarse a aceptar un token emitido justo antes.
+func (s State) Revokes(claims *authjwt.Claims) bool {
+	if s.TokenRevoked {
+		return true
+	}
+	if s.RevokedBefore > 0 && claims.IssuedAt <= s.RevokedBefore {
+		return true
+	}
+	return s.MinPolicyVersion > 0 && claims.PolicyVersion < s.MinPolicyVersion
+}
+
+// Subject y tipos de los eventos de revocación publicados por Connect-Auth
+const (
+	DefaultSubject         = "connect.auth.revocations"
+	EventTokenRevoked      = "token_revoked"
+	EventUserRevoked       = "user_revoked"
+	EventUserPolicyVersion = "user_policy_version"
+)
+
+// Event es un evento de revocación. Es compatible con el sobre Event del
+// módulo nats (PublishEventCore(subject, tipo, EventData{...})).
+type Event struct {
+	Type string    `json:"type"`
+	Data EventData `json:"data"`
+}
+
+// EventData contiene los datos de la revocación según el tipo de evento
+type EventData struct {
+	JTI           string `json:"jti,omitempty"`
+	SteamID       string `json:"steamid,omitempty"`
+	ExpiresAt     int64  `json:"exp,omitempty"`           // token_revoked
+	IssuedBefore  int64  `json:"issued_before,omitempty"` // user_revoked; 0 = ahora
+	PolicyVersion int    `json:"policy_version,omitempty"`
+	Reason        string `json:"reason,omitempty"`
+}
+
+// Apply registra el evento en el store
+func Apply(ctx context.Context, store Store, event Event) error {
+	data := event.Data
+	switch event.Type {
+	case EventTokenRevoked:
+		if data.JTI == "" {
+			return fmt.Errorf("revocation: %s without jti", event.Type)
+		}
+		var expiresAt time.Time
+		if data.ExpiresAt > 0 {
+			expiresAt = time.Unix(data.ExpiresAt, 0)
+		}
+		return store.RevokeToken(ctx, data.JTI, expiresAt)
+	case EventUserRevoked:
+		if data.SteamID == "" {
+			return fmt.Errorf("revocation: %s without steamid", event.Type)
+		}
+		issuedBefore := time.Now()
+		if data.IssuedBefore > 0 {
+			issuedBefore = time.Unix(data.IssuedBefore, 0)
+		}
+		return store.RevokeUser(ctx, data.SteamID, issuedBefore)
+	case EventUserPolicyVersion:
+		if data.SteamID == "" || data.PolicyVersion < 1 {
+			return fmt.Errorf("revocation: %s requires steamid and policy_version", event.Type)
+		}
+		return store.SetUserPolicyVersion(ctx, data.SteamID, data.PolicyVersion)
+	default:
+		return fmt.Errorf("revocation: unknown event type %q", event.Type)
+	}
+}
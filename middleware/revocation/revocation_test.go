@@ -0,0 +1,164 @@
+package revocation
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/AoC-Gamers/connect-libraries/middleware/v2/authjwt"
+)
+
+const steamID = "76561198000000001"
+
+func TestMemoryRevocations(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	store := NewMemory(time.Hour)
+	store.now = func() time.Time { return now }
+
+	token := &authjwt.Claims{ID: "jti-1", SteamID: steamID, PolicyVersion: 2, IssuedAt: now.Add(-time.Minute).Unix()}
+	if revoked, _ := store.IsRevoked(ctx, token); revoked {
+		t.Fatal("expected token not revoked")
+	}
+
+	if err := store.RevokeToken(ctx, "jti-1", now.Add(time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+	if revoked, _ := store.IsRevoked(ctx, token); !revoked {
+		t.Fatal("expected token revoked by jti")
+	}
+	other := &authjwt.Claims{ID: "jti-2", SteamID: steamID, PolicyVersion: 2, IssuedAt: token.IssuedAt}
+	if revoked, _ := store.IsRevoked(ctx, other); revoked {
+		t.Fatal("expected other jti not revoked")
+	}
+
+	// Revocación por usuario: solo tokens emitidos en o antes de la fecha
+	if err := store.RevokeUser(ctx, steamID, now.Add(-30*time.Second)); err != nil {
+		t.Fatal(err)
+	}
+	if revoked, _ := store.IsRevoked(ctx, other); !revoked {
+		t.Fatal("expected token issued before revocation to be revoked")
+	}
+	fresh := &authjwt.Claims{SteamID: steamID, PolicyVersion: 2, IssuedAt: now.Unix()}
+	if revoked, _ := store.IsRevoked(ctx, fresh); revoked {
+		t.Fatal("expected token issued after revocation to be accepted")
+	}
+	// Una fecha anterior no reemplaza a la más reciente
+	_ = store.RevokeUser(ctx, steamID, now.Add(-time.Hour))
+	if revoked, _ := store.IsRevoked(ctx, other); !revoked {
+		t.Fatal("expected latest issued-before to be kept")
+	}
+
+	// Policy version por usuario
+	if err := store.SetUserPolicyVersion(ctx, steamID, 3); err != nil {
+		t.Fatal(err)
+	}
+	if revoked, _ := store.IsRevoked(ctx, fresh); !revoked {
+		t.Fatal("expected token with old policy version to be revoked")
+	}
+	if revoked, _ := store.IsRevoked(ctx, &authjwt.Claims{SteamID: steamID, PolicyVersion: 3, IssuedAt: now.Unix()}); revoked {
+		t.Fatal("expected token with current policy version to be accepted")
+	}
+
+	// Las entradas vencen con la retención y la exp del token
+	now = now.Add(2 * time.Hour)
+	if revoked, _ := store.IsRevoked(ctx, token); revoked {
+		t.Fatal("expected revocations to expire")
+	}
+	_ = store.RevokeToken(ctx, "jti-3", time.Time{})
+	if len(store.users) != 1 || len(store.policies) != 1 {
+		t.Fatal("expected revocations not to sweep expired entries")
+	}
+	store.Sweep()
+	if len(store.tokens) != 1 || len(store.users) != 0 || len(store.policies) != 0 {
+		t.Fatalf("expected expired entries to be swept, got %d/%d/%d", len(store.tokens), len(store.users), len(store.policies))
+	}
+}
+
+func TestUserRevocationSameSecond(t *testing.T) {
+	ctx := context.Background()
+	revokedAt := time.Unix(1_700_000_000, 600_000_000)
+	store := NewMemory(time.Hour)
+	store.now = func() time.Time { return revokedAt }
+	_ = store.RevokeUser(ctx, steamID, revokedAt)
+
+	// iat tiene precisión de segundos: un token emitido en el mismo segundo,
+	// antes o después de la revocación, no se puede distinguir y se revoca
+	for _, issuedAt := range []time.Time{revokedAt.Add(-500 * time.Millisecond), revokedAt.Add(300 * time.Millisecond)} {
+		claims := &authjwt.Claims{SteamID: steamID, IssuedAt: issuedAt.Unix()}
+		if revoked, _ := store.IsRevoked(ctx, claims); !revoked {
+			t.Fatalf("expected token issued at %s to be revoked", issuedAt)
+		}
+	}
+	next := &authjwt.Claims{SteamID: steamID, IssuedAt: revokedAt.Unix() + 1}
+	if revoked, _ := store.IsRevoked(ctx, next); revoked {
+		t.Fatal("expected token issued in the next second to be accepted")
+	}
+}
+
+func TestMemoryRunSweeps(t *testing.T) {
+	store := NewMemory(time.Hour)
+	_ = store.RevokeToken(context.Background(), "jti-1", time.Now().Add(-time.Second))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		store.Run(ctx, time.Millisecond)
+		close(done)
+	}()
+	deadline := time.Now().Add(time.Second)
+	for {
+		store.mu.RLock()
+		n := len(store.tokens)
+		store.mu.RUnlock()
+		if n == 0 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("expected Run to sweep expired entries")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	cancel()
+	<-done
+}
+
+func TestApply(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemory(0)
+	now := time.Now()
+
+	events := []Event{
+		{Type: EventTokenRevoked, Data: EventData{JTI: "jti-1", ExpiresAt: now.Add(time.Hour).Unix()}},
+		{Type: EventUserRevoked, Data: EventData{SteamID: "76561198000000002", Reason: "sanction"}},
+		{Type: EventUserPolicyVersion, Data: EventData{SteamID: "76561198000000003", PolicyVersion: 5}},
+	}
+	for _, event := range events {
+		if err := Apply(ctx, store, event); err != nil {
+			t.Fatalf("Apply(%s): %v", event.Type, err)
+		}
+	}
+
+	checks := []*authjwt.Claims{
+		{ID: "jti-1", SteamID: steamID, IssuedAt: now.Unix()},
+		{SteamID: "76561198000000002", IssuedAt: now.Add(-time.Second).Unix()},
+		{SteamID: "76561198000000003", PolicyVersion: 4, IssuedAt: now.Unix()},
+	}
+	for _, claims := range checks {
+		if revoked, _ := store.IsRevoked(ctx, claims); !revoked {
+			t.Fatalf("expected %+v to be revoked", claims)
+		}
+	}
+
+	invalid := []Event{
+		{Type: EventTokenRevoked},
+		{Type: EventUserRevoked},
+		{Type: EventUserPolicyVersion, Data: EventData{SteamID: steamID}},
+		{Type: "unknown"},
+	}
+	for _, event := range invalid {
+		if err := Apply(ctx, store, event); err == nil {
+			t.Fatalf("expected error for %+v", event)
+		}
+	}
+}
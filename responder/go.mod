@@ -0,0 +1,3 @@
+module github.com/AoC-Gamers/connect-libraries/responder
+
+go 1.26.0
@@ -0,0 +1,189 @@
+// Package responder define la interfaz de respuestas de error de autenticación
+// y autorización compartida por apikey, middleware/chi y errors.
+//
+// El módulo no tiene dependencias. Las interfaces ErrorResponder de apikey y
+// middleware/chi son subconjuntos de ErrorResponder, por lo que una misma
+// implementación (Default, errors.AuthResponder o una propia) configura ambos
+// middlewares sin adaptadores ni imports entre módulos.
+package responder
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+)
+
+// Códigos de error enviados por Default; coinciden con errors.ErrorCode
+const (
+	CodeUnauthorized            = "UNAUTHORIZED"
+	CodeTokenExpired            = "TOKEN_EXPIRED"
+	CodeTokenInvalid            = "TOKEN_INVALID"
+	CodePolicyVersionMismatch   = "POLICY_VERSION_MISMATCH"
+	CodeInsufficientPermissions = "INSUFFICIENT_PERMISSIONS"
+	CodeRateLimitExceeded       = "RATE_LIMIT_EXCEEDED"
+	CodeServiceUnavailable      = "SERVICE_UNAVAILABLE"
+)
+
+// ErrorResponder escribe las respuestas de error de los middlewares de
+// autenticación, autorización y rate limiting
+type ErrorResponder interface {
+	// Unauthorized responde 401 genérico (credenciales faltantes o inválidas)
+	Unauthorized(w http.ResponseWriter, detail string)
+
+	// TokenExpired responde 401 cuando el JWT expiró
+	TokenExpired(w http.ResponseWriter)
+
+	// PolicyVersionMismatch responde 401 cuando el token usa otra versión de política
+	PolicyVersionMismatch(w http.ResponseWriter, tokenVersion, currentVersion int)
+
+	// InsufficientPermissions responde 403 para la acción indicada
+	InsufficientPermissions(w http.ResponseWriter, action string)
+
+	// RateLimited responde 429; retryAfter en segundos (0 omite Retry-After)
+	RateLimited(w http.ResponseWriter, limit int, window string, retryAfter int)
+}
+
+// ExplainedErrorResponder es implementado opcionalmente por un ErrorResponder
+// que puede incluir la explicación de una decisión de autorización en el 403
+type ExplainedErrorResponder interface {
+	InsufficientPermissionsWithMeta(w http.ResponseWriter, action string, meta map[string]interface{})
+}
+
+// ClaimsErrorResponder es implementado opcionalmente por un ErrorResponder que
+// distingue los rechazos por claims estándar (issuer, audience, antigüedad o
+// claims requeridos); reason es el tipo del error (ej: "invalid_audience")
+type ClaimsErrorResponder interface {
+	InvalidTokenClaims(w http.ResponseWriter, reason, detail string)
+}
+
+// ServiceUnavailableResponder es implementado opcionalmente por un
+// ErrorResponder que responde 503 cuando una dependencia necesaria para
+// autenticar (ej: el backend de revocación) no está disponible
+type ServiceUnavailableResponder interface {
+	ServiceUnavailable(w http.ResponseWriter, service string)
+}
+
+// Default implementa ErrorResponder con el formato ErrorResponse de errors
+// (application/json) usando solo la librería estándar
+type Default struct{}
+
+var (
+	_ ErrorResponder              = Default{}
+	_ ExplainedErrorResponder     = Default{}
+	_ ClaimsErrorResponder        = Default{}
+	_ ServiceUnavailableResponder = Default{}
+)
+
+// Ensure retorna responder o Default si es nil
+func Ensure(responder ErrorResponder) ErrorResponder {
+	if responder == nil {
+		return Default{}
+	}
+	return responder
+}
+
+// Unauthorized responde con 401 genérico
+func (Default) Unauthorized(w http.ResponseWriter, detail string) {
+	Write(w, http.StatusUnauthorized, CodeUnauthorized, "unauthorized", detail, nil)
+}
+
+// TokenExpired responde cuando el JWT ha expirado
+func (Default) TokenExpired(w http.ResponseWriter) {
+	Write(w, http.StatusUnauthorized, CodeTokenExpired,
+		"unauthorized",
+		"JWT token has expired",
+		map[string]interface{}{
+			"should_refresh": true,
+		})
+}
+
+// InvalidTokenClaims responde 401 cuando el token fue rechazado por sus claims
+func (Default) InvalidTokenClaims(w http.ResponseWriter, reason, detail string) {
+	Write(w, http.StatusUnauthorized, CodeTokenInvalid,
+		"unauthorized",
+		"JWT token is invalid: "+detail,
+		map[string]interface{}{
+			"reason":        reason,
+			"should_reauth": true,
+		})
+}
+
+// PolicyVersionMismatch responde cuando hay mismatch de policy version
+func (Default) PolicyVersionMismatch(w http.ResponseWriter, tokenVersion, currentVersion int) {
+	Write(w, http.StatusUnauthorized, CodePolicyVersionMismatch,
+		"policy version mismatch",
+		"Token policy version does not match current version",
+		map[string]interface{}{
+			"token_version":   tokenVersion,
+			"current_version": currentVersion,
+			"should_reauth":   true,
+		})
+}
+
+// InsufficientPermissions responde con permisos insuficientes
+func (Default) InsufficientPermissions(w http.ResponseWriter, action string) {
+	Write(w, http.StatusForbidden, CodeInsufficientPermissions,
+		"insufficient permissions",
+		"You don't have permission to "+action, nil)
+}
+
+// InsufficientPermissionsWithMeta responde con permisos insuficientes incluyendo
+// meta["decision"] con la explicación sanitizada
+func (Default) InsufficientPermissionsWithMeta(w http.ResponseWriter, action string, meta map[string]interface{}) {
+	var responseMeta map[string]interface{}
+	if meta != nil {
+		responseMeta = map[string]interface{}{"decision": meta}
+	}
+	Write(w, http.StatusForbidden, CodeInsufficientPermissions,
+		"insufficient permissions",
+		"You don't have permission to "+action,
+		responseMeta)
+}
+
+// RateLimited responde con rate limit excedido y el header Retry-After
+func (Default) RateLimited(w http.ResponseWriter, limit int, window string, retryAfter int) {
+	if retryAfter > 0 {
+		w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+	}
+	Write(w, http.StatusTooManyRequests, CodeRateLimitExceeded,
+		"rate limit exceeded",
+		"Rate limit of "+strconv.Itoa(limit)+" requests per "+window+" exceeded",
+		map[string]interface{}{
+			"limit":       limit,
+			"window":      window,
+			"retry_after": retryAfter,
+		})
+}
+
+// ServiceUnavailable responde 503 cuando una dependencia no está disponible
+func (Default) ServiceUnavailable(w http.ResponseWriter, service string) {
+	Write(w, http.StatusServiceUnavailable, CodeServiceUnavailable,
+		"service unavailable",
+		"Service '"+service+"' is temporarily unavailable",
+		map[string]interface{}{
+			"service":     service,
+			"retry_after": 60,
+		})
+}
+
+// errorResponse es el formato ErrorResponse de errors
+type errorResponse struct {
+	Error  string                 `json:"error"`
+	Code   string                 `json:"code,omitempty"`
+	Status int                    `json:"status"`
+	Detail string                 `json:"detail,omitempty"`
+	Meta   map[string]interface{} `json:"meta,omitempty"`
+}
+
+// Write escribe una respuesta con el formato ErrorResponse de errors
+func Write(w http.ResponseWriter, status int, code, message, detail string, meta map[string]interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(errorResponse{
+		Error:  message,
+		Code:   code,
+		Status: status,
+		Detail: detail,
+		Meta:   meta,
+	})
+}
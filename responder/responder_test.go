@@ -0,0 +1,69 @@
+package responder
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func decode(t *testing.T, rr *httptest.ResponseRecorder) errorResponse {
+	t.Helper()
+	var resp errorResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("could not parse response: %v", err)
+	}
+	return resp
+}
+
+func TestDefaultResponses(t *testing.T) {
+	cases := []struct {
+		name   string
+		call   func(w http.ResponseWriter)
+		status int
+		code   string
+	}{
+		{"unauthorized", func(w http.ResponseWriter) { Default{}.Unauthorized(w, "missing token") }, http.StatusUnauthorized, CodeUnauthorized},
+		{"token expired", func(w http.ResponseWriter) { Default{}.TokenExpired(w) }, http.StatusUnauthorized, CodeTokenExpired},
+		{"invalid claims", func(w http.ResponseWriter) { Default{}.InvalidTokenClaims(w, "invalid_audience", "wrong audience") }, http.StatusUnauthorized, CodeTokenInvalid},
+		{"policy mismatch", func(w http.ResponseWriter) { Default{}.PolicyVersionMismatch(w, 1, 2) }, http.StatusUnauthorized, CodePolicyVersionMismatch},
+		{"insufficient permissions", func(w http.ResponseWriter) { Default{}.InsufficientPermissions(w, "edit") }, http.StatusForbidden, CodeInsufficientPermissions},
+		{"rate limited", func(w http.ResponseWriter) { Default{}.RateLimited(w, 100, "minute", 30) }, http.StatusTooManyRequests, CodeRateLimitExceeded},
+		{"service unavailable", func(w http.ResponseWriter) { Default{}.ServiceUnavailable(w, "revocation") }, http.StatusServiceUnavailable, CodeServiceUnavailable},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			tc.call(rr)
+
+			resp := decode(t, rr)
+			if rr.Code != tc.status || resp.Status != tc.status || resp.Code != tc.code {
+				t.Fatalf("unexpected response %d %+v", rr.Code, resp)
+			}
+			if rr.Header().Get("Content-Type") != "application/json" {
+				t.Fatalf("unexpected content type %q", rr.Header().Get("Content-Type"))
+			}
+		})
+	}
+}
+
+func TestRateLimitedSetsRetryAfter(t *testing.T) {
+	rr := httptest.NewRecorder()
+	Default{}.RateLimited(rr, 10, "second", 5)
+
+	resp := decode(t, rr)
+	if rr.Header().Get("Retry-After") != "5" || resp.Detail != "Rate limit of 10 requests per second exceeded" {
+		t.Fatalf("unexpected rate limit response %q %+v", rr.Header().Get("Retry-After"), resp)
+	}
+}
+
+func TestEnsureFallsBackToDefault(t *testing.T) {
+	if _, ok := Ensure(nil).(Default); !ok {
+		t.Fatalf("expected Default for nil responder")
+	}
+	custom := Default{}
+	if Ensure(custom) != custom {
+		t.Fatalf("expected custom responder to be kept")
+	}
+}
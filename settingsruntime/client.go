@@ -12,10 +12,19 @@ import (
 )
 
 type CoreHTTPClient struct {
-	baseURL string
-	apiKey  string
-	client  *http.Client
-	baseURI *url.URL
+	baseURL      string
+	apiKey       string
+	client       *http.Client
+	baseURI      *url.URL
+	errorDecoder func(*http.Response) error
+}
+
+// SetErrorDecoder sets how error responses (status >= 400) are decoded, e.g.
+// errors.FromResponse from connect-errors. The decoded error is wrapped with
+// %w so callers can inspect its code; without a decoder only the status is
+// reported.
+func (c *CoreHTTPClient) SetErrorDecoder(decode func(*http.Response) error) {
+	c.errorDecoder = decode
 }
 
 func NewCoreHTTPClient(baseURL, apiKey string, timeout time.Duration) (*CoreHTTPClient, error) {
@@ -79,7 +88,7 @@ func (c *CoreHTTPClient) Health(ctx context.Context) error {
 	defer func() { _ = resp.Body.Close() }()
 
 	if resp.StatusCode >= http.StatusBadRequest {
-		return fmt.Errorf("core health check returned status %d", resp.StatusCode)
+		return c.statusError(resp, "core health check")
 	}
 
 	return nil
@@ -119,7 +128,7 @@ func (c *CoreHTTPClient) GetSettingValue(ctx context.Context, entity, key string
 	defer func() { _ = resp.Body.Close() }()
 
 	if resp.StatusCode >= http.StatusBadRequest {
-		return "", fmt.Errorf("get setting %s/%s returned status %d", entity, key, resp.StatusCode)
+		return "", c.statusError(resp, fmt.Sprintf("get setting %s/%s", entity, key))
 	}
 
 	var payload struct {
@@ -132,6 +141,15 @@ func (c *CoreHTTPClient) GetSettingValue(ctx context.Context, entity, key string
 	return payload.Value, nil
 }
 
+func (c *CoreHTTPClient) statusError(resp *http.Response, operation string) error {
+	if c.errorDecoder != nil {
+		if err := c.errorDecoder(resp); err != nil {
+			return fmt.Errorf("%s returned status %d: %w", operation, resp.StatusCode, err)
+		}
+	}
+	return fmt.Errorf("%s returned status %d", operation, resp.StatusCode)
+}
+
 func (c *CoreHTTPClient) buildInternalURL(path string) (string, error) {
 	if c == nil || c.baseURI == nil {
 		return "", errors.New("core HTTP client base URL is not initialized")
@@ -2,8 +2,11 @@ package settingsruntime
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 )
@@ -62,3 +65,41 @@ func TestCoreHTTPClient_GetSettingValue(t *testing.T) {
 		t.Fatalf("unexpected value: %q", value)
 	}
 }
+
+type decodedError struct {
+	code string
+}
+
+func (e decodedError) Error() string { return e.code }
+
+func TestCoreHTTPClient_ErrorDecoder(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"code":"INTERNAL_NOT_FOUND","message":"resource not found","service":"connect-core","status":404}`))
+	}))
+	defer server.Close()
+
+	client, err := NewCoreHTTPClient(server.URL, "", time.Second)
+	if err != nil {
+		t.Fatalf("unexpected create error: %v", err)
+	}
+
+	if _, err := client.GetSettingValue(context.Background(), "CONFIG", "missing"); err == nil || !strings.Contains(err.Error(), "returned status 404") {
+		t.Fatalf("expected status error without decoder, got %v", err)
+	}
+
+	client.SetErrorDecoder(func(resp *http.Response) error {
+		var body struct {
+			Code string `json:"code"`
+		}
+		_ = json.NewDecoder(resp.Body).Decode(&body)
+		return decodedError{code: body.Code}
+	})
+
+	_, err = client.GetSettingValue(context.Background(), "CONFIG", "missing")
+	var decoded decodedError
+	if !errors.As(err, &decoded) || decoded.code != "INTERNAL_NOT_FOUND" {
+		t.Fatalf("expected decoded error to be wrapped, got %v", err)
+	}
+}